@@ -0,0 +1,66 @@
+// Package cmd provides the CLI commands for the application.
+package cmd
+
+import (
+	"os"
+
+	"charm.land/lipgloss/v2"
+	"github.com/spf13/cobra"
+
+	"scaffold/config"
+	"scaffold/internal/output"
+	"scaffold/internal/ui/theme"
+)
+
+// outputFormat holds the --output flag value.
+var outputFormat string
+
+// cliConfig returns the config non-interactive commands read their
+// preferences from: the config file with the profile in effect applied
+// (--profile, else the active one), the file alone when the profile does
+// not load, and defaults when the file does not.
+func cliConfig() *config.Config {
+	path := GetConfigFile()
+	base, err := config.Load(path)
+	if err != nil {
+		return config.DefaultConfig()
+	}
+	name := base.Profile
+	if WasProfileSet() {
+		name = GetProfile()
+	}
+	if cfg, err := config.LoadProfile(path, name); err == nil {
+		return cfg
+	}
+	return base
+}
+
+// newPrinter returns a Printer writing to cmd's output in the format given
+// by --output, or by cfg.UI.OutputFormat when the flag is not set. Tables
+// use the configured theme for the terminal's background.
+func newPrinter(cmd *cobra.Command, cfg *config.Config) (*output.Printer, error) {
+	name := cfg.UI.OutputFormat
+	if cmd.Flags().Changed("output") {
+		name = outputFormat
+	}
+	format, err := output.ParseFormat(name)
+	if err != nil {
+		return nil, err
+	}
+	// Only tables are colored; skip the background query otherwise.
+	var palette theme.Palette
+	if format == output.FormatTable {
+		isDark := lipgloss.HasDarkBackground(os.Stdin, os.Stdout)
+		palette = theme.NewPalette(cfg.UI.ThemeName, isDark)
+	}
+	return output.New(cmd.OutOrStdout(), format, palette), nil
+}
+
+// completeOutputFormats offers the supported --output values.
+func completeOutputFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := make([]string, len(output.Formats))
+	for i, f := range output.Formats {
+		names[i] = string(f)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
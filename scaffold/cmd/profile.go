@@ -0,0 +1,142 @@
+// Package cmd provides the CLI commands for the application.
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"scaffold/config"
+	"scaffold/internal/output"
+)
+
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named configuration profiles",
+	Long: `Profiles are partial config files stored in profiles/<name>.json next to
+the base config. The active profile is merged on top of the base config at
+startup; --profile selects a different one for a single run.`,
+	Example: `  scaffold profile create staging
+  scaffold profile use staging
+  scaffold profile list
+  scaffold profile use          # clear the active profile`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Disable UI execution for this subcommand and its children
+		runUI = false
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles (the active one is marked with *)",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := GetConfigFile()
+		names, err := config.ListProfiles(path)
+		if err != nil {
+			return err
+		}
+		p, err := newPrinter(cmd, cliConfig())
+		if err != nil {
+			return err
+		}
+		if len(names) == 0 && p.Format() == output.FormatText {
+			fmt.Fprintln(cmd.OutOrStdout(), "No profiles. Create one with: scaffold profile create <name>")
+			return nil
+		}
+		active := activeProfile(path)
+		records := make([]profileInfo, len(names))
+		for i, name := range names {
+			records[i] = profileInfo{Name: name, Active: name == active}
+		}
+		return p.Print(records)
+	},
+}
+
+// profileInfo is the record printed by profile list.
+type profileInfo struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+// Text marks the active profile with "*".
+func (p profileInfo) Text() string {
+	if p.Active {
+		return "* " + p.Name
+	}
+	return "  " + p.Name
+}
+
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an empty profile that inherits the base config",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := GetConfigFile()
+		if err := config.CreateProfile(path, args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Created profile %q at %s\n", args[0], config.ProfilePath(path, args[0]))
+		return nil
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:               "delete <name>",
+	Short:             "Delete a profile",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProfiles,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := config.DeleteProfile(GetConfigFile(), args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Deleted profile %q\n", args[0])
+		return nil
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:               "use [name]",
+	Short:             "Set the profile applied on startup (no name clears it)",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProfiles,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) == 1 {
+			name = args[0]
+		}
+		if err := config.SetActiveProfile(GetConfigFile(), name); err != nil {
+			return err
+		}
+		if name == "" {
+			fmt.Fprintln(cmd.OutOrStdout(), "Cleared the active profile")
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "Active profile: %s\n", name)
+		}
+		return nil
+	},
+}
+
+// activeProfile returns the active profile recorded in the base config at
+// path, or "" when there is none or the config cannot be read.
+func activeProfile(path string) string {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return ""
+	}
+	return cfg.Profile
+}
+
+// completeProfiles offers existing profile names for shell completion.
+func completeProfiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names, _ := config.ListProfiles(GetConfigFile())
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	profileCmd.AddCommand(profileListCmd, profileCreateCmd, profileDeleteCmd, profileUseCmd)
+	rootCmd.AddCommand(profileCmd)
+}
@@ -18,6 +18,9 @@ var (
 	// skipWelcome suppresses the first-run welcome screen.
 	skipWelcome bool
 
+	// profile selects a named config profile overlay for this run.
+	profile string
+
 	// logLevel sets the logging verbosity.
 	logLevel string
 
@@ -46,11 +49,17 @@ This template includes:
   # Run with custom config file
   scaffold --config /path/to/config.json
 
+  # Run against the "staging" profile
+  scaffold --profile staging
+
   # Run with debug logging
   scaffold --debug --log-level trace
 
   # Show version information
-  scaffold version`,
+  scaffold version
+
+  # Print command results as JSON
+  scaffold version --output json`,
 	Version: "1.0.0",
 	// Run executes the root command.
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -96,6 +105,11 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false,
 		"Enable debug mode with trace logging")
 
+	// Profile flag
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "",
+		"Config profile to apply on top of the base config (overrides the active profile)")
+	_ = rootCmd.RegisterFlagCompletionFunc("profile", completeProfiles)
+
 	// Skip welcome screen flag
 	rootCmd.PersistentFlags().BoolVar(&skipWelcome, "skip-welcome", false,
 		"Skip the first-run welcome screen")
@@ -103,6 +117,11 @@ func init() {
 	// Log level flag
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info",
 		"Set logging level (trace, debug, info, warn, error, fatal)")
+
+	// Output format flag
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "",
+		"Output format for command results: text, json, ndjson or table (default: ui.outputFormat from config)")
+	_ = rootCmd.RegisterFlagCompletionFunc("output", completeOutputFormats)
 }
 
 // GetConfigFile returns the path to the configuration file, computing default if needed.
@@ -123,6 +142,17 @@ func SkipWelcome() bool {
 	return skipWelcome
 }
 
+// GetProfile returns the value of the --profile flag.
+func GetProfile() string {
+	return profile
+}
+
+// WasProfileSet reports whether --profile was explicitly passed on the command line.
+// An explicit empty value disables the base config's active profile for this run.
+func WasProfileSet() bool {
+	return rootCmd.PersistentFlags().Changed("profile")
+}
+
 // WasLogLevelSet reports whether --log-level was explicitly passed on the command line.
 // Use this to distinguish an explicit flag from Cobra's default value.
 func WasLogLevelSet() bool {
@@ -0,0 +1,167 @@
+// Package cmd provides the CLI commands for the application.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"scaffold/config"
+	"scaffold/internal/ui/theme"
+)
+
+var themeCmd = &cobra.Command{
+	Use:   "theme",
+	Short: "Manage user color themes",
+	Long: `User themes are .json or .toml files in themes/ next to the config file.
+They are loaded at startup and can be reloaded from the command palette.`,
+	Example: `  scaffold theme import ~/Downloads/gruvbox-dark.yaml
+  scaffold theme import Dracula.itermcolors --name dracula
+  scaffold theme export ember --format css > ember.css
+  scaffold theme export --format base16 --light`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Disable UI execution for this subcommand and its children
+		runUI = false
+	},
+}
+
+var (
+	// importName overrides the name of an imported theme.
+	importName string
+
+	// importFormat forces the scheme format instead of detecting it.
+	importFormat string
+
+	// importForce allows an import to replace an existing theme file.
+	importForce bool
+)
+
+var themeImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a terminal or editor color scheme as a user theme",
+	Long: `Converts a color scheme into a user theme: base16/base24 YAML, iTerm2
+.itermcolors, Alacritty (TOML or YAML), Kitty, WezTerm (TOML) or VS Code
+theme JSON. The format is detected from the file unless --format is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scheme, err := theme.ReadScheme(args[0], theme.SchemeFormat(importFormat))
+		if err != nil {
+			return err
+		}
+		if importName != "" {
+			scheme.Name = importName
+		}
+		f := scheme.File()
+		if _, err := f.Spec(); err != nil {
+			return fmt.Errorf("theme %q: %w", f.Name, err)
+		}
+		if slices.Contains(theme.AvailableThemes(), f.Name) {
+			return fmt.Errorf("theme %q is built in; pass --name to import it under another name", f.Name)
+		}
+
+		dir := config.ThemesDir(GetConfigFile())
+		slug := config.Slugify(f.Name)
+		if slug == "" {
+			return fmt.Errorf("theme %q: name has no usable characters for a file name; pass --name", f.Name)
+		}
+		path := filepath.Join(dir, slug+".json")
+		if _, err := os.Stat(path); err == nil && !importForce {
+			return fmt.Errorf("%s already exists; pass --force to replace it", path)
+		} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		if err := theme.SaveFile(path, f); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Imported theme %q to %s\n", f.Name, path)
+		return nil
+	},
+}
+
+var (
+	// exportFormat is the format theme export writes.
+	exportFormat string
+
+	// exportLight selects the light variant for single-variant formats.
+	exportLight bool
+)
+
+var themeExportCmd = &cobra.Command{
+	Use:   "export [name]",
+	Short: "Write a theme's derived palette as JSON, CSS or base16",
+	Long: `Writes every color the theme derives, for designers and other tools.
+JSON and CSS include the dark and light variants; base16 holds one, the dark
+variant unless --light is given. Without a name the configured theme is
+exported. User themes are included.`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeThemes,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		theme.LoadThemes(config.ThemesDir(GetConfigFile()))
+		name := cliConfig().UI.ThemeName
+		if len(args) == 1 {
+			name = args[0]
+		}
+		data, err := theme.Export(name, theme.ExportFormat(exportFormat), !exportLight)
+		if err != nil {
+			return err
+		}
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	},
+}
+
+// completeThemes offers the built-in and user theme names for shell
+// completion.
+func completeThemes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	theme.LoadThemes(config.ThemesDir(GetConfigFile()))
+	return theme.AvailableThemes(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeExportFormats offers the theme export --format values for shell
+// completion.
+func completeExportFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	formats := make([]string, len(theme.ExportFormats))
+	for i, f := range theme.ExportFormats {
+		formats[i] = string(f)
+	}
+	return formats, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeSchemeFormats offers the theme import --format values for shell
+// completion.
+func completeSchemeFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	formats := make([]string, len(theme.SchemeFormats))
+	for i, f := range theme.SchemeFormats {
+		formats[i] = string(f)
+	}
+	return formats, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	themeImportCmd.Flags().StringVar(&importName, "name", "",
+		"Name of the imported theme (default: the scheme's own name, or the file name)")
+	themeImportCmd.Flags().StringVar(&importFormat, "format", "",
+		"Scheme format: base16, iterm2, alacritty, kitty, wezterm or vscode (default: detected)")
+	_ = themeImportCmd.RegisterFlagCompletionFunc("format", completeSchemeFormats)
+	themeImportCmd.Flags().BoolVar(&importForce, "force", false,
+		"Replace an existing theme file of the same name")
+
+	themeExportCmd.Flags().StringVar(&exportFormat, "format", string(theme.ExportJSON),
+		"Export format: json, css or base16")
+	_ = themeExportCmd.RegisterFlagCompletionFunc("format", completeExportFormats)
+	themeExportCmd.Flags().BoolVar(&exportLight, "light", false,
+		"Export the light variant (base16 only; json and css include both)")
+
+	themeCmd.AddCommand(themeImportCmd, themeExportCmd)
+	rootCmd.AddCommand(themeCmd)
+}
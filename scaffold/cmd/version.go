@@ -3,24 +3,27 @@ package cmd
 
 import (
 	"fmt"
+	"runtime"
 
 	"github.com/spf13/cobra"
-
-	"scaffold/config"
 )
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number",
 	Long:  `All software has versions. This one is no exception.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		cfg := config.DefaultConfig()
-		if cfgFile != "" {
-			if fileCfg, err := config.Load(cfgFile); err == nil {
-				cfg = fileCfg
-			}
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := cliConfig()
+		p, err := newPrinter(cmd, cfg)
+		if err != nil {
+			return err
 		}
-		fmt.Printf("scaffold v%s\n", cfg.App.Version)
+		return p.Print(versionInfo{
+			Name:    "scaffold",
+			Version: cfg.App.Version,
+			Go:      runtime.Version(),
+			OS:      runtime.GOOS + "/" + runtime.GOARCH,
+		})
 	},
 	PreRun: func(cmd *cobra.Command, args []string) {
 		// Disable UI execution for this subcommand
@@ -28,6 +31,19 @@ var versionCmd = &cobra.Command{
 	},
 }
 
+// versionInfo is the record printed by the version command.
+type versionInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Go      string `json:"go"`
+	OS      string `json:"os"`
+}
+
+// Text keeps the classic "scaffold v1.0.0" line for text output.
+func (v versionInfo) Text() string {
+	return fmt.Sprintf("%s v%s", v.Name, v.Version)
+}
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
 }
@@ -30,11 +30,18 @@ var (
 
 // Config holds the application configuration.
 // All fields are exported to support JSON marshaling and environment variable binding.
+// Fields tagged cfg_secret:"true" use json:"-" so they never reach config.json
+// or any JSON export; they are persisted through a SecretStore instead.
 type Config struct {
 	// ConfigVersion tracks the schema version. Used by NeedsUpgrade to detect
 	// configs written by older builds. Not shown in the settings UI (cfg_exclude).
 	ConfigVersion int `json:"configVersion" koanf:"configVersion" cfg_default:"1" cfg_exclude:"true"`
 
+	// Profile names the active overlay in profiles/<name>.json, merged on top
+	// of this file at load time. Set by "scaffold profile use" or the in-app
+	// profile picker. Not shown in the settings UI (cfg_exclude).
+	Profile string `json:"profile,omitempty" mapstructure:"profile" koanf:"profile" cfg_exclude:"true"`
+
 	// LogLevel specifies the logging verbosity level.
 	// Valid values: trace, debug, info, warn, error, fatal
 	LogLevel string `json:"logLevel" mapstructure:"logLevel" koanf:"logLevel" cfg_default:"info" cfg_label:"Log Level" cfg_desc:"Logging verbosity (effective level shown in footer)" cfg_options:"trace,debug,info,warn,error,fatal"`
@@ -55,6 +62,13 @@ type Config struct {
 	// Notifications contains notification preferences.
 	Notifications NotificationsConfig `json:"notifications" mapstructure:"notifications" koanf:"notifications" cfg_label:"Notifications"`
 
+	// Keys overrides key bindings by scope and name, for example
+	// {"global": {"quit": ["ctrl+q"]}}; an empty list unbinds a key. The
+	// key-bindings screen writes keys.json next to this file (see KeysPath),
+	// whose entries take precedence. Not shown in the settings UI
+	// (cfg_exclude).
+	Keys map[string]map[string][]string `json:"keys,omitempty" mapstructure:"keys" koanf:"keys" cfg_exclude:"true"`
+
 	// App contains general application configuration.
 	App AppConfig `json:"app" mapstructure:"app" koanf:"app" cfg_label:"Application" cfg_exclude:"true"`
 }
@@ -68,11 +82,17 @@ type UIConfig struct {
 	CompactMode bool `json:"compactMode" mapstructure:"compactMode" koanf:"compactMode" cfg_label:"Compact Mode" cfg_desc:"Reduce vertical spacing in lists and menus"`
 
 	// OutputFormat controls how structured output is rendered.
-	OutputFormat string `json:"outputFormat" mapstructure:"outputFormat" koanf:"outputFormat" cfg_default:"text" cfg_label:"Output Format" cfg_desc:"Format for structured output" cfg_options:"text,json,table"`
+	OutputFormat string `json:"outputFormat" mapstructure:"outputFormat" koanf:"outputFormat" cfg_default:"text" cfg_label:"Output Format" cfg_desc:"Format for structured output" cfg_options:"text,json,ndjson,table"`
 
 	// DateFormat is the Go time layout used when displaying dates.
 	DateFormat string `json:"dateFormat" mapstructure:"dateFormat" koanf:"dateFormat" cfg_default:"2006-01-02" cfg_label:"Date Format" cfg_desc:"Go time layout, e.g. 2006-01-02"`
 
+	// TimeZone is the IANA zone timestamps are shown in; "Local" is the system zone.
+	TimeZone string `json:"timeZone" mapstructure:"timeZone" koanf:"timeZone" cfg_default:"Local" cfg_label:"Time Zone" cfg_desc:"IANA zone for displayed times, e.g. Europe/Berlin (Local = system)"`
+
+	// RelativeTimes shows timestamps within the last week as "3m ago".
+	RelativeTimes bool `json:"relativeTimes" mapstructure:"relativeTimes" koanf:"relativeTimes" cfg_label:"Relative Times" cfg_desc:"Show recent times as \"3m ago\""`
+
 	// ThemeName specifies the color theme to use.
 	ThemeName string `json:"themeName" mapstructure:"themeName" koanf:"themeName" cfg_default:"ember" cfg_label:"Color Theme" cfg_desc:"Visual theme for the application" cfg_options:"_themes"`
 
@@ -125,9 +145,20 @@ type NetworkConfig struct {
 	// RetryCount is the number of times to retry failed requests.
 	RetryCount int `json:"retryCount" mapstructure:"retryCount" koanf:"retryCount" cfg_default:"3" cfg_label:"Retry Count" cfg_desc:"Number of retry attempts for failed requests"`
 
+	// APIToken authenticates requests to APIEndpoint.
+	// Secret: kept in the OS keyring or encrypted secrets file, never in config.json.
+	APIToken string `json:"-" mapstructure:"apiToken" koanf:"apiToken" cfg_secret:"true" cfg_label:"API Token" cfg_desc:"Bearer token for API requests (stored securely)"`
+
 	// ProxyURL is the HTTP proxy URL (optional).
 	ProxyURL string `json:"proxyUrl" mapstructure:"proxyUrl" koanf:"proxyUrl" cfg_label:"Proxy URL" cfg_desc:"HTTP proxy URL (leave empty for direct connection)"`
 
+	// ProxyUsername is the proxy authentication user (optional).
+	ProxyUsername string `json:"proxyUsername" mapstructure:"proxyUsername" koanf:"proxyUsername" cfg_label:"Proxy Username" cfg_desc:"Username for proxy authentication"`
+
+	// ProxyPassword is the proxy authentication password (optional).
+	// Secret: kept in the OS keyring or encrypted secrets file, never in config.json.
+	ProxyPassword string `json:"-" mapstructure:"proxyPassword" koanf:"proxyPassword" cfg_secret:"true" cfg_label:"Proxy Password" cfg_desc:"Password for proxy authentication (stored securely)"`
+
 	// VerifySSL enables SSL certificate verification.
 	VerifySSL bool `json:"verifySSL" mapstructure:"verifySSL" koanf:"verifySSL" cfg_default:"true" cfg_label:"Verify SSL" cfg_desc:"Verify SSL certificates (disable for self-signed)"`
 }
@@ -258,6 +289,18 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("%w: invalid log level '%s'", ErrInvalidConfig, c.LogLevel)
 	}
 
+	// Validate date display settings
+	if err := ValidateDateFormat(c.UI.DateFormat); err != nil {
+		return err
+	}
+	if _, err := LoadTimeZone(c.UI.TimeZone); err != nil {
+		return err
+	}
+
+	if err := validateQuietHours(c.Notifications); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -305,19 +348,23 @@ func applyStructDefaults(rv reflect.Value) {
 			applyStructDefaults(fv)
 			continue
 		}
-		def := sf.Tag.Get("cfg_default")
-		if def == "" {
-			continue
+		if def := sf.Tag.Get("cfg_default"); def != "" {
+			setFromTag(fv, def)
 		}
-		switch fv.Kind() {
-		case reflect.String:
-			fv.SetString(def)
-		case reflect.Bool:
-			fv.SetBool(def == "true")
-		case reflect.Int, reflect.Int64:
-			if n, err := strconv.Atoi(def); err == nil {
-				fv.SetInt(int64(n))
-			}
+	}
+}
+
+// setFromTag parses a cfg_default tag value into fv. Unparseable ints and
+// unsupported kinds leave fv unchanged.
+func setFromTag(fv reflect.Value, def string) {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(def)
+	case reflect.Bool:
+		fv.SetBool(def == "true")
+	case reflect.Int, reflect.Int64:
+		if n, err := strconv.Atoi(def); err == nil {
+			fv.SetInt(int64(n))
 		}
 	}
 }
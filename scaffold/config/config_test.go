@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -134,6 +135,13 @@ func TestLoadFromBytes_UserValuesOverrideDefaults(t *testing.T) {
 	assert.Equal(t, "catppuccin", cfg.UI.ThemeName)
 }
 
+func TestLoadFromBytes_KeysSection(t *testing.T) {
+	cfg, err := LoadFromBytes([]byte(`{"keys": {"global": {"quit": ["ctrl+q"], "help": []}}}`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ctrl+q"}, cfg.Keys["global"]["quit"])
+	assert.Empty(t, cfg.Keys["global"]["help"])
+}
+
 // --- DefaultConfig ---
 
 func TestDefaultConfig_DebugFalse(t *testing.T) {
@@ -233,3 +241,40 @@ func writeJSON(t *testing.T, content string) string {
 	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
 	return path
 }
+
+func TestValidate_DateFormat(t *testing.T) {
+	cfg := &Config{LogLevel: "info"}
+	cfg.UI.DateFormat = "Jan 2, 2006 15:04"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.UI.DateFormat = "YYYY-MM-DD"
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidConfig, "a layout without reference-time elements is rejected")
+}
+
+func TestValidate_TimeZone(t *testing.T) {
+	cfg := &Config{LogLevel: "info"}
+	for _, zone := range []string{"", LocalTimeZone, "UTC", "Europe/Berlin"} {
+		cfg.UI.TimeZone = zone
+		assert.NoError(t, cfg.Validate(), "zone %q should be valid", zone)
+	}
+	cfg.UI.TimeZone = "Nowhere/Special"
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidConfig)
+}
+
+func TestValidate_QuietHours(t *testing.T) {
+	cfg := &Config{LogLevel: "info"}
+	cfg.Notifications.QuietHoursStart = "22:00"
+	cfg.Notifications.QuietHoursEnd = "07:30"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Notifications.QuietHoursEnd = "7pm"
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidConfig)
+	cfg.Notifications.QuietHoursEnd = "24:00"
+	assert.ErrorIs(t, cfg.Validate(), ErrInvalidConfig)
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	d, err := ParseTimeOfDay("07:05")
+	require.NoError(t, err)
+	assert.Equal(t, 7*time.Hour+5*time.Minute, d)
+}
@@ -38,3 +38,15 @@ func DefaultConfigPath() string {
 	appName := Slugify(DefaultConfig().App.Name)
 	return filepath.Join(cfgDir, appName, "config.json")
 }
+
+// KeysPath returns the key-binding overrides file for the config file at
+// configPath: keys.json next to the config file.
+func KeysPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "keys.json")
+}
+
+// ThemesDir returns the directory of user theme files for the config file
+// at configPath: themes/ next to the config file.
+func ThemesDir(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "themes")
+}
@@ -0,0 +1,272 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	koanfjson "github.com/knadh/koanf/parsers/json"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/knadh/koanf/v2"
+)
+
+var (
+	// ErrProfileNotFound is returned when a named profile has no overlay file.
+	ErrProfileNotFound = errors.New("profile not found")
+
+	// ErrProfileExists is returned when creating a profile that already exists.
+	ErrProfileExists = errors.New("profile already exists")
+
+	// ErrInvalidProfile is returned for profile names that are not valid slugs.
+	ErrInvalidProfile = errors.New("invalid profile name")
+)
+
+// profileExt is the file extension of profile overlay files.
+const profileExt = ".json"
+
+// profileKeysIgnored lists keys that are never written to a profile overlay.
+// They describe the base file itself rather than a per-environment setting.
+var profileKeysIgnored = map[string]bool{
+	"configVersion": true,
+	"profile":       true,
+}
+
+// ProfilesDir returns the directory holding profile overlays for the config
+// file at configPath: a "profiles" directory next to the config file.
+func ProfilesDir(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), "profiles")
+}
+
+// ProfilePath returns the overlay file path for the named profile.
+func ProfilePath(configPath, name string) string {
+	return filepath.Join(ProfilesDir(configPath), name+profileExt)
+}
+
+// ValidateProfileName reports whether name is usable as a profile name.
+// Names must be non-empty lowercase slugs (see Slugify) so they map cleanly
+// onto file names on every platform.
+func ValidateProfileName(name string) error {
+	if name == "" || Slugify(name) != name {
+		return fmt.Errorf("%w: %q (use lowercase letters, digits and hyphens)", ErrInvalidProfile, name)
+	}
+	return nil
+}
+
+// ListProfiles returns the sorted names of all profiles next to configPath.
+// A missing profiles directory is not an error; it yields an empty list.
+func ListProfiles(configPath string) ([]string, error) {
+	entries, err := os.ReadDir(ProfilesDir(configPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: listing profiles: %w", err)
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		name, ok := strings.CutSuffix(e.Name(), profileExt)
+		if !ok || ValidateProfileName(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// CreateProfile writes an empty overlay for the named profile.
+// An empty overlay inherits every value from the base config; settings saved
+// while the profile is active are written to it by SaveProfile.
+func CreateProfile(configPath, name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	path := ProfilePath(configPath, name)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%w: %s", ErrProfileExists, name)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("config: creating profiles directory: %w", err)
+	}
+	return writeFileAtomic(path, []byte("{}\n"), 0o644)
+}
+
+// DeleteProfile removes the named profile's overlay and the secrets stored
+// for it. If the base config had the profile marked active, the active
+// profile is cleared.
+func DeleteProfile(configPath, name string) error {
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+	if err := os.Remove(ProfilePath(configPath, name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+		}
+		return fmt.Errorf("config: deleting profile: %w", err)
+	}
+	if err := deleteProfileSecrets(name, NewSecretStore(configPath)); err != nil {
+		return err
+	}
+
+	base, err := Load(configPath)
+	if errors.Is(err, ErrConfigNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if base.Profile == name {
+		return SetActiveProfile(configPath, "")
+	}
+	return nil
+}
+
+// LoadProfile builds the effective config for the named profile:
+// defaults → base config file (if present) → profiles/<name>.json.
+// The returned config has Profile set to name. An empty name is equivalent
+// to Load(configPath).
+func LoadProfile(configPath, name string) (*Config, error) {
+	if name == "" {
+		return Load(configPath)
+	}
+	if err := ValidateProfileName(name); err != nil {
+		return nil, err
+	}
+	overlay := ProfilePath(configPath, name)
+	if _, err := os.Stat(overlay); os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	k := koanf.New(".")
+
+	// 1. Defaults
+	if err := loadDefaults(k); err != nil {
+		return nil, fmt.Errorf("loading defaults: %w", err)
+	}
+
+	// 2. Base config, when one has been written
+	if _, err := os.Stat(configPath); err == nil {
+		if err := k.Load(file.Provider(configPath), koanfjson.Parser()); err != nil {
+			return nil, fmt.Errorf("loading config from %s: %w", configPath, err)
+		}
+	}
+
+	// 3. Profile overlay
+	if err := k.Load(file.Provider(overlay), koanfjson.Parser()); err != nil {
+		return nil, fmt.Errorf("loading profile from %s: %w", overlay, err)
+	}
+
+	cfg := &Config{}
+	if err := k.Unmarshal("", cfg); err != nil {
+		return nil, fmt.Errorf("parsing configuration: %w", err)
+	}
+	cfg.Profile = name
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// SetActiveProfile records name as the active profile in the base config so
+// it is applied on the next launch. An empty name clears the active profile.
+// The base config file is created from defaults if it does not exist yet.
+func SetActiveProfile(configPath, name string) error {
+	if name != "" {
+		if err := ValidateProfileName(name); err != nil {
+			return err
+		}
+		if _, err := os.Stat(ProfilePath(configPath, name)); os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+		}
+	}
+
+	base, err := Load(configPath)
+	if errors.Is(err, ErrConfigNotFound) {
+		base = DefaultConfig()
+	} else if err != nil {
+		return err
+	}
+	base.Profile = name
+	return Save(base, configPath)
+}
+
+// SaveProfile persists cfg into the overlay of its active profile (cfg.Profile).
+// Only values that differ from the base config are written, so later edits
+// to the base config still flow through to the profile. The schema version
+// is recorded in the base config, which is created from defaults if it does
+// not exist yet.
+func SaveProfile(cfg *Config, configPath string) error {
+	if err := ValidateProfileName(cfg.Profile); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("config: save validation: %w", err)
+	}
+
+	base, err := Load(configPath)
+	missing := errors.Is(err, ErrConfigNotFound)
+	if missing {
+		base = DefaultConfig()
+	} else if err != nil {
+		return err
+	}
+	if missing || base.ConfigVersion != cfg.ConfigVersion {
+		base.ConfigVersion = cfg.ConfigVersion
+		if err := Save(base, configPath); err != nil {
+			return err
+		}
+	}
+
+	want, err := flatten(cfg)
+	if err != nil {
+		return err
+	}
+	have, err := flatten(base)
+	if err != nil {
+		return err
+	}
+
+	out := koanf.New(".")
+	for key, val := range want {
+		if profileKeysIgnored[key] || reflect.DeepEqual(have[key], val) {
+			continue
+		}
+		if err := out.Set(key, val); err != nil {
+			return fmt.Errorf("config: building profile overlay: %w", err)
+		}
+	}
+
+	data, err := out.Marshal(koanfjson.Parser())
+	if err != nil {
+		return fmt.Errorf("config: koanf marshal during save: %w", err)
+	}
+
+	path := ProfilePath(configPath, cfg.Profile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("config: creating profiles directory: %w", err)
+	}
+	return writeFileAtomic(path, data, 0o644)
+}
+
+// flatten returns cfg as a flat map of dot-path keys to JSON scalar values.
+func flatten(cfg *Config) (map[string]any, error) {
+	raw, err := cfg.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+	k := koanf.New(".")
+	if err := k.Load(rawbytes.Provider(raw), koanfjson.Parser()); err != nil {
+		return nil, fmt.Errorf("config: koanf parse: %w", err)
+	}
+	return k.All(), nil
+}
@@ -0,0 +1,211 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- ListProfiles / CreateProfile / DeleteProfile ---
+
+func TestListProfiles_MissingDirIsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	names, err := ListProfiles(path)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestCreateProfile_ListedSorted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	require.NoError(t, CreateProfile(path, "staging"))
+	require.NoError(t, CreateProfile(path, "dev"))
+
+	names, err := ListProfiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "staging"}, names)
+}
+
+func TestCreateProfile_AlreadyExists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	require.NoError(t, CreateProfile(path, "dev"))
+	assert.ErrorIs(t, CreateProfile(path, "dev"), ErrProfileExists)
+}
+
+func TestCreateProfile_InvalidName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	for _, name := range []string{"", "Prod", "../etc", "my profile"} {
+		assert.ErrorIs(t, CreateProfile(path, name), ErrInvalidProfile, "name %q", name)
+	}
+}
+
+func TestDeleteProfile_NotFound(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.ErrorIs(t, DeleteProfile(path, "ghost"), ErrProfileNotFound)
+}
+
+func TestDeleteProfile_ClearsActive(t *testing.T) {
+	t.Setenv(SecretStoreEnv, "file")
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, CreateProfile(path, "prod"))
+	require.NoError(t, SetActiveProfile(path, "prod"))
+
+	require.NoError(t, DeleteProfile(path, "prod"))
+
+	base, err := Load(path)
+	require.NoError(t, err)
+	assert.Empty(t, base.Profile, "deleting the active profile must clear it")
+}
+
+func TestDeleteProfile_RemovesItsSecrets(t *testing.T) {
+	t.Setenv(SecretStoreEnv, "file")
+	t.Setenv(SecretKeyEnv, "")
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, CreateProfile(path, "prod"))
+	require.NoError(t, CreateProfile(path, "dev"))
+	store := NewSecretStore(path)
+	require.NoError(t, store.Set("network.apiToken", "base-token"))
+	require.NoError(t, store.Set("prod/network.apiToken", "prod-token"))
+	require.NoError(t, store.Set("prod/network.proxyPassword", ""))
+	require.NoError(t, store.Set("dev/network.apiToken", "dev-token"))
+
+	require.NoError(t, DeleteProfile(path, "prod"))
+
+	for _, key := range []string{"prod/network.apiToken", "prod/network.proxyPassword"} {
+		_, err := store.Get(key)
+		assert.ErrorIs(t, err, ErrSecretNotFound, key)
+	}
+	got, err := store.Get("network.apiToken")
+	require.NoError(t, err)
+	assert.Equal(t, "base-token", got, "base secrets are kept")
+	got, err = store.Get("dev/network.apiToken")
+	require.NoError(t, err)
+	assert.Equal(t, "dev-token", got, "other profiles' secrets are kept")
+}
+
+// --- LoadProfile ---
+
+func TestLoadProfile_OverlayWinsOverBase(t *testing.T) {
+	path := writeJSON(t, `{"logLevel":"warn","ui":{"themeName":"ocean","showBanner":false}}`)
+	require.NoError(t, CreateProfile(path, "prod"))
+	require.NoError(t, os.WriteFile(ProfilePath(path, "prod"),
+		[]byte(`{"ui":{"themeName":"nord"}}`), 0o644))
+
+	cfg, err := LoadProfile(path, "prod")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", cfg.Profile)
+	assert.Equal(t, "nord", cfg.UI.ThemeName, "overlay value must win")
+	assert.Equal(t, "warn", cfg.LogLevel, "base value must be inherited")
+	assert.False(t, cfg.UI.ShowBanner, "base value must be inherited")
+	assert.True(t, cfg.UI.ShowHelpBar, "default must fill unset fields")
+}
+
+func TestLoadProfile_NotFound(t *testing.T) {
+	path := writeJSON(t, `{"logLevel":"info"}`)
+
+	_, err := LoadProfile(path, "missing")
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestLoadProfile_InvalidOverlayRejected(t *testing.T) {
+	path := writeJSON(t, `{"logLevel":"info"}`)
+	require.NoError(t, CreateProfile(path, "bad"))
+	require.NoError(t, os.WriteFile(ProfilePath(path, "bad"),
+		[]byte(`{"logLevel":"verbose"}`), 0o644))
+
+	_, err := LoadProfile(path, "bad")
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+// --- SaveProfile / SetActiveProfile ---
+
+func TestSaveProfile_WritesOnlyDifferences(t *testing.T) {
+	path := writeJSON(t, `{"logLevel":"warn"}`)
+	require.NoError(t, CreateProfile(path, "dev"))
+
+	cfg, err := LoadProfile(path, "dev")
+	require.NoError(t, err)
+	cfg.Network.APIEndpoint = "http://localhost:8080"
+	require.NoError(t, SaveProfile(cfg, path))
+
+	data, err := os.ReadFile(ProfilePath(path, "dev"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"network":{"apiEndpoint":"http://localhost:8080"}}`, string(data))
+
+	base, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.example.com", base.Network.APIEndpoint,
+		"base config must not change when saving a profile")
+}
+
+func TestSaveProfile_ComparesKeyLists(t *testing.T) {
+	path := writeJSON(t, `{"keys": {"menu": {"up": ["k"]}}}`)
+	require.NoError(t, CreateProfile(path, "vim"))
+
+	cfg, err := LoadProfile(path, "vim")
+	require.NoError(t, err)
+	cfg.Keys["menu"]["down"] = []string{"j"}
+	require.NoError(t, SaveProfile(cfg, path))
+
+	data, err := os.ReadFile(ProfilePath(path, "vim"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"keys":{"menu":{"down":["j"]}}}`, string(data))
+}
+
+func TestSaveProfile_WritesVersionToBase(t *testing.T) {
+	path := writeJSON(t, `{"configVersion":0,"logLevel":"warn"}`)
+	require.NoError(t, CreateProfile(path, "dev"))
+
+	cfg, err := LoadProfile(path, "dev")
+	require.NoError(t, err)
+	cfg.ConfigVersion = CurrentConfigVersion
+	require.NoError(t, SaveProfile(cfg, path))
+
+	base, err := Load(path)
+	require.NoError(t, err)
+	assert.False(t, NeedsUpgrade(base))
+	assert.Equal(t, "warn", base.LogLevel, "other base values must be preserved")
+
+	data, err := os.ReadFile(ProfilePath(path, "dev"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{}`, string(data), "the version is not written to the overlay")
+}
+
+func TestSaveProfile_CreatesMissingBase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, CreateProfile(path, "dev"))
+	require.True(t, IsFirstRun(path))
+
+	cfg, err := LoadProfile(path, "dev")
+	require.NoError(t, err)
+	require.NoError(t, SaveProfile(cfg, path))
+
+	assert.False(t, IsFirstRun(path), "saving a profile ends the first run")
+	base, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, CurrentConfigVersion, base.ConfigVersion)
+	assert.Empty(t, base.Profile)
+}
+
+func TestSetActiveProfile_Persists(t *testing.T) {
+	path := writeJSON(t, `{"logLevel":"error"}`)
+	require.NoError(t, CreateProfile(path, "staging"))
+
+	require.NoError(t, SetActiveProfile(path, "staging"))
+
+	base, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", base.Profile)
+	assert.Equal(t, "error", base.LogLevel, "other base values must be preserved")
+}
+
+func TestSetActiveProfile_UnknownProfile(t *testing.T) {
+	path := writeJSON(t, `{"logLevel":"info"}`)
+	assert.ErrorIs(t, SetActiveProfile(path, "nope"), ErrProfileNotFound)
+}
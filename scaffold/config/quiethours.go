@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParseTimeOfDay parses a 24-hour "HH:MM" clock time, as used by
+// Notifications.QuietHoursStart and QuietHoursEnd, into the offset from
+// midnight.
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: time of day %q: want HH:MM", ErrInvalidConfig, s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// validateQuietHours checks the quiet hours clock times. Leaving either
+// empty disables quiet hours.
+func validateQuietHours(n NotificationsConfig) error {
+	for _, s := range []string{n.QuietHoursStart, n.QuietHoursEnd} {
+		if s == "" {
+			continue
+		}
+		if _, err := ParseTimeOfDay(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -38,8 +38,14 @@ func Save(cfg *Config, path string) error {
 		return fmt.Errorf("config: koanf marshal during save: %w", err)
 	}
 
+	return writeFileAtomic(path, out, 0o644)
+}
+
+// writeFileAtomic writes data to a temp file next to path, then renames it
+// into place so readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
 	tmp := path + ".tmp"
-	if err := os.WriteFile(tmp, out, 0o644); err != nil {
+	if err := os.WriteFile(tmp, data, perm); err != nil {
 		return fmt.Errorf("config: writing temp file: %w", err)
 	}
 	if err := os.Rename(tmp, path); err != nil {
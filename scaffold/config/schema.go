@@ -24,12 +24,39 @@ type FieldMeta struct {
 	Kind     FieldKind
 	Options  []string // non-nil only for FieldSelect
 	ReadOnly bool
+	Secret   bool          // cfg_secret tag: masked in the UI, stored outside config.json
+	Default  string        // raw cfg_default tag; "" means the zero value
 	Value    reflect.Value // settable Value pointing into the working *Config
 }
 
+// DefaultValue returns the field's default as the field's own type, parsed
+// from the cfg_default tag the same way DefaultConfig does.
+func (f FieldMeta) DefaultValue() any {
+	v := reflect.New(f.Value.Type()).Elem()
+	if f.Default != "" {
+		setFromTag(v, f.Default)
+	}
+	return v.Interface()
+}
+
+// IsDefault reports whether the field currently holds its default value.
+func (f FieldMeta) IsDefault() bool {
+	return f.Value.Interface() == f.DefaultValue()
+}
+
+// ResetToDefault writes the field's default value into the working config.
+// Read-only fields are left unchanged.
+func (f FieldMeta) ResetToDefault() {
+	if f.ReadOnly {
+		return
+	}
+	f.Value.Set(reflect.ValueOf(f.DefaultValue()))
+}
+
 // GroupMeta groups related fields under a label.
 type GroupMeta struct {
-	Label  string
+	Key    string // koanf key of the nested struct, "general" for top-level fields
+	Label  string // cfg_label tag on the nested struct
 	Fields []FieldMeta
 }
 
@@ -54,6 +81,7 @@ func Schema(cfg *Config) []GroupMeta {
 		}
 		if fv.Kind() == reflect.Struct {
 			groups = append(groups, GroupMeta{
+				Key:    koanfKey,
 				Label:  tagOrName(sf, "cfg_label"),
 				Fields: nestedFields(fv, koanfKey),
 			})
@@ -64,6 +92,7 @@ func Schema(cfg *Config) []GroupMeta {
 
 	if len(topFields) > 0 {
 		groups = slices.Insert(groups, 0, GroupMeta{
+			Key:    "general",
 			Label:  "General",
 			Fields: topFields,
 		})
@@ -107,6 +136,8 @@ func leafField(sf reflect.StructField, fv reflect.Value, key string) FieldMeta {
 		Label:    tagOrName(sf, "cfg_label"),
 		Desc:     sf.Tag.Get("cfg_desc"),
 		ReadOnly: readOnly,
+		Secret:   sf.Tag.Get("cfg_secret") == "true",
+		Default:  sf.Tag.Get("cfg_default"),
 		Options:  options,
 		Kind:     deriveKind(fv.Kind(), options, readOnly),
 		Value:    fv,
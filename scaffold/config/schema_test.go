@@ -106,3 +106,61 @@ func TestSchema_TopLevelNonStructFieldsGroupedAsGeneral(t *testing.T) {
 	assert.True(t, keys["logLevel"], "logLevel must be in General group")
 	assert.True(t, keys["debug"], "debug must be in General group")
 }
+
+// findField returns the FieldMeta for key, failing the test if absent.
+func findField(t *testing.T, groups []GroupMeta, key string) FieldMeta {
+	t.Helper()
+	for _, g := range groups {
+		for _, f := range g.Fields {
+			if f.Key == key {
+				return f
+			}
+		}
+	}
+	t.Fatalf("field %q not found", key)
+	return FieldMeta{}
+}
+
+// TestFieldMeta_DefaultsMatchDefaultConfig verifies that every field of a
+// default config reports IsDefault, so the UI shows no "changed" markers.
+func TestFieldMeta_DefaultsMatchDefaultConfig(t *testing.T) {
+	for _, g := range Schema(DefaultConfig()) {
+		for _, f := range g.Fields {
+			assert.True(t, f.IsDefault(), "%s must be at its default", f.Key)
+		}
+	}
+}
+
+// TestFieldMeta_ResetToDefault verifies that a single field is restored from
+// its cfg_default tag without touching other fields.
+func TestFieldMeta_ResetToDefault(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.UI.ThemeName = "nord"
+	cfg.Network.Timeout = 5
+	cfg.UI.ShowBanner = false
+	groups := Schema(cfg)
+
+	timeout := findField(t, groups, "network.timeout")
+	assert.False(t, timeout.IsDefault())
+	assert.Equal(t, 30, timeout.DefaultValue())
+
+	timeout.ResetToDefault()
+	assert.Equal(t, 30, cfg.Network.Timeout)
+	assert.Equal(t, "nord", cfg.UI.ThemeName, "other fields must be untouched")
+
+	findField(t, groups, "ui.showBanner").ResetToDefault()
+	assert.True(t, cfg.UI.ShowBanner)
+}
+
+// TestFieldMeta_UntaggedDefaultIsZero verifies that fields without a
+// cfg_default tag default to their zero value.
+func TestFieldMeta_UntaggedDefaultIsZero(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Network.APIToken = "tok"
+
+	f := findField(t, Schema(cfg), "network.apiToken")
+	assert.Empty(t, f.Default)
+	assert.False(t, f.IsDefault())
+	f.ResetToDefault()
+	assert.Empty(t, cfg.Network.APIToken)
+}
@@ -0,0 +1,530 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/zalando/go-keyring"
+)
+
+// Environment variables consulted by NewSecretStore.
+const (
+	// SecretKeyEnv holds a passphrase used to derive the encryption key of
+	// the file-based secret store. When unset, a random key is generated and
+	// kept in secrets.key (mode 0600) next to the config file.
+	SecretKeyEnv = "SCAFFOLD_SECRET_KEY"
+
+	// SecretStoreEnv forces a backend: "file" skips the OS keyring probe,
+	// which is useful in headless CI where no keyring daemon is running.
+	SecretStoreEnv = "SCAFFOLD_SECRET_STORE"
+)
+
+// ErrSecretNotFound is returned by SecretStore.Get for keys that are unset.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretStore persists the values of cfg_secret fields outside config.json.
+// Keys are dot-path koanf keys, optionally prefixed with "<profile>/".
+type SecretStore interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+var (
+	secretStoresMu sync.Mutex
+	secretStores   = map[string]SecretStore{} // config directory -> store
+)
+
+// NewSecretStore returns the OS keyring when one is reachable, and otherwise
+// an AES-GCM encrypted file store in the directory of configPath. Either is
+// scoped to that directory, so configs in different directories never see
+// each other's secrets. The store is built, and the keyring probed, once per
+// directory; later calls return the same store.
+func NewSecretStore(configPath string) SecretStore {
+	dir := filepath.Dir(configPath)
+	secretStoresMu.Lock()
+	defer secretStoresMu.Unlock()
+	if store, ok := secretStores[dir]; ok {
+		return store
+	}
+
+	var store SecretStore = &fileStore{
+		path:    filepath.Join(dir, "secrets.enc"),
+		keyPath: filepath.Join(dir, "secrets.key"),
+	}
+	if os.Getenv(SecretStoreEnv) != "file" {
+		if ks := (keyringStore{service: keyringService(dir)}); ks.available() {
+			store = ks
+		}
+	}
+	secretStores[dir] = store
+	return store
+}
+
+// keyringService returns the keyring service for the configs in dir: the
+// app name and a hash of the absolute directory.
+func keyringService(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	sum := sha256.Sum256([]byte(dir))
+	return fmt.Sprintf("%s-%x", Slugify(DefaultConfig().App.Name), sum[:4])
+}
+
+// batcher is implemented by stores that apply several reads and changes
+// with a single read and write of their backing file.
+type batcher interface {
+	batch(fn func(SecretStore) error) error
+}
+
+// inBatch runs fn against store, as one batch when the store supports it.
+func inBatch(store SecretStore, fn func(SecretStore) error) error {
+	if b, ok := store.(batcher); ok {
+		return b.batch(fn)
+	}
+	return fn(store)
+}
+
+// mapStore is an in-memory SecretStore; [fileStore] batches run on one.
+type mapStore map[string]string
+
+func (s mapStore) Get(key string) (string, error) {
+	v, ok := s[key]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return v, nil
+}
+
+func (s mapStore) Set(key, value string) error {
+	s[key] = value
+	return nil
+}
+
+func (s mapStore) Delete(key string) error {
+	if _, ok := s[key]; !ok {
+		return ErrSecretNotFound
+	}
+	delete(s, key)
+	return nil
+}
+
+// SecretsError is returned by LoadSecrets when some secrets could not be
+// read, for example from a locked keyring or with the wrong SecretKeyEnv.
+// The fields of Keys keep their previous values.
+type SecretsError struct {
+	Keys []string // dot-path keys of the unread fields
+	Err  error    // the read errors, joined
+}
+
+func (e *SecretsError) Error() string { return e.Err.Error() }
+
+func (e *SecretsError) Unwrap() error { return e.Err }
+
+// UnreadSecrets returns the keys a [SecretsError] in err's chain could not
+// read, or nil.
+func UnreadSecrets(err error) []string {
+	var serr *SecretsError
+	if errors.As(err, &serr) {
+		return serr.Keys
+	}
+	return nil
+}
+
+// LoadSecrets fills every cfg_secret field of cfg from store. While a profile
+// is active, profile-scoped values take precedence over base values; an
+// empty profile-scoped value clears the base value. Fields with no stored
+// value are left unchanged. Fields whose value could not be read are
+// reported in a *SecretsError; pass its Keys to SaveSecrets.
+func LoadSecrets(cfg *Config, store SecretStore) error {
+	err := inBatch(store, func(store SecretStore) error {
+		serr := &SecretsError{}
+		var errs []error
+		walkSecrets(reflect.ValueOf(cfg).Elem(), "", func(key string, fv reflect.Value) {
+			for _, k := range secretKeys(cfg.Profile, key) {
+				val, err := store.Get(k)
+				if errors.Is(err, ErrSecretNotFound) {
+					continue
+				}
+				if err != nil {
+					serr.Keys = append(serr.Keys, key)
+					errs = append(errs, fmt.Errorf("config: reading secret %s: %w", key, err))
+					return
+				}
+				fv.SetString(val)
+				return
+			}
+		})
+		if len(errs) == 0 {
+			return nil
+		}
+		serr.Err = errors.Join(errs...)
+		return serr
+	})
+	if err != nil && UnreadSecrets(err) == nil {
+		// The batch itself failed, so nothing was read.
+		keys := []string{}
+		walkSecrets(reflect.ValueOf(cfg).Elem(), "", func(key string, _ reflect.Value) {
+			keys = append(keys, key)
+		})
+		return &SecretsError{Keys: keys, Err: err}
+	}
+	return err
+}
+
+// SaveSecrets writes every cfg_secret field of cfg to store. Without a
+// profile, empty values delete the stored secret. While a profile is active,
+// only values that differ from the base value are stored, scoped to the
+// profile, so the profile keeps following later changes to the base; an
+// empty value over a base secret is stored as such to clear it.
+// The unread keys, as reported by LoadSecrets, still hold whatever the store
+// has for them: they are written only when given a non-empty value, and
+// never deleted or cleared.
+func SaveSecrets(cfg *Config, store SecretStore, unread ...string) error {
+	return inBatch(store, func(store SecretStore) error {
+		var errs []error
+		walkSecrets(reflect.ValueOf(cfg).Elem(), "", func(key string, fv reflect.Value) {
+			if fv.String() == "" && slices.Contains(unread, key) {
+				return
+			}
+			if err := saveSecret(store, cfg.Profile, key, fv.String()); err != nil {
+				errs = append(errs, fmt.Errorf("config: writing secret %s: %w", key, err))
+			}
+		})
+		return errors.Join(errs...)
+	})
+}
+
+// deleteProfileSecrets removes every secret scoped to profile from store.
+func deleteProfileSecrets(profile string, store SecretStore) error {
+	return inBatch(store, func(store SecretStore) error {
+		var errs []error
+		walkSecrets(reflect.ValueOf(DefaultConfig()).Elem(), "", func(key string, _ reflect.Value) {
+			err := store.Delete(secretKeys(profile, key)[0])
+			if err != nil && !errors.Is(err, ErrSecretNotFound) {
+				errs = append(errs, fmt.Errorf("config: deleting secret %s: %w", key, err))
+			}
+		})
+		return errors.Join(errs...)
+	})
+}
+
+// saveSecret stores val for key; see [SaveSecrets].
+func saveSecret(store SecretStore, profile, key, val string) error {
+	k := secretKeys(profile, key)[0]
+	if profile != "" {
+		base, err := store.Get(key)
+		if err != nil && !errors.Is(err, ErrSecretNotFound) {
+			return err
+		}
+		if val != base {
+			return store.Set(k, val)
+		}
+	} else if val != "" {
+		return store.Set(k, val)
+	}
+	if err := store.Delete(k); !errors.Is(err, ErrSecretNotFound) {
+		return err
+	}
+	return nil
+}
+
+// secretKeys returns the store keys to try for a field, most specific first.
+func secretKeys(profile, key string) []string {
+	if profile == "" {
+		return []string{key}
+	}
+	return []string{profile + "/" + key, key}
+}
+
+// walkSecrets calls fn for every string field tagged cfg_secret:"true".
+func walkSecrets(rv reflect.Value, prefix string, fn func(key string, fv reflect.Value)) {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		fv := rv.Field(i)
+		key := sf.Tag.Get("koanf")
+		if key == "" {
+			continue
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		if fv.Kind() == reflect.Struct {
+			walkSecrets(fv, key, fn)
+			continue
+		}
+		if sf.Tag.Get("cfg_secret") == "true" && fv.Kind() == reflect.String {
+			fn(key, fv)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// OS keyring
+// -----------------------------------------------------------------------------
+
+// keyringStore stores secrets in the OS keyring (Secret Service, Keychain or
+// Windows Credential Manager) under service.
+type keyringStore struct {
+	service string
+}
+
+// available reports whether the keyring backend answers requests.
+func (s keyringStore) available() bool {
+	_, err := keyring.Get(s.service, "__probe__")
+	return err == nil || errors.Is(err, keyring.ErrNotFound)
+}
+
+func (s keyringStore) Get(key string) (string, error) {
+	val, err := keyring.Get(s.service, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrSecretNotFound
+	}
+	return val, err
+}
+
+func (s keyringStore) Set(key, value string) error {
+	return keyring.Set(s.service, key, value)
+}
+
+func (s keyringStore) Delete(key string) error {
+	err := keyring.Delete(s.service, key)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrSecretNotFound
+	}
+	return err
+}
+
+// -----------------------------------------------------------------------------
+// Encrypted file
+// -----------------------------------------------------------------------------
+
+// File layout: version (1) | salt (16) | nonce (12) | AES-256-GCM ciphertext.
+// The plaintext is a JSON object of key → value.
+const (
+	secretsFileVersion = 1
+	secretsSaltLen     = 16
+	secretsKeyLen      = 32
+	secretsKDFIter     = 600_000
+)
+
+// fileStore keeps secrets in an AES-GCM encrypted file. The key is derived
+// from SecretKeyEnv when set, or read from (and generated into) keyPath.
+// Derived keys are cached per passphrase and salt, and a rewrite keeps the
+// salt of the file it replaces, so the key is derived once per process.
+type fileStore struct {
+	mu      sync.Mutex
+	path    string
+	keyPath string
+	salt    []byte            // salt of the file last read or written
+	derived map[string][]byte // passphrase + salt -> key
+}
+
+// batch reads the secrets once, runs fn on them and writes them back if fn
+// changed them. Nothing is written when fn fails.
+func (s *fileStore) batch(fn func(SecretStore) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	before := maps.Clone(secrets)
+	if err := fn(mapStore(secrets)); err != nil {
+		return err
+	}
+	if maps.Equal(before, secrets) {
+		return nil
+	}
+	return s.write(secrets)
+}
+
+func (s *fileStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.read()
+	if err != nil {
+		return "", err
+	}
+	val, ok := secrets[key]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return val, nil
+}
+
+func (s *fileStore) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	secrets[key] = value
+	return s.write(secrets)
+}
+
+func (s *fileStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[key]; !ok {
+		return ErrSecretNotFound
+	}
+	delete(secrets, key)
+	return s.write(secrets)
+}
+
+// read decrypts the secrets file. A missing file yields an empty map.
+func (s *fileStore) read() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("config: reading secrets file: %w", err)
+	}
+	if len(data) < 1+secretsSaltLen || data[0] != secretsFileVersion {
+		return nil, errors.New("config: unrecognised secrets file format")
+	}
+	salt := data[1 : 1+secretsSaltLen]
+	s.salt = slices.Clone(salt)
+
+	gcm, err := s.cipher(salt, false)
+	if err != nil {
+		return nil, err
+	}
+	rest := data[1+secretsSaltLen:]
+	if len(rest) < gcm.NonceSize() {
+		return nil, errors.New("config: truncated secrets file")
+	}
+	nonce, ciphertext := rest[:gcm.NonceSize()], rest[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, data[:1])
+	if err != nil {
+		return nil, fmt.Errorf("config: decrypting secrets file (wrong %s?): %w", SecretKeyEnv, err)
+	}
+
+	secrets := map[string]string{}
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, fmt.Errorf("config: parsing secrets file: %w", err)
+	}
+	return secrets, nil
+}
+
+// write encrypts secrets with a fresh nonce and replaces the file. The salt
+// of the current file is kept; a new file gets a fresh one.
+func (s *fileStore) write(secrets map[string]string) error {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return fmt.Errorf("config: encoding secrets: %w", err)
+	}
+
+	salt := s.salt
+	if salt == nil {
+		salt = make([]byte, secretsSaltLen)
+		if _, err := rand.Read(salt); err != nil {
+			return fmt.Errorf("config: generating salt: %w", err)
+		}
+	}
+	gcm, err := s.cipher(salt, true)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("config: generating nonce: %w", err)
+	}
+
+	aad := []byte{secretsFileVersion}
+	out := make([]byte, 0, 1+len(salt)+len(nonce)+len(plain)+gcm.Overhead())
+	out = append(out, secretsFileVersion)
+	out = append(out, salt...)
+	out = append(out, nonce...)
+	out = gcm.Seal(out, nonce, plain, aad)
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("config: creating secrets directory: %w", err)
+	}
+	if err := writeFileAtomic(s.path, out, 0o600); err != nil {
+		return err
+	}
+	s.salt = salt
+	return nil
+}
+
+// cipher returns the AEAD for salt. With create set, a missing key file is
+// generated; otherwise a missing key file is an error.
+func (s *fileStore) cipher(salt []byte, create bool) (cipher.AEAD, error) {
+	key, err := s.key(salt, create)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("config: creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// key returns the 256-bit encryption key: derived from SecretKeyEnv via
+// PBKDF2 when set, otherwise the random key stored at keyPath.
+func (s *fileStore) key(salt []byte, create bool) ([]byte, error) {
+	if pass := os.Getenv(SecretKeyEnv); pass != "" {
+		id := pass + "\x00" + string(salt)
+		if key, ok := s.derived[id]; ok {
+			return key, nil
+		}
+		key, err := pbkdf2.Key(sha256.New, pass, salt, secretsKDFIter, secretsKeyLen)
+		if err != nil {
+			return nil, err
+		}
+		if s.derived == nil {
+			s.derived = map[string][]byte{}
+		}
+		s.derived[id] = key
+		return key, nil
+	}
+
+	key, err := os.ReadFile(s.keyPath)
+	if err == nil {
+		if len(key) != secretsKeyLen {
+			return nil, errors.New("config: invalid secrets key file")
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) || !create {
+		return nil, fmt.Errorf("config: reading secrets key: %w", err)
+	}
+
+	key = make([]byte, secretsKeyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("config: generating secrets key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.keyPath), 0o700); err != nil {
+		return nil, fmt.Errorf("config: creating secrets directory: %w", err)
+	}
+	if err := writeFileAtomic(s.keyPath, key, 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
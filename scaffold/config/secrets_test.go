@@ -0,0 +1,253 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestFileStore returns a fileStore rooted in a temp directory.
+func newTestFileStore(t *testing.T) *fileStore {
+	t.Helper()
+	dir := t.TempDir()
+	return &fileStore{
+		path:    filepath.Join(dir, "secrets.enc"),
+		keyPath: filepath.Join(dir, "secrets.key"),
+	}
+}
+
+// --- JSON exclusion ---
+
+func TestToJSON_OmitsSecrets(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Network.APIToken = "tok-123"
+	cfg.Network.ProxyPassword = "hunter2"
+
+	data, err := cfg.ToJSON()
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "tok-123")
+	assert.NotContains(t, string(data), "hunter2")
+}
+
+func TestSave_OmitsSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := DefaultConfig()
+	cfg.Network.APIToken = "tok-123"
+
+	require.NoError(t, Save(cfg, path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "tok-123")
+}
+
+func TestSchema_SecretFieldsMarked(t *testing.T) {
+	groups := Schema(DefaultConfig())
+
+	secrets := map[string]bool{}
+	for _, g := range groups {
+		for _, f := range g.Fields {
+			if f.Secret {
+				secrets[f.Key] = true
+			}
+		}
+	}
+	assert.Equal(t, map[string]bool{"network.apiToken": true, "network.proxyPassword": true}, secrets)
+}
+
+// --- LoadSecrets / SaveSecrets ---
+
+func TestSaveSecrets_LoadSecrets_RoundTrip(t *testing.T) {
+	store := mapStore{}
+	cfg := DefaultConfig()
+	cfg.Network.APIToken = "tok-123"
+	require.NoError(t, SaveSecrets(cfg, store))
+	assert.Equal(t, "tok-123", store["network.apiToken"])
+	assert.NotContains(t, store, "network.proxyPassword", "empty secrets must not be stored")
+
+	loaded := DefaultConfig()
+	require.NoError(t, LoadSecrets(loaded, store))
+	assert.Equal(t, "tok-123", loaded.Network.APIToken)
+}
+
+func TestSaveSecrets_EmptyValueDeletes(t *testing.T) {
+	store := mapStore{"network.apiToken": "old"}
+	require.NoError(t, SaveSecrets(DefaultConfig(), store))
+	assert.Empty(t, store)
+}
+
+func TestLoadSecrets_ProfileFallsBackToBase(t *testing.T) {
+	store := mapStore{
+		"network.apiToken":           "base-token",
+		"prod/network.proxyPassword": "prod-pass",
+		"network.proxyPassword":      "base-pass",
+	}
+	cfg := DefaultConfig()
+	cfg.Profile = "prod"
+
+	require.NoError(t, LoadSecrets(cfg, store))
+	assert.Equal(t, "base-token", cfg.Network.APIToken, "unset profile secret falls back to base")
+	assert.Equal(t, "prod-pass", cfg.Network.ProxyPassword, "profile secret wins")
+}
+
+func TestSaveSecrets_ScopedToProfile(t *testing.T) {
+	store := mapStore{}
+	cfg := DefaultConfig()
+	cfg.Profile = "dev"
+	cfg.Network.APIToken = "dev-token"
+
+	require.NoError(t, SaveSecrets(cfg, store))
+	assert.Equal(t, mapStore{"dev/network.apiToken": "dev-token"}, store)
+}
+
+func TestSaveSecrets_ProfileStoresOnlyOverrides(t *testing.T) {
+	store := mapStore{"network.apiToken": "base-token", "network.proxyPassword": "base-pass"}
+	cfg := DefaultConfig()
+	cfg.Profile = "dev"
+	require.NoError(t, LoadSecrets(cfg, store))
+
+	require.NoError(t, SaveSecrets(cfg, store))
+	assert.NotContains(t, store, "dev/network.apiToken", "inherited base values are not copied")
+
+	store["network.apiToken"] = "rotated"
+	require.NoError(t, LoadSecrets(cfg, store))
+	assert.Equal(t, "rotated", cfg.Network.APIToken, "the profile follows the base")
+
+	cfg.Network.ProxyPassword = ""
+	require.NoError(t, SaveSecrets(cfg, store))
+	assert.Equal(t, "", store["dev/network.proxyPassword"])
+	assert.Equal(t, "base-pass", store["network.proxyPassword"], "the base is untouched")
+	loaded := DefaultConfig()
+	loaded.Profile = "dev"
+	require.NoError(t, LoadSecrets(loaded, store))
+	assert.Empty(t, loaded.Network.ProxyPassword, "the profile clears the base secret")
+
+	cfg.Network.ProxyPassword = "base-pass"
+	require.NoError(t, SaveSecrets(cfg, store))
+	assert.NotContains(t, store, "dev/network.proxyPassword", "matching the base again drops the override")
+}
+
+// lockedStore is a SecretStore whose reads fail, like a locked keyring.
+type lockedStore struct{ mapStore }
+
+var errLocked = errors.New("keyring is locked")
+
+func (lockedStore) Get(string) (string, error) { return "", errLocked }
+
+func TestLoadSecrets_ReadFailureKeepsStoredSecrets(t *testing.T) {
+	store := lockedStore{mapStore{"network.apiToken": "tok-123", "network.proxyPassword": "hunter2"}}
+	cfg := DefaultConfig()
+
+	err := LoadSecrets(cfg, store)
+	require.ErrorIs(t, err, errLocked)
+	unread := UnreadSecrets(err)
+	assert.ElementsMatch(t, []string{"network.apiToken", "network.proxyPassword"}, unread)
+	assert.Empty(t, cfg.Network.APIToken)
+
+	require.NoError(t, SaveSecrets(cfg, store, unread...))
+	assert.Equal(t, "tok-123", store.mapStore["network.apiToken"], "an unread secret is not deleted")
+	assert.Equal(t, "hunter2", store.mapStore["network.proxyPassword"])
+
+	cfg.Network.APIToken = "tok-456"
+	require.NoError(t, SaveSecrets(cfg, store, unread...))
+	assert.Equal(t, "tok-456", store.mapStore["network.apiToken"], "a new value is still saved")
+}
+
+// --- fileStore ---
+
+func TestFileStore_RoundTripWithKeyFile(t *testing.T) {
+	t.Setenv(SecretKeyEnv, "")
+	s := newTestFileStore(t)
+
+	_, err := s.Get("missing")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+
+	require.NoError(t, s.Set("network.apiToken", "tok-123"))
+	got, err := s.Get("network.apiToken")
+	require.NoError(t, err)
+	assert.Equal(t, "tok-123", got)
+
+	raw, err := os.ReadFile(s.path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "tok-123", "secrets file must be encrypted")
+
+	for _, p := range []string{s.path, s.keyPath} {
+		info, err := os.Stat(p)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "%s must be private", p)
+	}
+
+	require.NoError(t, s.Delete("network.apiToken"))
+	_, err = s.Get("network.apiToken")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestFileStore_Passphrase(t *testing.T) {
+	t.Setenv(SecretKeyEnv, "correct horse")
+	s := newTestFileStore(t)
+	require.NoError(t, s.Set("k", "v"))
+
+	_, err := os.Stat(s.keyPath)
+	assert.True(t, os.IsNotExist(err), "no key file is written when a passphrase is set")
+
+	got, err := s.Get("k")
+	require.NoError(t, err)
+	assert.Equal(t, "v", got)
+
+	t.Setenv(SecretKeyEnv, "wrong")
+	_, err = s.Get("k")
+	assert.Error(t, err, "a wrong passphrase must fail to decrypt")
+}
+
+func TestFileStore_DerivesKeyOncePerSalt(t *testing.T) {
+	t.Setenv(SecretKeyEnv, "correct horse")
+	s := newTestFileStore(t)
+	cfg := DefaultConfig()
+	cfg.Network.APIToken = "tok-123"
+	cfg.Network.ProxyPassword = "hunter2"
+
+	require.NoError(t, SaveSecrets(cfg, s))
+	require.NoError(t, s.Set("k", "v"))
+	loaded := DefaultConfig()
+	require.NoError(t, LoadSecrets(loaded, s))
+	assert.Equal(t, "hunter2", loaded.Network.ProxyPassword)
+	assert.Len(t, s.derived, 1, "rewrites keep the salt, so the key is derived once")
+}
+
+func TestFileStore_BatchWritesOnlyChanges(t *testing.T) {
+	t.Setenv(SecretKeyEnv, "")
+	s := newTestFileStore(t)
+	require.NoError(t, SaveSecrets(DefaultConfig(), s))
+	_, err := os.Stat(s.path)
+	assert.True(t, os.IsNotExist(err), "an unchanged batch writes nothing")
+
+	err = s.batch(func(tx SecretStore) error {
+		require.NoError(t, tx.Set("k", "v"))
+		return errors.New("boom")
+	})
+	assert.EqualError(t, err, "boom")
+	_, err = s.Get("k")
+	assert.ErrorIs(t, err, ErrSecretNotFound, "a failed batch is not written")
+}
+
+func TestNewSecretStore_BuiltOncePerDirectory(t *testing.T) {
+	t.Setenv(SecretStoreEnv, "file")
+	dir := t.TempDir()
+	store := NewSecretStore(filepath.Join(dir, "config.json"))
+	assert.Same(t, store, NewSecretStore(filepath.Join(dir, "other.json")))
+	assert.NotSame(t, store, NewSecretStore(filepath.Join(t.TempDir(), "config.json")))
+}
+
+func TestKeyringService_ScopedToConfigDirectory(t *testing.T) {
+	a, b := t.TempDir(), t.TempDir()
+	assert.NotEqual(t, keyringService(a), keyringService(b), "configs in different directories never share secrets")
+	assert.Equal(t, keyringService(a), keyringService(filepath.Join(a, ".")))
+	assert.Regexp(t, "^"+Slugify(DefaultConfig().App.Name)+"-[0-9a-f]{8}$", keyringService(a))
+
+	t.Chdir(a)
+	assert.Equal(t, keyringService(a), keyringService("."), "relative directories are made absolute")
+}
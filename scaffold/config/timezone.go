@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	// Embed the IANA database so UI.TimeZone works on systems without one.
+	_ "time/tzdata"
+)
+
+// LocalTimeZone is the UI.TimeZone value that selects the system zone.
+const LocalTimeZone = "Local"
+
+// ValidateDateFormat reports whether layout is a usable Go time layout for
+// UI.DateFormat: it must contain at least one reference-time element and
+// parse the text it formats. The empty layout is valid and means the
+// default.
+func ValidateDateFormat(layout string) error {
+	if layout == "" {
+		return nil
+	}
+	// Any time other than the reference time changes every element.
+	sample := time.Date(2001, time.March, 4, 17, 8, 9, 0, time.UTC)
+	out := sample.Format(layout)
+	if out == layout {
+		return fmt.Errorf("%w: date format %q has no date or time elements", ErrInvalidConfig, layout)
+	}
+	if _, err := time.Parse(layout, out); err != nil {
+		return fmt.Errorf("%w: date format %q: %v", ErrInvalidConfig, layout, err)
+	}
+	return nil
+}
+
+// LoadTimeZone resolves UI.TimeZone, an IANA zone name such as
+// "Europe/Berlin". The empty name and LocalTimeZone return time.Local.
+func LoadTimeZone(zone string) (*time.Location, error) {
+	if zone == "" || zone == LocalTimeZone {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: time zone %q: %v", ErrInvalidConfig, zone, err)
+	}
+	return loc, nil
+}
@@ -0,0 +1,207 @@
+// Package httpclient builds the application's HTTP client from
+// NetworkConfig: proxy, TLS verification, timeout, retries with exponential
+// backoff, bearer authentication against APIEndpoint, and request/response
+// logging through the debug logger.
+//
+// Client wraps the configured *http.Client with helpers for JSON APIs;
+// HTTPClient exposes the *http.Client itself for libraries that want one.
+// FetchJSON runs a request as a task so results arrive in the Bubble Tea
+// loop as task.DoneMsg or task.ErrMsg.
+package httpclient
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"scaffold/config"
+	"scaffold/internal/task"
+)
+
+// StatusError is returned for responses outside the 2xx range.
+type StatusError struct {
+	Method string
+	URL    string
+	Code   int
+	Body   string // start of the response body, for diagnostics
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%s %s: %d %s", e.Method, e.URL, e.Code, http.StatusText(e.Code))
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// errorBodyLimit caps how much of an error response StatusError keeps.
+const errorBodyLimit = 512
+
+// Client sends requests to the configured API.
+type Client struct {
+	http  *http.Client
+	base  *url.URL // nil without an APIEndpoint
+	token string
+}
+
+// New creates a Client from cfg. It fails when APIEndpoint or ProxyURL is
+// not a valid absolute URL.
+func New(cfg config.NetworkConfig) (*Client, error) {
+	hc, err := NewHTTPClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{http: hc, token: cfg.APIToken}
+	if cfg.APIEndpoint != "" {
+		if c.base, err = parseAbsolute(cfg.APIEndpoint); err != nil {
+			return nil, fmt.Errorf("httpclient: api endpoint: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// NewHTTPClient creates an *http.Client from cfg. Timeout bounds each
+// request including its retries; without a ProxyURL the proxy comes from
+// the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment.
+func NewHTTPClient(cfg config.NetworkConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if cfg.ProxyURL != "" {
+		proxy, err := parseAbsolute(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("httpclient: proxy: %w", err)
+		}
+		if cfg.ProxyUsername != "" {
+			proxy.User = url.UserPassword(cfg.ProxyUsername, cfg.ProxyPassword)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if !cfg.VerifySSL {
+		// Opt-in for servers with self-signed certificates.
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &http.Client{
+		Transport: &retryTransport{
+			next:    &logTransport{next: transport},
+			retries: max(cfg.RetryCount, 0),
+		},
+		Timeout: time.Duration(max(cfg.Timeout, 0)) * time.Second,
+	}, nil
+}
+
+// HTTPClient returns the underlying client.
+func (c *Client) HTTPClient() *http.Client {
+	return c.http
+}
+
+// NewRequest creates a request for path, resolved against APIEndpoint
+// unless it is an absolute URL. The API token, when one is set, is only
+// sent to APIEndpoint's scheme and host, never to other servers.
+func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	target, err := c.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("httpclient: %w", err)
+	}
+	if c.token != "" && c.isAPI(req.URL) {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+// Do sends req. Responses outside the 2xx range are returned as a
+// *StatusError with the body closed.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, errorBodyLimit))
+		return nil, &StatusError{
+			Method: req.Method,
+			URL:    req.URL.Redacted(),
+			Code:   resp.StatusCode,
+			Body:   strings.TrimSpace(string(snippet)),
+		}
+	}
+	return resp, nil
+}
+
+// GetJSON fetches path and decodes the JSON response into v.
+func (c *Client) GetJSON(ctx context.Context, path string, v any) error {
+	req, err := c.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("httpclient: decoding %s: %w", req.URL.Redacted(), err)
+	}
+	return nil
+}
+
+// FetchJSON fetches path in a task and decodes the response into a T,
+// delivered as task.DoneMsg[T] or, on failure, task.ErrMsg.
+func FetchJSON[T any](ctx context.Context, c *Client, label, path string) tea.Cmd {
+	return task.Run(ctx, label, func(ctx context.Context) (T, error) {
+		var v T
+		err := c.GetJSON(ctx, path, &v)
+		return v, err
+	})
+}
+
+// resolve turns path into an absolute URL.
+func (c *Client) resolve(path string) (string, error) {
+	ref, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("httpclient: %w", err)
+	}
+	if ref.IsAbs() {
+		return ref.String(), nil
+	}
+	if c.base == nil {
+		return "", fmt.Errorf("httpclient: relative path %q without an API endpoint", path)
+	}
+	// Treat the endpoint as a directory so "v1/items" extends its path.
+	base := *c.base
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	return base.ResolveReference(&url.URL{Path: strings.TrimPrefix(ref.Path, "/"), RawQuery: ref.RawQuery}).String(), nil
+}
+
+// isAPI reports whether u is on APIEndpoint's scheme and host.
+func (c *Client) isAPI(u *url.URL) bool {
+	return c.base != nil && strings.EqualFold(u.Scheme, c.base.Scheme) && strings.EqualFold(u.Host, c.base.Host)
+}
+
+// parseAbsolute parses s and requires a scheme and host.
+func parseAbsolute(s string) (*url.URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute URL", s)
+	}
+	return u, nil
+}
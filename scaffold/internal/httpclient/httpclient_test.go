@@ -0,0 +1,297 @@
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/config"
+	"scaffold/internal/logger"
+	"scaffold/internal/task"
+)
+
+// fastBackoff shortens retry delays for the test.
+func fastBackoff(t *testing.T) {
+	t.Helper()
+	base, limit := backoffBase, backoffMax
+	backoffBase, backoffMax = time.Millisecond, 5*time.Millisecond
+	t.Cleanup(func() { backoffBase, backoffMax = base, limit })
+}
+
+func testConfig(endpoint string) config.NetworkConfig {
+	return config.NetworkConfig{APIEndpoint: endpoint, Timeout: 5, RetryCount: 3, VerifySSL: true}
+}
+
+func TestClient_GetJSON_ResolvesPathAndSendsToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/items", r.URL.Path)
+		assert.Equal(t, "a=1", r.URL.RawQuery)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		_, _ = io.WriteString(w, `{"name":"widget"}`)
+	}))
+	defer srv.Close()
+
+	cfg := testConfig(srv.URL + "/api")
+	cfg.APIToken = "secret"
+	c, err := New(cfg)
+	require.NoError(t, err)
+
+	var got struct{ Name string }
+	require.NoError(t, c.GetJSON(context.Background(), "/v1/items?a=1", &got))
+	assert.Equal(t, "widget", got.Name)
+}
+
+func TestClient_SendsTokenOnlyToAPIEndpoint(t *testing.T) {
+	cfg := testConfig("https://api.example.com/v1")
+	cfg.APIToken = "secret"
+	c, err := New(cfg)
+	require.NoError(t, err)
+
+	for target, want := range map[string]string{
+		"/items":                            "Bearer secret",
+		"https://API.example.com/v2/items":  "Bearer secret",
+		"https://cdn.example.com/asset.js":  "",
+		"http://api.example.com/v1/items":   "",
+		"https://api.example.com:8443/item": "",
+	} {
+		req, err := c.NewRequest(context.Background(), http.MethodGet, target, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, req.Header.Get("Authorization"), target)
+	}
+}
+
+func TestClient_RetriesTemporaryFailures(t *testing.T) {
+	fastBackoff(t)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+
+	c, err := New(testConfig(srv.URL))
+	require.NoError(t, err)
+	var got map[string]any
+	require.NoError(t, c.GetJSON(context.Background(), "/", &got))
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestClient_RetriesReplayBody(t *testing.T) {
+	fastBackoff(t)
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(testConfig(srv.URL))
+	require.NoError(t, err)
+	req, err := c.NewRequest(context.Background(), http.MethodPut, "/", bytes.NewBufferString("payload"))
+	require.NoError(t, err)
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, []string{"payload", "payload"}, bodies)
+}
+
+func TestClient_RetriesPostOnlyWithIdempotencyKey(t *testing.T) {
+	fastBackoff(t)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer srv.Close()
+
+	c, err := New(testConfig(srv.URL))
+	require.NoError(t, err)
+	post := func(key string) {
+		req, err := c.NewRequest(context.Background(), http.MethodPost, "/", bytes.NewBufferString("payload"))
+		require.NoError(t, err)
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		_, err = c.Do(req)
+		var se *StatusError
+		require.ErrorAs(t, err, &se)
+	}
+
+	post("")
+	assert.EqualValues(t, 1, calls.Load(), "a POST may have been applied, so it is sent once")
+
+	calls.Store(0)
+	post("order-42")
+	assert.EqualValues(t, 4, calls.Load(), "with an Idempotency-Key it is retried")
+}
+
+func TestClient_GivesUpAfterRetryCount(t *testing.T) {
+	fastBackoff(t)
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	cfg := testConfig(srv.URL)
+	cfg.RetryCount = 2
+	c, err := New(cfg)
+	require.NoError(t, err)
+	err = c.GetJSON(context.Background(), "/", new(any))
+
+	var se *StatusError
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, http.StatusTooManyRequests, se.Code)
+	assert.EqualValues(t, 3, calls.Load(), "one attempt plus two retries")
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "no such item", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c, err := New(testConfig(srv.URL))
+	require.NoError(t, err)
+	err = c.GetJSON(context.Background(), "/missing", new(any))
+
+	var se *StatusError
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, http.StatusNotFound, se.Code)
+	assert.Equal(t, "no such item", se.Body)
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestClient_StopsRetryingWhenCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c, err := New(testConfig(srv.URL))
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = c.GetJSON(ctx, "/", new(any))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestNewHTTPClient_VerifySSL(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	cfg := testConfig(srv.URL)
+	cfg.RetryCount = 0
+	strict, err := NewHTTPClient(cfg)
+	require.NoError(t, err)
+	_, err = strict.Get(srv.URL)
+	assert.Error(t, err, "self-signed certificate is rejected")
+
+	cfg.VerifySSL = false
+	lax, err := NewHTTPClient(cfg)
+	require.NoError(t, err)
+	resp, err := lax.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
+
+func TestNewHTTPClient_UsesProxyWithCredentials(t *testing.T) {
+	var gotURL, gotAuth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		gotAuth = r.Header.Get("Proxy-Authorization")
+	}))
+	defer proxy.Close()
+
+	cfg := testConfig("")
+	cfg.ProxyURL = proxy.URL
+	cfg.ProxyUsername = "user"
+	cfg.ProxyPassword = "pass"
+	hc, err := NewHTTPClient(cfg)
+	require.NoError(t, err)
+	resp, err := hc.Get("http://api.invalid/ping")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, "http://api.invalid/ping", gotURL)
+	assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte("user:pass")), gotAuth)
+}
+
+func TestNew_RejectsInvalidURLs(t *testing.T) {
+	_, err := New(testConfig("api.example.com"))
+	assert.Error(t, err)
+
+	cfg := testConfig("")
+	cfg.ProxyURL = "not a url"
+	_, err = New(cfg)
+	assert.Error(t, err)
+}
+
+func TestClient_RelativePathNeedsEndpoint(t *testing.T) {
+	c, err := New(testConfig(""))
+	require.NoError(t, err)
+	_, err = c.NewRequest(context.Background(), http.MethodGet, "/items", nil)
+	assert.Error(t, err)
+}
+
+func TestFetchJSON_DeliversTaskMessages(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/bad" {
+			http.Error(w, "nope", http.StatusForbidden)
+			return
+		}
+		_, _ = io.WriteString(w, `[1,2,3]`)
+	}))
+	defer srv.Close()
+	c, err := New(testConfig(srv.URL))
+	require.NoError(t, err)
+
+	done, ok := FetchJSON[[]int](context.Background(), c, "numbers", "/")().(task.DoneMsg[[]int])
+	require.True(t, ok)
+	assert.Equal(t, "numbers", done.Label)
+	assert.Equal(t, []int{1, 2, 3}, done.Value)
+
+	failed, ok := FetchJSON[[]int](context.Background(), c, "numbers", "/bad")().(task.ErrMsg)
+	require.True(t, ok)
+	var se *StatusError
+	assert.True(t, errors.As(failed.Err, &se))
+}
+
+func TestClient_LogsRequestsWithoutCredentials(t *testing.T) {
+	var buf bytes.Buffer
+	logger.SetupWithWriter(&buf)
+	t.Cleanup(func() { logger.Setup(false) })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+	cfg := testConfig(srv.URL)
+	cfg.APIToken = "secret"
+	c, err := New(cfg)
+	require.NoError(t, err)
+	require.NoError(t, c.GetJSON(context.Background(), "/status", new(any)))
+
+	assert.Contains(t, buf.String(), "--> GET "+srv.URL+"/status")
+	assert.Contains(t, buf.String(), "<-- GET "+srv.URL+"/status 200 OK")
+	assert.NotContains(t, buf.String(), "secret")
+}
@@ -0,0 +1,151 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"scaffold/internal/logger"
+)
+
+// Backoff between retries: the delay doubles from backoffBase up to
+// backoffMax, with up to 50% jitter so clients don't retry in lockstep.
+// Variables so tests can shorten them.
+var (
+	backoffBase = 250 * time.Millisecond
+	backoffMax  = 5 * time.Second
+)
+
+// retryTransport retries failed round trips: network errors and responses
+// that signal a temporary condition (429 and 502–504). Only idempotent
+// requests are retried, since a failed write may still have been applied;
+// requests whose body cannot be replayed are sent once.
+type retryTransport struct {
+	next    http.RoundTripper
+	retries int
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.retries || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		delay := backoff(attempt)
+		if resp != nil {
+			if d, ok := retryAfter(resp); ok {
+				delay = d
+			}
+			resp.Body.Close()
+		}
+		logger.Debug("http: retrying %s %s in %s (attempt %d of %d)",
+			req.Method, req.URL.Redacted(), delay, attempt+1, t.retries)
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// idempotentMethods are the methods whose requests may be sent twice with
+// the effect of sending them once.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// idempotent reports whether req may be sent again: its method is
+// idempotent, or it carries an Idempotency-Key the server deduplicates on.
+func idempotent(req *http.Request) bool {
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	return idempotentMethods[method] || req.Header.Get("Idempotency-Key") != ""
+}
+
+// retryable reports whether a round trip may be repeated.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if !idempotent(req) {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		// A cancelled or expired request stays that way.
+		return req.Context().Err() == nil && !errors.Is(err, context.Canceled)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// backoff returns the delay before retry number attempt+1.
+func backoff(attempt int) time.Duration {
+	d := backoffBase << attempt
+	if d <= 0 || d > backoffMax {
+		d = backoffMax
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+// retryAfter reads a Retry-After header given in seconds, capped at
+// backoffMax.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	secs, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	return min(time.Duration(secs)*time.Second, backoffMax), true
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// logTransport logs every round trip to the debug log. URLs are redacted
+// and headers are not logged, so credentials never reach the log.
+type logTransport struct {
+	next http.RoundTripper
+}
+
+func (t *logTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	logger.Debug("http: --> %s %s", req.Method, req.URL.Redacted())
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		logger.Debug("http: <-- %s %s failed after %s: %v", req.Method, req.URL.Redacted(), elapsed, err)
+		return nil, err
+	}
+	logger.Debug("http: <-- %s %s %s (%s, %d bytes)",
+		req.Method, req.URL.Redacted(), resp.Status, elapsed, resp.ContentLength)
+	return resp, nil
+}
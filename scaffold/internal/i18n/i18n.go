@@ -0,0 +1,186 @@
+// Package i18n provides the message catalog for UIConfig.Language.
+//
+// Catalogs are embedded JSON files under locales/, one per language, mapping
+// a dot-separated message key to either a string or, for counted messages,
+// an object of CLDR plural forms ("one", "other"). Lookups fall back to the
+// English catalog, so a partial translation never shows a raw key for text
+// that English covers.
+//
+// The current language is process-wide: rootModel sets it from the config
+// at startup and whenever the Language setting changes, then asks every
+// Localizable component to rebuild its cached text.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Fallback is the language every lookup falls back to. Its catalog must
+// contain every key used in code.
+const Fallback = "en"
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// Localizable is implemented by components that cache translated text and
+// must rebuild it when the language changes.
+type Localizable interface {
+	ApplyLanguage()
+}
+
+// message is one catalog entry: a plain string, or plural forms keyed by
+// CLDR category.
+type message struct {
+	text   string
+	plural map[string]string
+}
+
+// UnmarshalJSON accepts either a JSON string or an object of plural forms.
+func (m *message) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &m.text)
+	}
+	return json.Unmarshal(data, &m.plural)
+}
+
+// form returns the text for a plural category, falling back to "other".
+func (m message) form(category string) string {
+	if m.plural == nil {
+		return m.text
+	}
+	if s, ok := m.plural[category]; ok {
+		return s
+	}
+	return m.plural["other"]
+}
+
+type catalog map[string]message
+
+var (
+	catalogs = mustLoadCatalogs()
+
+	mu      sync.RWMutex
+	current = Fallback
+)
+
+// mustLoadCatalogs parses every embedded locale file. A malformed catalog is
+// a build defect, so it panics at init rather than failing at lookup time.
+func mustLoadCatalogs() map[string]catalog {
+	files, err := localeFS.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: reading locales: %v", err))
+	}
+	out := make(map[string]catalog, len(files))
+	for _, f := range files {
+		data, err := localeFS.ReadFile(path.Join("locales", f.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: reading %s: %v", f.Name(), err))
+		}
+		var c catalog
+		if err := json.Unmarshal(data, &c); err != nil {
+			panic(fmt.Sprintf("i18n: parsing %s: %v", f.Name(), err))
+		}
+		out[strings.TrimSuffix(f.Name(), ".json")] = c
+	}
+	return out
+}
+
+// Languages returns the codes of every embedded catalog, English first.
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		if lang != Fallback {
+			langs = append(langs, lang)
+		}
+	}
+	slices.Sort(langs)
+	return append([]string{Fallback}, langs...)
+}
+
+// SetLanguage makes lang the current language and reports whether it
+// changed. Unknown codes select Fallback.
+func SetLanguage(lang string) bool {
+	if _, ok := catalogs[lang]; !ok {
+		lang = Fallback
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if lang == current {
+		return false
+	}
+	current = lang
+	return true
+}
+
+// Language returns the current language code.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// DisplayName returns the native name of a language ("Deutsch", "日本語"),
+// or the code itself when no catalog exists for it.
+func DisplayName(lang string) string {
+	if m, ok := catalogs[lang]["language.name"]; ok {
+		return m.text
+	}
+	return lang
+}
+
+// lookup finds key in the current catalog, then in Fallback.
+func lookup(key string) (message, string, bool) {
+	lang := Language()
+	if m, ok := catalogs[lang][key]; ok {
+		return m, lang, true
+	}
+	if m, ok := catalogs[Fallback][key]; ok {
+		return m, Fallback, true
+	}
+	return message{}, "", false
+}
+
+// Lookup returns the translation of key and whether any catalog has it.
+// Use it for text whose English source lives outside the catalog, such as
+// cfg_label tags; use T for keys the English catalog defines.
+func Lookup(key string) (string, bool) {
+	m, _, ok := lookup(key)
+	if !ok {
+		return "", false
+	}
+	return m.form("other"), true
+}
+
+// T returns the translation of key, formatted with args as by fmt.Sprintf.
+// A key missing from every catalog is returned verbatim so it stands out.
+func T(key string, args ...any) string {
+	m, _, ok := lookup(key)
+	if !ok {
+		return key
+	}
+	return format(m.form("other"), args)
+}
+
+// N returns the plural form of key that matches n in the language the
+// message was found in. n is passed as the first format argument, followed
+// by args.
+func N(key string, n int, args ...any) string {
+	m, lang, ok := lookup(key)
+	if !ok {
+		return key
+	}
+	return format(m.form(pluralCategory(lang, n)), append([]any{n}, args...))
+}
+
+func format(s string, args []any) string {
+	if len(args) == 0 {
+		return s
+	}
+	return fmt.Sprintf(s, args...)
+}
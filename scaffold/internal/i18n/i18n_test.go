@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/config"
+)
+
+// useLanguage switches to lang for the duration of the test.
+func useLanguage(t *testing.T, lang string) {
+	t.Helper()
+	prev := Language()
+	SetLanguage(lang)
+	t.Cleanup(func() { SetLanguage(prev) })
+}
+
+func TestLanguages_MatchConfigOptions(t *testing.T) {
+	langs := Languages()
+	require.NotEmpty(t, langs)
+	assert.Equal(t, Fallback, langs[0])
+
+	cfg := config.DefaultConfig()
+	var options []string
+	for _, g := range config.Schema(cfg) {
+		for _, f := range g.Fields {
+			if f.Key == "ui.language" {
+				options = f.Options
+			}
+		}
+	}
+	assert.ElementsMatch(t, options, langs, "cfg_options of ui.language must list every catalog")
+}
+
+func TestSetLanguage(t *testing.T) {
+	useLanguage(t, Fallback)
+
+	assert.True(t, SetLanguage("de"))
+	assert.Equal(t, "de", Language())
+	assert.False(t, SetLanguage("de"), "same language is not a change")
+
+	assert.True(t, SetLanguage("xx"))
+	assert.Equal(t, Fallback, Language(), "unknown codes select the fallback")
+}
+
+func TestT_TranslatesAndFormats(t *testing.T) {
+	useLanguage(t, "es")
+	assert.Equal(t, "Perfil: work", T("status.profile", "work"))
+
+	SetLanguage(Fallback)
+	assert.Equal(t, "Profile: work", T("status.profile", "work"))
+}
+
+func TestT_FallsBackToEnglish(t *testing.T) {
+	useLanguage(t, "ja")
+	catalogs[Fallback]["test.onlyEnglish"] = message{text: "English only"}
+	t.Cleanup(func() { delete(catalogs[Fallback], "test.onlyEnglish") })
+
+	assert.Equal(t, "English only", T("test.onlyEnglish"))
+}
+
+func TestT_MissingKeyIsReturnedVerbatim(t *testing.T) {
+	assert.Equal(t, "no.such.key", T("no.such.key"))
+	_, ok := Lookup("no.such.key")
+	assert.False(t, ok)
+}
+
+func TestLookup_FieldTranslations(t *testing.T) {
+	useLanguage(t, Fallback)
+	_, ok := Lookup("field.ui.language.label")
+	assert.False(t, ok, "English field labels come from cfg_label tags")
+
+	SetLanguage("zh")
+	label, ok := Lookup("field.ui.language.label")
+	assert.True(t, ok)
+	assert.Equal(t, "语言", label)
+}
+
+func TestN_PluralForms(t *testing.T) {
+	tests := []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{"en", 1, "Save 1 change?"},
+		{"en", 2, "Save 2 changes?"},
+		{"en", 0, "Save 0 changes?"},
+		{"fr", 0, "Enregistrer 0 modification ?"},
+		{"fr", 3, "Enregistrer 3 modifications ?"},
+		{"ja", 1, "1 件の変更を保存しますか？"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			useLanguage(t, tt.lang)
+			assert.Equal(t, tt.want, N("settings.save.title", tt.n))
+		})
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	assert.Equal(t, "Deutsch", DisplayName("de"))
+	assert.Equal(t, "日本語", DisplayName("ja"))
+	assert.Equal(t, "xx", DisplayName("xx"))
+}
+
+var verbRe = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+// forms returns every text of m, one per plural form.
+func forms(m message) []string {
+	if m.plural == nil {
+		return []string{m.text}
+	}
+	out := make([]string, 0, len(m.plural))
+	for _, s := range m.plural {
+		out = append(out, s)
+	}
+	return out
+}
+
+// TestCatalogs_Consistent checks every translation against its English
+// source: the key must exist (in the English catalog, or as a schema field
+// or group for field./group. keys), format verbs must match, and plural
+// messages must define "other".
+func TestCatalogs_Consistent(t *testing.T) {
+	schemaKeys := map[string]bool{}
+	for _, g := range config.Schema(config.DefaultConfig()) {
+		schemaKeys["group."+g.Key] = true
+		for _, f := range g.Fields {
+			schemaKeys["field."+f.Key] = true
+		}
+	}
+	en := catalogs[Fallback]
+
+	for lang, c := range catalogs {
+		for key, m := range c {
+			if m.plural != nil {
+				assert.Contains(t, m.plural, "other", "%s: %s has no \"other\" form", lang, key)
+			}
+			if strings.HasPrefix(key, "field.") || strings.HasPrefix(key, "group.") {
+				assert.True(t, schemaKeys[schemaKey(key)], "%s: %s names no settings field", lang, key)
+				continue
+			}
+			src, ok := en[key]
+			if !assert.True(t, ok, "%s: %s is missing from %s.json", lang, key, Fallback) {
+				continue
+			}
+			want := verbRe.FindAllString(src.form("other"), -1)
+			for _, s := range forms(m) {
+				got := verbRe.FindAllString(s, -1)
+				assert.True(t, slices.Equal(want, got), "%s: %s has verbs %v, want %v", lang, key, got, want)
+			}
+		}
+	}
+}
+
+// schemaKey strips the .label/.desc/.options.<value> suffix from a field key.
+func schemaKey(key string) string {
+	if !strings.HasPrefix(key, "field.") {
+		return key
+	}
+	if i := strings.Index(key, ".options."); i >= 0 {
+		return key[:i]
+	}
+	return key[:strings.LastIndex(key, ".")]
+}
@@ -0,0 +1,22 @@
+package i18n
+
+// pluralCategory returns the CLDR plural category of the integer n in lang.
+// Only the categories needed by the embedded catalogs are distinguished.
+func pluralCategory(lang string, n int) string {
+	switch lang {
+	case "ja", "zh":
+		// No grammatical number.
+		return "other"
+	case "fr":
+		// Zero takes the singular in French.
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+	default: // en, es, de
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	}
+}
@@ -0,0 +1,86 @@
+package notify
+
+import (
+	"os"
+	"runtime"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Bell rings the terminal bell for notifications that may play a sound.
+type Bell struct{}
+
+func (Bell) Deliver(n Notification) tea.Cmd {
+	if !n.Sound {
+		return nil
+	}
+	return tea.Raw("\a")
+}
+
+func (Bell) External() bool { return true }
+
+// OSC shows notifications through the terminal emulator with an escape
+// sequence, which most terminals forward to the desktop.
+type OSC struct {
+	// Variant is 9 (iTerm2, WezTerm, Ghostty, kitty) or 777 (urxvt, foot,
+	// VTE terminals). OSC 9 carries no title, so the title prefixes the
+	// body.
+	Variant int
+}
+
+func (o OSC) Deliver(n Notification) tea.Cmd {
+	title, body := oscText(n.Title), oscText(n.Body)
+	if o.Variant == 777 {
+		// Fields are ';'-separated, so the title cannot contain one.
+		title = strings.ReplaceAll(title, ";", ",")
+		return tea.Raw("\x1b]777;notify;" + title + ";" + body + "\x07")
+	}
+	return tea.Raw(ansi.Notify(title + ": " + body))
+}
+
+func (OSC) External() bool { return true }
+
+// oscText removes control characters, which would end or corrupt the
+// escape sequence.
+func oscText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// Detect returns the external backends usable in this environment: the
+// bell always, plus desktop notifications over D-Bus when a session bus is
+// available, or else terminal notifications when the terminal is known to
+// support them. In-app backends are the UI's to add.
+func Detect(appName string) []Backend {
+	backends := []Backend{Bell{}}
+	switch {
+	case runtime.GOOS == "linux" && os.Getenv("DBUS_SESSION_BUS_ADDRESS") != "":
+		backends = append(backends, SessionBus(appName))
+	case oscVariant() != 0:
+		backends = append(backends, OSC{Variant: oscVariant()})
+	}
+	return backends
+}
+
+// oscVariant returns the OSC notification code the terminal understands,
+// or 0 if unknown.
+func oscVariant() int {
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "ghostty":
+		return 9
+	}
+	term := os.Getenv("TERM")
+	switch {
+	case strings.Contains(term, "kitty"):
+		return 9
+	case strings.HasPrefix(term, "rxvt"), strings.HasPrefix(term, "foot"), os.Getenv("VTE_VERSION") != "":
+		return 777
+	}
+	return 0
+}
@@ -0,0 +1,93 @@
+package notify
+
+import (
+	"sync"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/godbus/dbus/v5"
+
+	"scaffold/internal/logger"
+)
+
+// The freedesktop notification service.
+const (
+	dbusDest   = "org.freedesktop.Notifications"
+	dbusPath   = "/org/freedesktop/Notifications"
+	dbusNotify = dbusDest + ".Notify"
+)
+
+// Urgency levels of the freedesktop "urgency" hint.
+const (
+	urgencyNormal   byte = 1
+	urgencyCritical byte = 2
+)
+
+// Caller is the part of a D-Bus object the backend uses. dbus.BusObject
+// satisfies it; tests substitute a fake.
+type Caller interface {
+	Call(method string, flags dbus.Flags, args ...any) *dbus.Call
+}
+
+// DBus shows desktop notifications through org.freedesktop.Notifications.
+type DBus struct {
+	appName string
+	connect func() (Caller, error)
+
+	once sync.Once
+	obj  Caller
+	err  error
+}
+
+// NewDBus creates a backend calling obj, the notification service object.
+func NewDBus(appName string, obj Caller) *DBus {
+	return &DBus{appName: appName, connect: func() (Caller, error) { return obj, nil }}
+}
+
+// SessionBus creates a backend on the user's session bus. The connection
+// is made on the first notification; if it fails, notifications are
+// dropped and the error is logged.
+func SessionBus(appName string) *DBus {
+	return &DBus{appName: appName, connect: func() (Caller, error) {
+		conn, err := dbus.ConnectSessionBus()
+		if err != nil {
+			return nil, err
+		}
+		return conn.Object(dbusDest, dbusPath), nil
+	}}
+}
+
+// Deliver sends n in the background. Errors are only logged; a missing
+// notification daemon should not disturb the UI.
+func (d *DBus) Deliver(n Notification) tea.Cmd {
+	return func() tea.Msg {
+		d.once.Do(func() { d.obj, d.err = d.connect() })
+		if d.err != nil {
+			logger.Debug("notify: d-bus unavailable: %v", d.err)
+			return nil
+		}
+		urgency := urgencyNormal
+		if n.Kind == KindError {
+			urgency = urgencyCritical
+		}
+		hints := map[string]dbus.Variant{
+			"urgency":        dbus.MakeVariant(urgency),
+			"suppress-sound": dbus.MakeVariant(!n.Sound),
+		}
+		call := d.obj.Call(dbusNotify, 0,
+			d.appName,  // app_name
+			uint32(0),  // replaces_id
+			"",         // app_icon
+			n.Title,    // summary
+			n.Body,     // body
+			[]string{}, // actions
+			hints,      // hints
+			int32(-1),  // expire_timeout: server default
+		)
+		if call.Err != nil {
+			logger.Debug("notify: d-bus notify failed: %v", call.Err)
+		}
+		return nil
+	}
+}
+
+func (*DBus) External() bool { return true }
@@ -0,0 +1,167 @@
+// Package notify tells the user about finished work: failed tasks and tasks
+// that ran long enough for the user to have looked away. A Notifier applies
+// NotificationsConfig (the master switch, per-event toggles, sound and
+// quiet hours) and hands each notification to a set of Backends: the
+// terminal bell, OSC 9/777 terminal notifications, desktop notifications
+// over D-Bus, and in-app backends such as the UI's toasts.
+package notify
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/task"
+	"scaffold/internal/timefmt"
+)
+
+// LongTask is how long a task must run before its completion is notified.
+const LongTask = 10 * time.Second
+
+// Kind classifies a notification.
+type Kind int
+
+const (
+	KindInfo Kind = iota
+	KindSuccess
+	KindError
+)
+
+// Notification is one message to deliver.
+type Notification struct {
+	Title string
+	Body  string
+	Kind  Kind
+	Sound bool // the backend may play a sound
+}
+
+// Backend delivers notifications to one destination.
+type Backend interface {
+	// Deliver returns a command that shows n, or nil if the backend has
+	// nothing to do for it.
+	Deliver(n Notification) tea.Cmd
+	// External reports whether the backend reaches beyond the app's own
+	// screen: sounds, terminal or desktop notifications. Quiet hours mute
+	// external backends.
+	External() bool
+}
+
+// Notifier decides whether and where to deliver notifications.
+type Notifier struct {
+	cfg      config.NotificationsConfig
+	quiet    quietHours
+	backends []Backend
+	now      func() time.Time // for tests; nil means time.Now
+}
+
+// New creates a Notifier delivering to backends under cfg.
+func New(cfg config.NotificationsConfig, backends ...Backend) Notifier {
+	return Notifier{backends: backends}.WithConfig(cfg)
+}
+
+// WithConfig returns a copy of the Notifier using cfg. Invalid quiet hours,
+// which config.Validate rejects, disable quiet hours.
+func (n Notifier) WithConfig(cfg config.NotificationsConfig) Notifier {
+	n.cfg = cfg
+	n.quiet, _ = parseQuietHours(cfg.QuietHoursStart, cfg.QuietHoursEnd)
+	return n
+}
+
+// Quiet reports whether t falls within quiet hours.
+func (n Notifier) Quiet(t time.Time) bool {
+	return n.quiet.contains(t)
+}
+
+// Notify delivers note to every backend, unless notifications are off.
+// During quiet hours only the in-app backends are used and sound is off;
+// outside them sound follows SoundEnabled.
+func (n Notifier) Notify(note Notification) tea.Cmd {
+	if !n.cfg.EnableNotifications {
+		return nil
+	}
+	quiet := n.Quiet(n.clock())
+	note.Sound = n.cfg.SoundEnabled && !quiet
+
+	var cmds []tea.Cmd
+	for _, b := range n.backends {
+		if quiet && b.External() {
+			continue
+		}
+		cmds = append(cmds, b.Deliver(note))
+	}
+	return tea.Batch(cmds...)
+}
+
+// TaskFinished notifies about a finished task: failures when NotifyOnError
+// is set, and completions of at least LongTask when NotifyOnComplete is
+// set. Cancelled tasks are not reported; the user cancelled them.
+func (n Notifier) TaskFinished(f task.Finished) tea.Cmd {
+	err := f.TaskErr()
+	switch {
+	case errors.Is(err, context.Canceled):
+		return nil
+	case err != nil:
+		if !n.cfg.NotifyOnError {
+			return nil
+		}
+		return n.Notify(Notification{
+			Title: i18n.T("notify.taskFailed"),
+			Body:  i18n.T("notify.taskFailedBody", f.TaskLabel(), err),
+			Kind:  KindError,
+		})
+	case f.TaskElapsed() >= LongTask:
+		if !n.cfg.NotifyOnComplete {
+			return nil
+		}
+		return n.Notify(Notification{
+			Title: i18n.T("notify.taskDone"),
+			Body:  i18n.T("notify.taskDoneBody", f.TaskLabel(), timefmt.Elapsed(f.TaskElapsed())),
+			Kind:  KindSuccess,
+		})
+	}
+	return nil
+}
+
+func (n Notifier) clock() time.Time {
+	if n.now != nil {
+		return n.now()
+	}
+	return time.Now()
+}
+
+// quietHours is a daily window given as offsets from midnight. A window
+// whose end is before its start wraps midnight; an empty one (start equal
+// to end, or unset) is never quiet.
+type quietHours struct {
+	start, end time.Duration
+}
+
+// parseQuietHours parses HH:MM bounds. Either being empty disables quiet
+// hours.
+func parseQuietHours(start, end string) (quietHours, error) {
+	if start == "" || end == "" {
+		return quietHours{}, nil
+	}
+	s, err := config.ParseTimeOfDay(start)
+	if err != nil {
+		return quietHours{}, err
+	}
+	e, err := config.ParseTimeOfDay(end)
+	if err != nil {
+		return quietHours{}, err
+	}
+	return quietHours{start: s, end: e}, nil
+}
+
+// contains reports whether t's wall-clock time is inside the window.
+func (q quietHours) contains(t time.Time) bool {
+	at := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if q.start <= q.end {
+		return at >= q.start && at < q.end
+	}
+	return at >= q.start || at < q.end
+}
@@ -0,0 +1,179 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/config"
+	"scaffold/internal/task"
+)
+
+// recorder is a Backend that records what it is asked to deliver.
+type recorder struct {
+	external bool
+	got      []Notification
+}
+
+func (r *recorder) Deliver(n Notification) tea.Cmd {
+	r.got = append(r.got, n)
+	return nil
+}
+
+func (r *recorder) External() bool { return r.external }
+
+// fakeBus records D-Bus calls.
+type fakeBus struct {
+	method string
+	args   []any
+	err    error
+}
+
+func (f *fakeBus) Call(method string, _ dbus.Flags, args ...any) *dbus.Call {
+	f.method, f.args = method, args
+	return &dbus.Call{Err: f.err}
+}
+
+func enabledConfig() config.NotificationsConfig {
+	return config.NotificationsConfig{
+		EnableNotifications: true,
+		SoundEnabled:        true,
+		NotifyOnError:       true,
+		NotifyOnComplete:    true,
+		QuietHoursStart:     "22:00",
+		QuietHoursEnd:       "07:00",
+	}
+}
+
+// at returns a Notifier whose clock reads hh:mm.
+func at(n Notifier, hh, mm int) Notifier {
+	n.now = func() time.Time { return time.Date(2026, 1, 2, hh, mm, 0, 0, time.Local) }
+	return n
+}
+
+func TestQuietHours_WrapsMidnight(t *testing.T) {
+	q, err := parseQuietHours("22:00", "07:00")
+	require.NoError(t, err)
+	day := func(hh, mm int) time.Time { return time.Date(2026, 1, 2, hh, mm, 0, 0, time.UTC) }
+
+	assert.True(t, q.contains(day(22, 0)))
+	assert.True(t, q.contains(day(23, 59)))
+	assert.True(t, q.contains(day(0, 0)))
+	assert.True(t, q.contains(day(6, 59)))
+	assert.False(t, q.contains(day(7, 0)), "the end is exclusive")
+	assert.False(t, q.contains(day(12, 0)))
+	assert.False(t, q.contains(day(21, 59)))
+}
+
+func TestQuietHours_SameDayAndEmpty(t *testing.T) {
+	q, err := parseQuietHours("12:30", "13:30")
+	require.NoError(t, err)
+	assert.True(t, q.contains(time.Date(2026, 1, 2, 13, 0, 0, 0, time.UTC)))
+	assert.False(t, q.contains(time.Date(2026, 1, 2, 14, 0, 0, 0, time.UTC)))
+
+	for _, bounds := range [][2]string{{"", "07:00"}, {"09:00", "09:00"}} {
+		q, err := parseQuietHours(bounds[0], bounds[1])
+		require.NoError(t, err)
+		assert.False(t, q.contains(time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)), "%v is never quiet", bounds)
+	}
+
+	_, err = parseQuietHours("25:00", "07:00")
+	assert.Error(t, err)
+}
+
+func TestNotifier_QuietHoursMuteExternalBackends(t *testing.T) {
+	inApp, bell := &recorder{}, &recorder{external: true}
+	n := New(enabledConfig(), inApp, bell)
+
+	at(n, 23, 0).Notify(Notification{Title: "t"})
+	require.Len(t, inApp.got, 1)
+	assert.False(t, inApp.got[0].Sound, "no sound during quiet hours")
+	assert.Empty(t, bell.got)
+
+	at(n, 12, 0).Notify(Notification{Title: "t"})
+	assert.Len(t, bell.got, 1)
+	assert.True(t, bell.got[0].Sound)
+}
+
+func TestNotifier_RespectsSwitches(t *testing.T) {
+	b := &recorder{}
+	cfg := enabledConfig()
+	cfg.SoundEnabled = false
+	at(New(cfg, b), 12, 0).Notify(Notification{})
+	require.Len(t, b.got, 1)
+	assert.False(t, b.got[0].Sound)
+
+	cfg.EnableNotifications = false
+	at(New(cfg, b), 12, 0).Notify(Notification{})
+	assert.Len(t, b.got, 1, "disabled notifications deliver nothing")
+}
+
+func TestNotifier_TaskFinished(t *testing.T) {
+	b := &recorder{}
+	n := at(New(enabledConfig(), b), 12, 0)
+
+	n.TaskFinished(task.DoneMsg[string]{Label: "quick", Elapsed: time.Second})
+	assert.Empty(t, b.got, "short tasks are not reported")
+
+	n.TaskFinished(task.DoneMsg[string]{Label: "sync", Elapsed: 2 * time.Minute})
+	require.Len(t, b.got, 1)
+	assert.Equal(t, KindSuccess, b.got[0].Kind)
+	assert.Contains(t, b.got[0].Body, "sync")
+
+	n.TaskFinished(task.ErrMsg{Label: "sync", Err: errors.New("boom")})
+	require.Len(t, b.got, 2)
+	assert.Equal(t, KindError, b.got[1].Kind)
+	assert.Contains(t, b.got[1].Body, "boom")
+
+	n.TaskFinished(task.ErrMsg{Label: "sync", Err: context.Canceled})
+	assert.Len(t, b.got, 2, "cancelled tasks are not reported")
+
+	cfg := enabledConfig()
+	cfg.NotifyOnError, cfg.NotifyOnComplete = false, false
+	n = at(New(cfg, b), 12, 0)
+	n.TaskFinished(task.ErrMsg{Label: "sync", Err: errors.New("boom")})
+	n.TaskFinished(task.DoneMsg[int]{Label: "sync", Elapsed: time.Hour})
+	assert.Len(t, b.got, 2)
+}
+
+func TestBell_RingsOnlyWithSound(t *testing.T) {
+	assert.Nil(t, Bell{}.Deliver(Notification{}))
+	assert.Equal(t, tea.RawMsg{Msg: "\a"}, Bell{}.Deliver(Notification{Sound: true})())
+}
+
+func TestOSC_Sequences(t *testing.T) {
+	n := Notification{Title: "Build; done", Body: "ok\x1bnow"}
+	assert.Equal(t, tea.RawMsg{Msg: "\x1b]9;Build; done: ok now\x07"}, OSC{Variant: 9}.Deliver(n)())
+	assert.Equal(t, tea.RawMsg{Msg: "\x1b]777;notify;Build, done;ok now\x07"}, OSC{Variant: 777}.Deliver(n)())
+}
+
+func TestDBus_CallsNotificationService(t *testing.T) {
+	bus := &fakeBus{}
+	d := NewDBus("scaffold", bus)
+
+	assert.Nil(t, d.Deliver(Notification{Title: "Task failed", Body: "boom", Kind: KindError})())
+
+	assert.Equal(t, "org.freedesktop.Notifications.Notify", bus.method)
+	require.Len(t, bus.args, 8)
+	assert.Equal(t, "scaffold", bus.args[0])
+	assert.Equal(t, "Task failed", bus.args[3])
+	assert.Equal(t, "boom", bus.args[4])
+	hints := bus.args[6].(map[string]dbus.Variant)
+	assert.Equal(t, urgencyCritical, hints["urgency"].Value())
+	assert.Equal(t, true, hints["suppress-sound"].Value())
+	assert.Equal(t, int32(-1), bus.args[7])
+}
+
+func TestDBus_ToleratesFailures(t *testing.T) {
+	d := NewDBus("scaffold", &fakeBus{err: errors.New("no daemon")})
+	assert.Nil(t, d.Deliver(Notification{})())
+
+	d = &DBus{connect: func() (Caller, error) { return nil, errors.New("no bus") }}
+	assert.Nil(t, d.Deliver(Notification{})())
+}
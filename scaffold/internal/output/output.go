@@ -0,0 +1,259 @@
+// Package output renders the results of non-interactive commands in the
+// format chosen by UIConfig.OutputFormat or the --output flag: aligned text
+// for people, JSON or NDJSON for scripts, or a themed table.
+//
+// Records are structs. Their exported fields are the columns, named by the
+// field's json tag (fields tagged json:"-" are skipped), so the same record
+// type prints identically in every format.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+
+	"scaffold/internal/ui/theme"
+)
+
+// Format selects how records are written.
+type Format string
+
+const (
+	FormatText   Format = "text"   // aligned "key  value" lines
+	FormatJSON   Format = "json"   // an array for a slice of records, an object for one
+	FormatNDJSON Format = "ndjson" // one JSON object per record and line
+	FormatTable  Format = "table"  // lipgloss table styled with the theme palette
+)
+
+// Formats lists every supported format, in the order of the cfg_options of
+// UIConfig.OutputFormat.
+var Formats = []Format{FormatText, FormatJSON, FormatNDJSON, FormatTable}
+
+// ParseFormat validates a format name. The empty name means FormatText.
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return FormatText, nil
+	}
+	for _, f := range Formats {
+		if string(f) == s {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("unknown output format %q (want text, json, ndjson or table)", s)
+}
+
+// Texter is implemented by records with a conventional one-line text form,
+// such as "scaffold v1.0.0". FormatText prints it instead of the aligned
+// field listing.
+type Texter interface {
+	Text() string
+}
+
+// Printer writes records to w in one format.
+type Printer struct {
+	w       io.Writer
+	format  Format
+	palette theme.Palette
+}
+
+// New creates a Printer. The palette styles FormatTable; colors are
+// downsampled, or dropped when w is not a terminal.
+func New(w io.Writer, format Format, p theme.Palette) *Printer {
+	return &Printer{w: w, format: format, palette: p}
+}
+
+// Format returns the format the Printer writes.
+func (p *Printer) Format() Format {
+	return p.format
+}
+
+// Print writes records, which must be a struct, a pointer to one, or a
+// slice of either holding a single struct type. Nil records are an error.
+func (p *Printer) Print(records any) error {
+	rows, list, err := recordValues(records)
+	if err != nil {
+		return err
+	}
+	switch p.format {
+	case FormatJSON:
+		return p.printJSON(rows, list)
+	case FormatNDJSON:
+		return p.printNDJSON(rows)
+	case FormatTable:
+		return p.printTable(rows)
+	default:
+		return p.printText(rows)
+	}
+}
+
+// printJSON writes a list of records as one JSON array, empty or not, and
+// a single record as one JSON object, so the shape follows the type of the
+// input rather than the number of records.
+func (p *Printer) printJSON(rows []reflect.Value, list bool) error {
+	var v any
+	if list {
+		values := make([]any, len(rows))
+		for i, r := range rows {
+			values[i] = r.Interface()
+		}
+		v = values
+	} else {
+		v = rows[0].Interface()
+	}
+	if err := json.NewEncoder(p.w).Encode(v); err != nil {
+		return fmt.Errorf("encoding output: %w", err)
+	}
+	return nil
+}
+
+// printNDJSON writes one compact object per record and line, and nothing
+// for no records.
+func (p *Printer) printNDJSON(rows []reflect.Value) error {
+	enc := json.NewEncoder(p.w)
+	for _, r := range rows {
+		if err := enc.Encode(r.Interface()); err != nil {
+			return fmt.Errorf("encoding output: %w", err)
+		}
+	}
+	return nil
+}
+
+// printText writes each record's Text() when it has one, and otherwise its
+// fields as "key  value" lines with the values aligned, separating records
+// with a blank line.
+func (p *Printer) printText(rows []reflect.Value) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	var blocks []string
+	for _, r := range rows {
+		if t, ok := r.Interface().(Texter); ok {
+			blocks = append(blocks, t.Text())
+			continue
+		}
+		cols := columns(r.Type())
+		keyW := 0
+		for _, c := range cols {
+			keyW = max(keyW, len(c.name))
+		}
+		lines := make([]string, len(cols))
+		for i, c := range cols {
+			lines[i] = fmt.Sprintf("%-*s  %s", keyW, c.name, cellText(r.Field(c.index)))
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	sep := "\n\n"
+	if _, ok := rows[0].Interface().(Texter); ok {
+		sep = "\n"
+	}
+	_, err := fmt.Fprintln(p.w, strings.Join(blocks, sep))
+	return err
+}
+
+// printTable writes the records as a table with one column per field.
+func (p *Printer) printTable(rows []reflect.Value) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	cols := columns(rows[0].Type())
+	headers := make([]string, len(cols))
+	for i, c := range cols {
+		headers[i] = strings.ToUpper(c.name)
+	}
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(lipgloss.NewStyle().Foreground(p.palette.Border)).
+		Headers(headers...).
+		StyleFunc(p.cellStyle)
+	for _, r := range rows {
+		cells := make([]string, len(cols))
+		for i, c := range cols {
+			cells[i] = cellText(r.Field(c.index))
+		}
+		t.Row(cells...)
+	}
+	_, err := lipgloss.Fprintln(p.w, t.Render())
+	return err
+}
+
+// cellStyle styles the header row in the primary color and stripes the
+// body rows.
+func (p *Printer) cellStyle(row, _ int) lipgloss.Style {
+	s := lipgloss.NewStyle().Padding(0, 1)
+	switch {
+	case row == table.HeaderRow:
+		return s.Bold(true).Foreground(p.palette.Primary)
+	case row%2 == 1:
+		return s.Foreground(p.palette.ForegroundMuted)
+	default:
+		return s.Foreground(p.palette.Foreground)
+	}
+}
+
+// column is an output column backed by a struct field.
+type column struct {
+	name  string
+	index int
+}
+
+// columns returns the output columns of struct type t.
+func columns(t reflect.Type) []column {
+	var cols []column
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		cols = append(cols, column{name: name, index: i})
+	}
+	return cols
+}
+
+// cellText formats a field value for text and table output.
+func cellText(v reflect.Value) string {
+	return fmt.Sprint(v.Interface())
+}
+
+// recordValues flattens records into struct values of a single type, since
+// text and table output read every record's fields by the columns of the
+// first. list reports whether records was a slice, or nil, rather than a
+// single record.
+func recordValues(records any) (rows []reflect.Value, list bool, err error) {
+	v := reflect.ValueOf(records)
+	if !v.IsValid() {
+		return nil, true, nil
+	}
+	list = v.Kind() == reflect.Slice
+	if !list {
+		v = reflect.Append(reflect.MakeSlice(reflect.SliceOf(v.Type()), 0, 1), v)
+	}
+	rows = make([]reflect.Value, v.Len())
+	for i := range rows {
+		r := v.Index(i)
+		for r.Kind() == reflect.Pointer || r.Kind() == reflect.Interface {
+			if r.IsNil() {
+				return nil, false, fmt.Errorf("output: record %d is nil", i)
+			}
+			r = r.Elem()
+		}
+		if r.Kind() != reflect.Struct {
+			return nil, false, fmt.Errorf("output: record of type %s is not a struct", r.Type())
+		}
+		if i > 0 && r.Type() != rows[0].Type() {
+			return nil, false, fmt.Errorf("output: record %d is a %s, not a %s like the others", i, r.Type(), rows[0].Type())
+		}
+		rows[i] = r
+	}
+	return rows, list, nil
+}
@@ -0,0 +1,104 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/internal/ui/theme"
+)
+
+type item struct {
+	Name    string `json:"name"`
+	Count   int    `json:"count,omitempty"`
+	Secret  string `json:"-"`
+	private string
+}
+
+type line struct {
+	Name string `json:"name"`
+}
+
+func (l line) Text() string { return "> " + l.Name }
+
+func render(t *testing.T, f Format, records any) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, New(&buf, f, theme.NewPalette("default", true)).Print(records))
+	return buf.String()
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, f := range Formats {
+		got, err := ParseFormat(string(f))
+		require.NoError(t, err)
+		assert.Equal(t, f, got)
+	}
+	got, err := ParseFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, FormatText, got)
+
+	_, err = ParseFormat("yaml")
+	assert.Error(t, err)
+}
+
+func TestPrint_TextAlignsFields(t *testing.T) {
+	out := render(t, FormatText, item{Name: "alpha", Count: 3, Secret: "x"})
+	assert.Equal(t, "name   alpha\ncount  3\n", out)
+	assert.NotContains(t, out, "x\n", "json:\"-\" fields are skipped")
+}
+
+func TestPrint_TextUsesTexter(t *testing.T) {
+	out := render(t, FormatText, []line{{"a"}, {"b"}})
+	assert.Equal(t, "> a\n> b\n", out)
+}
+
+func TestPrint_JSONListIsArray(t *testing.T) {
+	out := render(t, FormatJSON, []*item{{Name: "a", Count: 1}, {Name: "b"}})
+	assert.Equal(t, "[{\"name\":\"a\",\"count\":1},{\"name\":\"b\"}]\n", out)
+	assert.Equal(t, "[{\"name\":\"a\"}]\n", render(t, FormatJSON, []item{{Name: "a"}}), "one element is still a list")
+	assert.Equal(t, "[]\n", render(t, FormatJSON, []item{}))
+}
+
+func TestPrint_JSONSingleRecordIsObject(t *testing.T) {
+	assert.Equal(t, "{\"name\":\"a\"}\n", render(t, FormatJSON, &item{Name: "a"}))
+}
+
+func TestPrint_NDJSON(t *testing.T) {
+	out := render(t, FormatNDJSON, []*item{{Name: "a", Count: 1}, {Name: "b"}})
+	assert.Equal(t, "{\"name\":\"a\",\"count\":1}\n{\"name\":\"b\"}\n", out)
+	assert.Empty(t, render(t, FormatNDJSON, []item{}))
+	assert.Empty(t, render(t, FormatText, []item{}))
+}
+
+func TestPrint_Table(t *testing.T) {
+	out := render(t, FormatTable, []item{{Name: "alpha", Count: 3}, {Name: "beta"}})
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	require.Len(t, lines, 6, "top border, header, separator, two rows, bottom border")
+	assert.Contains(t, lines[1], "NAME")
+	assert.Contains(t, lines[1], "COUNT")
+	assert.Contains(t, lines[3], "alpha")
+	assert.NotContains(t, out, "\x1b[", "colors are dropped when writing to a non-terminal")
+}
+
+func TestPrint_RejectsNonStructs(t *testing.T) {
+	err := New(&bytes.Buffer{}, FormatText, theme.Palette{}).Print([]int{1})
+	assert.Error(t, err)
+}
+
+func TestPrint_RejectsNilRecords(t *testing.T) {
+	p := New(&bytes.Buffer{}, FormatText, theme.Palette{})
+	assert.Error(t, p.Print((*item)(nil)))
+	assert.Error(t, p.Print([]*item{{Name: "a"}, nil}))
+	assert.Error(t, p.Print([]any{nil}))
+}
+
+func TestPrint_RejectsMixedRecordTypes(t *testing.T) {
+	for _, f := range Formats {
+		err := New(&bytes.Buffer{}, f, theme.Palette{}).Print([]any{line{Name: "a"}, item{Name: "b"}})
+		assert.Error(t, err, f)
+	}
+}
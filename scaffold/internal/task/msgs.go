@@ -2,20 +2,40 @@
 // BubbleTea program and routing results back through the message loop.
 package task
 
+import "time"
+
+// Finished is implemented by DoneMsg and ErrMsg, so observers such as the
+// notifier can react to any finished task regardless of its value type.
+type Finished interface {
+	TaskLabel() string
+	TaskElapsed() time.Duration
+	TaskErr() error
+}
+
 // DoneMsg carries a successfully completed task result.
 // T is the value type returned by the task function.
 type DoneMsg[T any] struct {
-	Label string
-	Value T
+	Label   string
+	Value   T
+	Elapsed time.Duration // how long the task ran
 }
 
+func (m DoneMsg[T]) TaskLabel() string          { return m.Label }
+func (m DoneMsg[T]) TaskElapsed() time.Duration { return m.Elapsed }
+func (m DoneMsg[T]) TaskErr() error             { return nil }
+
 // ErrMsg carries a failed or cancelled task error.
 // Err may be context.Canceled if the root context was cancelled.
 type ErrMsg struct {
-	Label string
-	Err   error
+	Label   string
+	Err     error
+	Elapsed time.Duration // how long the task ran
 }
 
+func (m ErrMsg) TaskLabel() string          { return m.Label }
+func (m ErrMsg) TaskElapsed() time.Duration { return m.Elapsed }
+func (m ErrMsg) TaskErr() error             { return m.Err }
+
 // ProgressMsg carries incremental progress updates (Progress in 0.0–1.0).
 type ProgressMsg struct {
 	Label    string
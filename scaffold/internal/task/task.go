@@ -19,6 +19,7 @@ type Result[T any] struct {
 // If ctx is cancelled before fn returns, ErrMsg{Err: ctx.Err()} is sent.
 func Run[T any](ctx context.Context, label string, fn func(context.Context) (T, error)) tea.Cmd {
 	return func() tea.Msg {
+		start := time.Now()
 		done := make(chan Result[T], 1)
 		go func() {
 			v, err := fn(ctx)
@@ -27,11 +28,11 @@ func Run[T any](ctx context.Context, label string, fn func(context.Context) (T,
 		select {
 		case r := <-done:
 			if r.Err != nil {
-				return ErrMsg{Label: label, Err: r.Err}
+				return ErrMsg{Label: label, Err: r.Err, Elapsed: time.Since(start)}
 			}
-			return DoneMsg[T]{Label: label, Value: r.Value}
+			return DoneMsg[T]{Label: label, Value: r.Value, Elapsed: time.Since(start)}
 		case <-ctx.Done():
-			return ErrMsg{Label: label, Err: ctx.Err()}
+			return ErrMsg{Label: label, Err: ctx.Err(), Elapsed: time.Since(start)}
 		}
 	}
 }
@@ -40,6 +41,7 @@ func Run[T any](ctx context.Context, label string, fn func(context.Context) (T,
 // The timeout context is cancelled when fn returns or after d, whichever comes first.
 func RunWithTimeout[T any](ctx context.Context, label string, d time.Duration, fn func(context.Context) (T, error)) tea.Cmd {
 	return func() tea.Msg {
+		start := time.Now()
 		tctx, cancel := context.WithTimeout(ctx, d)
 		defer cancel()
 		done := make(chan Result[T], 1)
@@ -50,11 +52,11 @@ func RunWithTimeout[T any](ctx context.Context, label string, d time.Duration, f
 		select {
 		case r := <-done:
 			if r.Err != nil {
-				return ErrMsg{Label: label, Err: r.Err}
+				return ErrMsg{Label: label, Err: r.Err, Elapsed: time.Since(start)}
 			}
-			return DoneMsg[T]{Label: label, Value: r.Value}
+			return DoneMsg[T]{Label: label, Value: r.Value, Elapsed: time.Since(start)}
 		case <-tctx.Done():
-			return ErrMsg{Label: label, Err: tctx.Err()}
+			return ErrMsg{Label: label, Err: tctx.Err(), Elapsed: time.Since(start)}
 		}
 	}
 }
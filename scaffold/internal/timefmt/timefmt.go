@@ -0,0 +1,149 @@
+// Package timefmt formats timestamps and durations for display. Every
+// timestamp the UI shows goes through a Formatter built from UIConfig: the
+// DateFormat Go layout, the TimeZone it is shown in, and the RelativeTimes
+// mode that renders recent times as "3m ago".
+//
+// Like the i18n catalog, the formatter in use is process-wide: the root
+// model installs one with Set whenever the config changes, and components
+// call Format or Elapsed.
+package timefmt
+
+import (
+	"sync"
+	"time"
+
+	"scaffold/config"
+	"scaffold/internal/i18n"
+)
+
+// DefaultLayout is used when no layout is configured.
+const DefaultLayout = "2006-01-02"
+
+// relativeLimit is how far from now a time may be for relative mode to
+// describe it; older and later times use the layout.
+const relativeLimit = 7 * 24 * time.Hour
+
+// Formatter renders times with a layout, in a zone, optionally relative to
+// now. The zero value uses DefaultLayout in the local zone.
+type Formatter struct {
+	layout   string
+	loc      *time.Location
+	relative bool
+	now      func() time.Time // for tests; nil means time.Now
+}
+
+// New creates a Formatter from UIConfig's DateFormat, TimeZone and
+// RelativeTimes values. An empty layout means DefaultLayout; the layout and
+// zone are checked with config.ValidateDateFormat and config.LoadTimeZone.
+func New(layout, zone string, relative bool) (Formatter, error) {
+	if err := config.ValidateDateFormat(layout); err != nil {
+		return Formatter{}, err
+	}
+	loc, err := config.LoadTimeZone(zone)
+	if err != nil {
+		return Formatter{}, err
+	}
+	return Formatter{layout: layout, loc: loc, relative: relative}, nil
+}
+
+// FromConfig creates a Formatter from the UI settings.
+func FromConfig(ui config.UIConfig) (Formatter, error) {
+	return New(ui.DateFormat, ui.TimeZone, ui.RelativeTimes)
+}
+
+// Format renders t: relative to now when relative mode is on and t is
+// within a week, otherwise with the layout in the configured zone.
+func (f Formatter) Format(t time.Time) string {
+	if f.relative {
+		if d := f.clock().Sub(t); d < relativeLimit && d > -relativeLimit {
+			return relative(d)
+		}
+	}
+	return f.Absolute(t)
+}
+
+// Absolute renders t with the layout in the configured zone, ignoring
+// relative mode.
+func (f Formatter) Absolute(t time.Time) string {
+	layout := f.layout
+	if layout == "" {
+		layout = DefaultLayout
+	}
+	loc := f.loc
+	if loc == nil {
+		loc = time.Local
+	}
+	return t.In(loc).Format(layout)
+}
+
+func (f Formatter) clock() time.Time {
+	if f.now != nil {
+		return f.now()
+	}
+	return time.Now()
+}
+
+// relative describes d, the time since a moment, as "just now", "3m ago"
+// or, for future moments, "in 3m".
+func relative(d time.Duration) string {
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < 10*time.Second {
+		return i18n.T("time.justNow")
+	}
+	var amount string
+	switch {
+	case d < time.Minute:
+		amount = i18n.T("time.unit.s", int(d/time.Second))
+	case d < time.Hour:
+		amount = i18n.T("time.unit.m", int(d/time.Minute))
+	case d < 24*time.Hour:
+		amount = i18n.T("time.unit.h", int(d/time.Hour))
+	default:
+		amount = i18n.T("time.unit.d", int(d/(24*time.Hour)))
+	}
+	if future {
+		return i18n.T("time.in", amount)
+	}
+	return i18n.T("time.ago", amount)
+}
+
+// Elapsed renders a running duration such as a task's age: "42s", "3m 5s",
+// "1h 2m". Precision drops with magnitude so the text stays short.
+func Elapsed(d time.Duration) string {
+	d = max(d, 0)
+	switch {
+	case d < time.Minute:
+		return i18n.T("time.unit.s", int(d/time.Second))
+	case d < time.Hour:
+		return i18n.T("time.unit.m", int(d/time.Minute)) + " " + i18n.T("time.unit.s", int(d%time.Minute/time.Second))
+	default:
+		return i18n.T("time.unit.h", int(d/time.Hour)) + " " + i18n.T("time.unit.m", int(d%time.Hour/time.Minute))
+	}
+}
+
+var (
+	mu      sync.RWMutex
+	current Formatter
+)
+
+// Set installs f as the formatter used by Format.
+func Set(f Formatter) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = f
+}
+
+// Current returns the installed formatter.
+func Current() Formatter {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Format renders t with the installed formatter.
+func Format(t time.Time) string {
+	return Current().Format(t)
+}
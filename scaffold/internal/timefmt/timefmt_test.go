@@ -0,0 +1,75 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/config"
+)
+
+var moment = time.Date(2024, time.July, 9, 22, 30, 0, 0, time.UTC)
+
+// fixedAt returns f with its clock stopped at now.
+func fixedAt(f Formatter, now time.Time) Formatter {
+	f.now = func() time.Time { return now }
+	return f
+}
+
+func TestNew_RejectsInvalidSettings(t *testing.T) {
+	_, err := New("no elements", "", false)
+	assert.ErrorIs(t, err, config.ErrInvalidConfig)
+
+	_, err = New("", "Mars/Olympus_Mons", false)
+	assert.ErrorIs(t, err, config.ErrInvalidConfig)
+}
+
+func TestFormatter_LayoutAndZone(t *testing.T) {
+	f, err := New("2006-01-02 15:04 MST", "Asia/Tokyo", false)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-07-10 07:30 JST", f.Format(moment), "times are shown in the configured zone")
+
+	var zero Formatter
+	assert.Equal(t, moment.Local().Format(DefaultLayout), zero.Format(moment))
+}
+
+func TestFormatter_Relative(t *testing.T) {
+	f, err := New("2006-01-02", "UTC", true)
+	require.NoError(t, err)
+
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{3 * time.Second, "just now"},
+		{42 * time.Second, "42s ago"},
+		{3*time.Minute + 20*time.Second, "3m ago"},
+		{5 * time.Hour, "5h ago"},
+		{2 * 24 * time.Hour, "2d ago"},
+		{-10 * time.Minute, "in 10m"},
+		{30 * 24 * time.Hour, "2024-07-09"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, fixedAt(f, moment.Add(tt.ago)).Format(moment), "%v ago", tt.ago)
+	}
+	assert.Equal(t, "2024-07-09", fixedAt(f, moment.Add(time.Hour)).Absolute(moment))
+}
+
+func TestElapsed(t *testing.T) {
+	assert.Equal(t, "0s", Elapsed(-time.Second))
+	assert.Equal(t, "42s", Elapsed(42*time.Second))
+	assert.Equal(t, "3m 5s", Elapsed(3*time.Minute+5*time.Second))
+	assert.Equal(t, "1h 2m", Elapsed(time.Hour+2*time.Minute+30*time.Second))
+}
+
+func TestSet_InstallsFormatter(t *testing.T) {
+	prev := Current()
+	t.Cleanup(func() { Set(prev) })
+
+	f, err := FromConfig(config.UIConfig{DateFormat: "02.01.2006", TimeZone: "UTC"})
+	require.NoError(t, err)
+	Set(f)
+	assert.Equal(t, "09.07.2024", Format(moment))
+}
@@ -0,0 +1,130 @@
+// Package anim is a small frame-driven animation toolkit built on tea.Tick:
+// easing functions, tweens for numeric values, a global speed scale read from
+// UIConfig.AnimationSpeed, and string transforms for screen transitions and
+// dialog reveals.
+//
+// Animations are driven by the owner: it starts a Tick loop when a tween
+// begins, advances its tweens by the elapsed time on every FrameMsg, and
+// stops scheduling frames once all of them are done.
+package anim
+
+import (
+	"math"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// FrameInterval is the delay between animation frames (about 60 fps).
+const FrameInterval = time.Second / 60
+
+// FrameMsg is delivered once per frame while an animation is running.
+type FrameMsg struct {
+	At time.Time
+}
+
+// Tick schedules the next FrameMsg.
+func Tick() tea.Cmd {
+	return tea.Tick(FrameInterval, func(t time.Time) tea.Msg {
+		return FrameMsg{At: t}
+	})
+}
+
+// Speed scales every animation duration. It mirrors the values of
+// UIConfig.AnimationSpeed; SpeedNone disables motion entirely.
+type Speed int
+
+const (
+	SpeedNormal Speed = iota
+	SpeedSlow
+	SpeedFast
+	SpeedNone
+)
+
+// ParseSpeed converts a UIConfig.AnimationSpeed value. Unknown and empty
+// values are treated as "normal".
+func ParseSpeed(s string) Speed {
+	switch s {
+	case "slow":
+		return SpeedSlow
+	case "fast":
+		return SpeedFast
+	case "none":
+		return SpeedNone
+	}
+	return SpeedNormal
+}
+
+// Scale returns d adjusted for the speed. SpeedNone returns 0, which makes
+// a tween finish immediately.
+func (s Speed) Scale(d time.Duration) time.Duration {
+	switch s {
+	case SpeedSlow:
+		return d * 2
+	case SpeedFast:
+		return d / 2
+	case SpeedNone:
+		return 0
+	}
+	return d
+}
+
+// Easing maps linear progress t in [0, 1] to eased progress.
+type Easing func(t float64) float64
+
+// Linear is constant-speed progress.
+func Linear(t float64) float64 { return t }
+
+// EaseInCubic starts slowly and accelerates.
+func EaseInCubic(t float64) float64 { return t * t * t }
+
+// EaseOutCubic starts fast and decelerates; the usual choice for motion
+// that responds to user input.
+func EaseOutCubic(t float64) float64 { return 1 - math.Pow(1-t, 3) }
+
+// EaseInOutCubic accelerates through the first half and decelerates through
+// the second.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// Tween interpolates a value from one number to another over a duration.
+// The zero value is a finished tween at 0.
+type Tween struct {
+	from, to float64
+	duration time.Duration
+	elapsed  time.Duration
+	ease     Easing
+}
+
+// NewTween creates a tween from → to over d. A nil ease means Linear; a
+// non-positive d yields a tween that is already done.
+func NewTween(from, to float64, d time.Duration, ease Easing) Tween {
+	if ease == nil {
+		ease = Linear
+	}
+	return Tween{from: from, to: to, duration: d, ease: ease}
+}
+
+// Advance returns the tween moved forward by dt.
+func (t Tween) Advance(dt time.Duration) Tween {
+	t.elapsed = min(t.elapsed+dt, t.duration)
+	return t
+}
+
+// Done reports whether the tween has reached its target.
+func (t Tween) Done() bool {
+	return t.elapsed >= t.duration
+}
+
+// Value returns the current eased value.
+func (t Tween) Value() float64 {
+	if t.Done() {
+		return t.to
+	}
+	p := float64(t.elapsed) / float64(t.duration)
+	return t.from + (t.to-t.from)*t.ease(p)
+}
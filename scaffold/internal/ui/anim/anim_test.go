@@ -0,0 +1,75 @@
+package anim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSpeed(t *testing.T) {
+	assert.Equal(t, SpeedSlow, ParseSpeed("slow"))
+	assert.Equal(t, SpeedFast, ParseSpeed("fast"))
+	assert.Equal(t, SpeedNone, ParseSpeed("none"))
+	assert.Equal(t, SpeedNormal, ParseSpeed("normal"))
+	assert.Equal(t, SpeedNormal, ParseSpeed(""), "unset speed is normal")
+}
+
+func TestSpeed_Scale(t *testing.T) {
+	d := 200 * time.Millisecond
+	assert.Equal(t, 400*time.Millisecond, SpeedSlow.Scale(d))
+	assert.Equal(t, d, SpeedNormal.Scale(d))
+	assert.Equal(t, 100*time.Millisecond, SpeedFast.Scale(d))
+	assert.Zero(t, SpeedNone.Scale(d))
+}
+
+func TestEasings_Endpoints(t *testing.T) {
+	for name, ease := range map[string]Easing{
+		"linear":     Linear,
+		"inCubic":    EaseInCubic,
+		"outCubic":   EaseOutCubic,
+		"inOutCubic": EaseInOutCubic,
+	} {
+		assert.InDelta(t, 0, ease(0), 1e-9, name)
+		assert.InDelta(t, 1, ease(1), 1e-9, name)
+	}
+	assert.Greater(t, EaseOutCubic(0.5), 0.5, "ease-out is ahead of linear")
+	assert.Less(t, EaseInCubic(0.5), 0.5, "ease-in is behind linear")
+}
+
+func TestTween_Advance(t *testing.T) {
+	tw := NewTween(10, 20, 100*time.Millisecond, nil)
+	assert.False(t, tw.Done())
+	assert.Equal(t, 10.0, tw.Value())
+
+	tw = tw.Advance(50 * time.Millisecond)
+	assert.InDelta(t, 15, tw.Value(), 1e-9)
+
+	tw = tw.Advance(time.Second)
+	assert.True(t, tw.Done())
+	assert.Equal(t, 20.0, tw.Value(), "overshooting the duration lands on the target")
+}
+
+func TestTween_ZeroDurationIsDone(t *testing.T) {
+	tw := NewTween(0, 1, SpeedNone.Scale(time.Second), EaseOutCubic)
+	assert.True(t, tw.Done())
+	assert.Equal(t, 1.0, tw.Value())
+
+	var zero Tween
+	assert.True(t, zero.Done())
+	assert.Zero(t, zero.Value())
+}
+
+func TestSlide(t *testing.T) {
+	assert.Equal(t, "  ab\n  cd", Slide("abc\ncde", 2, 4))
+	assert.Equal(t, "c\ne", Slide("abc\ncde", -2, 4))
+	assert.Equal(t, "abc", Slide("abc", 0, 4))
+}
+
+func TestReveal(t *testing.T) {
+	s := "1\n2\n3\n4\n5"
+	assert.Equal(t, s, Reveal(s, 1))
+	assert.Equal(t, "", Reveal(s, 0))
+	assert.Equal(t, "3", Reveal(s, 0.1), "at least the middle row is shown")
+	assert.Equal(t, "2\n3\n4", Reveal(s, 0.6))
+}
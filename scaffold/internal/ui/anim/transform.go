@@ -0,0 +1,43 @@
+package anim
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Slide shifts every line of s horizontally by dx cells within a column of
+// the given width. Positive dx moves content right (it enters from the
+// right edge); negative dx moves it left, cutting cells off the left edge.
+// Lines are clipped to width so the result never grows wider than the
+// column.
+func Slide(s string, dx, width int) string {
+	if dx == 0 || width <= 0 {
+		return s
+	}
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if dx > 0 {
+			lines[i] = ansi.Truncate(strings.Repeat(" ", dx)+line, width, "")
+		} else {
+			lines[i] = ansi.Cut(line, -dx, width-dx)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Reveal returns the middle fraction p of the lines of s, so a dialog
+// centered on screen appears to grow out of, or shrink into, its middle row.
+// p <= 0 returns ""; p >= 1 returns s unchanged.
+func Reveal(s string, p float64) string {
+	if p >= 1 {
+		return s
+	}
+	if p <= 0 {
+		return ""
+	}
+	lines := strings.Split(s, "\n")
+	n := max(int(float64(len(lines))*p+0.5), 1)
+	start := (len(lines) - n) / 2
+	return strings.Join(lines[start:start+n], "\n")
+}
@@ -0,0 +1,145 @@
+// Package ui — Animation state and frame handling for rootModel.
+package ui
+
+import (
+	"math"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"scaffold/internal/ui/anim"
+)
+
+// Animation durations at "normal" speed. UIConfig.AnimationSpeed scales
+// them, and "none" reduces them to zero so every change lands immediately.
+const (
+	transitionDuration = 200 * time.Millisecond
+	modalDuration      = 150 * time.Millisecond
+	bannerDuration     = 900 * time.Millisecond
+)
+
+// animations holds every running tween of the root model. A single frame
+// loop advances them all and stops once none is running.
+type animations struct {
+	ticking bool      // a FrameMsg is scheduled
+	last    time.Time // time of the previous frame; zero before the first
+	slide   anim.Tween
+	modal   anim.Tween
+	banner  anim.Tween
+	closing string     // last frame of a dismissed modal, drawn while it shrinks
+	swept   bannerLook // the look the banner last swept for
+}
+
+// bannerLook is what a banner sweep shows off: the theme, its mode, and
+// whether the banner is shown at all. Other theme updates, such as a
+// resize, leave it alone.
+type bannerLook struct {
+	theme  string
+	isDark bool
+	shown  bool
+}
+
+// running reports whether any tween still needs frames.
+func (a animations) running() bool {
+	return !a.slide.Done() || !a.modal.Done() || !a.banner.Done()
+}
+
+// tween creates a tween whose duration is scaled by the effective
+// animation speed.
+func (m rootModel) tween(from, to float64, d time.Duration, ease anim.Easing) anim.Tween {
+	speed := anim.ParseSpeed(m.effectiveCfg().UI.AnimationSpeed)
+	return anim.NewTween(from, to, speed.Scale(d), ease)
+}
+
+// startFrames schedules the frame loop if a tween is running and no frame
+// is pending yet.
+func (m rootModel) startFrames() (rootModel, tea.Cmd) {
+	if m.anims.ticking || !m.anims.running() {
+		return m, nil
+	}
+	m.anims.ticking = true
+	m.anims.last = time.Time{}
+	return m, anim.Tick()
+}
+
+// startTransition slides the body in from the right (dir 1, navigating
+// forward) or from the left (dir -1, going back).
+func (m rootModel) startTransition(dir float64) (rootModel, tea.Cmd) {
+	m.anims.slide = m.tween(dir, 0, transitionDuration, anim.EaseOutCubic)
+	return m.startFrames()
+}
+
+// startModalOpen grows the modal out of its middle row.
+func (m rootModel) startModalOpen() (rootModel, tea.Cmd) {
+	m.anims.closing = ""
+	m.anims.modal = m.tween(0, 1, modalDuration, anim.EaseOutCubic)
+	return m.startFrames()
+}
+
+// startModalClose shrinks the dialog that was just dismissed, continuing
+// from the current size if it was still opening.
+func (m rootModel) startModalClose(last string) (rootModel, tea.Cmd) {
+	m.anims.modal = m.tween(m.anims.modal.Value(), 0, modalDuration, anim.EaseInCubic)
+	if !m.anims.modal.Done() {
+		m.anims.closing = last
+	}
+	return m.startFrames()
+}
+
+// startBannerSweep sweeps the banner gradient through one full cycle.
+func (m rootModel) startBannerSweep() (rootModel, tea.Cmd) {
+	if !m.effectiveCfg().UI.ShowBanner {
+		return m, nil
+	}
+	m.anims.banner = m.tween(0, 1, bannerDuration, anim.EaseInOutCubic)
+	return m.startFrames()
+}
+
+// handleFrame advances every running tween by the time since the previous
+// frame and schedules the next one while any is still running.
+func (m rootModel) handleFrame(msg anim.FrameMsg) (tea.Model, tea.Cmd) {
+	dt := anim.FrameInterval
+	if !m.anims.last.IsZero() {
+		dt = msg.At.Sub(m.anims.last)
+	}
+	m.anims.last = msg.At
+
+	m.anims.slide = m.anims.slide.Advance(dt)
+	m.anims.modal = m.anims.modal.Advance(dt)
+	if m.anims.modal.Done() {
+		m.anims.closing = ""
+	}
+	if !m.anims.banner.Done() {
+		m.anims.banner = m.anims.banner.Advance(dt)
+		m.header = m.header.WithPhase(m.anims.banner.Value())
+	}
+
+	if !m.anims.running() {
+		m.anims.ticking = false
+		return m, nil
+	}
+	return m, anim.Tick()
+}
+
+// slideBody offsets body by the running screen transition.
+func (m rootModel) slideBody(body string) string {
+	if m.anims.slide.Done() {
+		return body
+	}
+	width := m.styles.MaxWidth - m.styles.Body.GetHorizontalFrameSize()
+	dx := int(math.Round(m.anims.slide.Value() * float64(width)))
+	return anim.Slide(body, dx, width)
+}
+
+// modalPopup returns the dialog to draw over the screen, sized by the
+// open/close animation, or "" when there is none.
+func (m rootModel) modalPopup() string {
+	popup := m.anims.closing
+	if m.modal.Visible() {
+		popup = m.modal.View().Content
+	}
+	if popup == "" || m.anims.modal.Done() {
+		return popup
+	}
+	return anim.Reveal(popup, m.anims.modal.Value())
+}
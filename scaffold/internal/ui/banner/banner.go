@@ -5,9 +5,12 @@ package banner
 import (
 	"fmt"
 	"image/color"
+	"math"
 	"math/rand/v2"
 	"strings"
 
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
 	colorful "github.com/lucasb-eyer/go-colorful"
 	"github.com/lsferreira42/figlet-go/figlet"
 )
@@ -229,6 +232,34 @@ func GradientThemed(primary, secondary color.Color) *Gradient {
 	return GradientThemedWithConfig(primary, secondary, GradientConfig{Stops: 7})
 }
 
+// Shifted returns a copy of g with its colors rotated by phase, a fraction of
+// the full cycle: 0 and 1 both return the original order. Fractional phases
+// blend neighbouring stops in HCL, so stepping phase from 0 to 1 sweeps the
+// gradient smoothly across the banner and back to its resting position.
+func (g *Gradient) Shifted(phase float64) *Gradient {
+	n := len(g.Colors)
+	if n < 2 {
+		return g
+	}
+	stops := make([]colorful.Color, n)
+	for i, hex := range g.Colors {
+		c, err := colorful.Hex("#" + strings.TrimPrefix(hex, "#"))
+		if err != nil {
+			return g
+		}
+		stops[i] = c
+	}
+	phase -= math.Floor(phase)
+	hexes := make([]string, n)
+	for i := range n {
+		pos := float64(i) + phase*float64(n)
+		lo := int(pos) % n
+		hi := (lo + 1) % n
+		hexes[i] = stops[lo].BlendHcl(stops[hi], pos-math.Floor(pos)).Clamped().Hex()[1:]
+	}
+	return &Gradient{Name: g.Name, Colors: hexes}
+}
+
 // GenerateGradient creates a perceptually smooth gradient between two hex colors.
 // Uses HCL blending for smooth transitions.
 func GenerateGradient(name, startHex, endHex string, stops int) (Gradient, error) {
@@ -309,6 +340,12 @@ type Config struct {
 	// Parser selects the output format. Valid values: "terminal-color" (default),
 	// "terminal" (plain text, no ANSI), "html".
 	Parser string
+
+	// Profile is the terminal color profile the colors are reduced to.
+	// ANSI256 snaps each color to the nearest of the xterm 256-color palette,
+	// ANSI to the nearest of the eight ANSI colors, and ASCII or NoTTY render
+	// without colors. The zero value, like TrueColor, keeps 24-bit colors.
+	Profile colorprofile.Profile
 }
 
 // Render renders ASCII art for the given config.
@@ -366,6 +403,9 @@ func Render(cfg Config) (string, error) {
 		}
 	}
 
+	// Reduce colors to the terminal's profile
+	colors = reduceColors(colors, cfg.Profile)
+
 	// Resolve width
 	width := cfg.Width
 	if width < 20 {
@@ -399,5 +439,66 @@ func Render(cfg Config) (string, error) {
 		return cfg.Text, fmt.Errorf("figlet render failed (font=%q): %w", font, err)
 	}
 
+	// figlet only writes 24-bit colors; rewrite the snapped ones as 256-color
+	// indexes so the output does not depend on the terminal downsampling.
+	if cfg.Profile == colorprofile.ANSI256 && parser == "terminal-color" {
+		var b strings.Builder
+		w := &colorprofile.Writer{Forward: &b, Profile: colorprofile.ANSI256}
+		if _, err := w.WriteString(result); err == nil {
+			result = b.String()
+		}
+	}
+
 	return result, nil
 }
+
+// reduceColors maps 24-bit colors to the perceptually nearest (CIEDE2000)
+// color the profile can show; see [Config.Profile]. ANSI colors are kept
+// unless the profile has no colors at all.
+func reduceColors(colors []figlet.Color, profile colorprofile.Profile) []figlet.Color {
+	var candidates []color.Color
+	switch profile {
+	case colorprofile.ASCII, colorprofile.NoTTY:
+		return nil
+	case colorprofile.ANSI:
+		for i := range ansiColorNames {
+			candidates = append(candidates, ansi.BasicColor(i))
+		}
+	case colorprofile.ANSI256:
+		for i := 16; i < 256; i++ { // 0-15 are the terminal's own palette
+			candidates = append(candidates, ansi.IndexedColor(i))
+		}
+	default:
+		return colors
+	}
+
+	reduced := make([]figlet.Color, len(colors))
+	for i, c := range colors {
+		tc, ok := c.(*figlet.TrueColor)
+		if !ok {
+			reduced[i] = c
+			continue
+		}
+		n := nearest(colorful.Color{R: float64(tc.R) / 255, G: float64(tc.G) / 255, B: float64(tc.B) / 255}, candidates)
+		if profile == colorprofile.ANSI {
+			reduced[i] = ansiColors[ansiColorNames[n]]
+			continue
+		}
+		cf, _ := colorful.MakeColor(candidates[n])
+		r, g, b := cf.RGB255()
+		reduced[i] = &figlet.TrueColor{R: int(r), G: int(g), B: int(b)}
+	}
+	return reduced
+}
+
+// nearest returns the index of the candidate perceptually closest to c.
+func nearest(c colorful.Color, candidates []color.Color) int {
+	best, bestDist := 0, math.Inf(1)
+	for i, candidate := range candidates {
+		cf, _ := colorful.MakeColor(candidate)
+		if d := c.DistanceCIEDE2000(cf); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
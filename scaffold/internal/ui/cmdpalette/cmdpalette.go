@@ -0,0 +1,281 @@
+// Package cmdpalette provides the command palette: a fuzzy-searchable list
+// of everything the user can do from the current screen, shown in a dialog
+// that rootModel draws over the screen. Running a command closes the
+// palette and dispatches the command's tea.Cmd.
+package cmdpalette
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/sahilm/fuzzy"
+
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/theme"
+)
+
+// Command is one entry of the palette.
+type Command struct {
+	Title string
+	Desc  string // shown muted after the title; also searched
+	Key   string // help label of the key that runs it directly, if any
+	Cmd   tea.Cmd
+}
+
+// Source returns extra commands for the palette. It is called each time
+// the palette opens, so titles follow the current language.
+type Source func() []Command
+
+var (
+	sourcesMu sync.Mutex
+	sources   []Source
+)
+
+// Register adds a source of commands that rootModel lists in the full
+// palette after its own. Components call it from an init function.
+func Register(src Source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources = append(sources, src)
+}
+
+// Registered returns the commands of every registered source.
+func Registered() []Command {
+	sourcesMu.Lock()
+	srcs := append([]Source(nil), sources...)
+	sourcesMu.Unlock()
+	var cmds []Command
+	for _, src := range srcs {
+		cmds = append(cmds, src()...)
+	}
+	return cmds
+}
+
+func init() {
+	keys.Register(
+		keys.Def{ID: "palette.prev", Keys: []string{"up", "ctrl+p"}, Help: "↑", Desc: "palette.keys.prev"},
+		keys.Def{ID: "palette.next", Keys: []string{"down", "ctrl+n"}, Help: "↓", Desc: "palette.keys.next"},
+		keys.Def{ID: "palette.run", Keys: []string{"enter"}, Desc: "palette.keys.run"},
+		keys.Def{ID: "palette.close", Keys: []string{"esc"}, Desc: "palette.keys.close"},
+	)
+}
+
+// KeyMap defines the keybindings active while the palette is open.
+type KeyMap struct {
+	Prev  key.Binding
+	Next  key.Binding
+	Run   key.Binding
+	Close key.Binding
+}
+
+func defaultKeyMap() KeyMap {
+	return KeyMap{
+		Prev:  keys.Bind("palette.prev"),
+		Next:  keys.Bind("palette.next"),
+		Run:   keys.Bind("palette.run"),
+		Close: keys.Bind("palette.close"),
+	}
+}
+
+// dialogWidth is the palette's width including border and padding.
+const dialogWidth = 64
+
+// maxRows caps the number of commands listed at once; the list scrolls to
+// keep the cursor visible.
+const maxRows = 10
+
+// styles holds the palette's themed styles.
+type styles struct {
+	modal    theme.ModalStyles
+	selected lipgloss.Style
+	normal   lipgloss.Style
+	muted    lipgloss.Style
+	key      lipgloss.Style
+}
+
+// Model is the command palette. The zero value is invisible.
+type Model struct {
+	input    textinput.Model
+	commands []Command
+	matches  []Command
+	cursor   int
+	offset   int // first listed match
+	visible  bool
+	keys     KeyMap
+	styles   styles
+}
+
+// New creates a visible palette listing commands, with an empty, focused
+// query.
+func New(commands []Command, p theme.Palette) Model {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = i18n.T("palette.placeholder")
+	m := Model{
+		input:    ti,
+		commands: commands,
+		visible:  true,
+		keys:     defaultKeyMap(),
+		styles: styles{
+			modal:    theme.NewModalStylesFromPalette(p),
+			selected: lipgloss.NewStyle().Foreground(p.Primary).Bold(true),
+			normal:   lipgloss.NewStyle().Foreground(p.Foreground),
+			muted:    lipgloss.NewStyle().Foreground(p.ForegroundMuted),
+			key:      lipgloss.NewStyle().Foreground(p.Secondary),
+		},
+	}
+	m.styles.modal.Dialog = m.styles.modal.Dialog.Width(dialogWidth)
+	m.input.SetWidth(dialogWidth - m.styles.modal.Dialog.GetHorizontalFrameSize() - lipgloss.Width(ti.Prompt) - 1)
+	m.input.Focus()
+	m.filter()
+	return m
+}
+
+// Visible reports whether the palette is displayed.
+func (m Model) Visible() bool { return m.visible }
+
+// Keys returns the palette's key bindings.
+func (m Model) Keys() KeyMap { return m.keys }
+
+// Matches returns the commands matching the query, best first.
+func (m Model) Matches() []Command { return m.matches }
+
+// Update handles a key press while the palette is open. Running a command
+// or closing the palette returns the zero Model.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok {
+		var cmd tea.Cmd
+		m.input, cmd = m.input.Update(msg)
+		return m, cmd
+	}
+	switch {
+	case key.Matches(keyMsg, m.keys.Close):
+		return Model{}, nil
+	case key.Matches(keyMsg, m.keys.Run):
+		if len(m.matches) == 0 {
+			return m, nil
+		}
+		return Model{}, m.matches[m.cursor].Cmd
+	case key.Matches(keyMsg, m.keys.Prev):
+		m.cursor = max(m.cursor-1, 0)
+		m.scrollToCursor()
+		return m, nil
+	case key.Matches(keyMsg, m.keys.Next):
+		m.cursor = max(min(m.cursor+1, len(m.matches)-1), 0)
+		m.scrollToCursor()
+		return m, nil
+	}
+
+	prev := m.input.Value()
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(keyMsg)
+	if m.input.Value() != prev {
+		m.filter()
+	}
+	return m, cmd
+}
+
+// filter ranks the commands against the query by the best fuzzy match of
+// their title and description. An empty query lists every command in
+// order.
+func (m *Model) filter() {
+	m.cursor, m.offset = 0, 0
+	query := strings.TrimSpace(m.input.Value())
+	if query == "" {
+		m.matches = m.commands
+		return
+	}
+
+	type scored struct {
+		cmd   Command
+		score int
+	}
+	var hits []scored
+	for _, c := range m.commands {
+		found := fuzzy.Find(query, []string{c.Title, c.Desc})
+		if len(found) == 0 {
+			continue
+		}
+		// fuzzy.Find sorts by score, so the first match is the best one.
+		hits = append(hits, scored{cmd: c, score: found[0].Score})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+
+	m.matches = make([]Command, len(hits))
+	for i, h := range hits {
+		m.matches[i] = h.cmd
+	}
+}
+
+// scrollToCursor keeps the cursor within the listed rows.
+func (m *Model) scrollToCursor() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+maxRows {
+		m.offset = m.cursor - maxRows + 1
+	}
+}
+
+// View renders the dialog.
+func (m Model) View() tea.View {
+	inner := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.modal.Title.Render(i18n.T("palette.title")),
+		"",
+		m.input.View(),
+		"",
+		m.list(),
+		"",
+		m.styles.modal.Hint.Render(m.hint()),
+	)
+	return tea.NewView(m.styles.modal.Dialog.Render(inner))
+}
+
+// hint lists the palette's keys as currently bound.
+func (m Model) hint() string {
+	return keys.Hint(
+		keys.Group(i18n.T("palette.hint.select"), m.keys.Prev, m.keys.Next),
+		keys.Group(i18n.T("palette.hint.run"), m.keys.Run),
+		keys.Group(i18n.T("palette.hint.close"), m.keys.Close),
+	)
+}
+
+// list renders the visible matches, one per line, with the key that runs
+// each command right-aligned.
+func (m Model) list() string {
+	if len(m.matches) == 0 {
+		return m.styles.muted.Render("  " + i18n.T("palette.none"))
+	}
+	width := dialogWidth - m.styles.modal.Dialog.GetHorizontalFrameSize()
+	end := min(m.offset+maxRows, len(m.matches))
+	rows := make([]string, 0, end-m.offset+1)
+	for i := m.offset; i < end; i++ {
+		c := m.matches[i]
+		title, marker := m.styles.normal, "  "
+		if i == m.cursor {
+			title, marker = m.styles.selected, "▸ "
+		}
+		left := title.Render(marker + c.Title)
+		if c.Desc != "" {
+			left += "  " + m.styles.muted.Render(c.Desc)
+		}
+		right := m.styles.key.Render(c.Key)
+		gap := width - lipgloss.Width(left) - lipgloss.Width(right)
+		if gap < 1 {
+			left = lipgloss.NewStyle().MaxWidth(max(width-lipgloss.Width(right)-1, 0)).Render(left)
+			gap = max(width-lipgloss.Width(left)-lipgloss.Width(right), 1)
+		}
+		rows = append(rows, left+strings.Repeat(" ", gap)+right)
+	}
+	if rest := len(m.matches) - end; rest > 0 {
+		rows = append(rows, m.styles.muted.Render("  "+i18n.T("search.more", rest)))
+	}
+	return strings.Join(rows, "\n")
+}
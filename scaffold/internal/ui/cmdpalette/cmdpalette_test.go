@@ -0,0 +1,100 @@
+package cmdpalette
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/theme"
+)
+
+type ranMsg struct{ name string }
+
+func testCommands() []Command {
+	run := func(name string) tea.Cmd { return func() tea.Msg { return ranMsg{name} } }
+	return []Command{
+		{Title: "Quit", Key: "q", Cmd: run("quit")},
+		{Title: "Go to: Settings", Desc: "Configure the app", Cmd: run("settings")},
+		{Title: "Theme: ocean", Cmd: run("ocean")},
+	}
+}
+
+// typeText types s into m one key at a time.
+func typeText(m Model, s string) Model {
+	for _, r := range s {
+		m, _ = m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	return m
+}
+
+func TestNew_ListsCommandsInOrder(t *testing.T) {
+	m := New(testCommands(), theme.Palette{})
+	require.True(t, m.Visible())
+	var titles []string
+	for _, c := range m.Matches() {
+		titles = append(titles, c.Title)
+	}
+	assert.Equal(t, []string{"Quit", "Go to: Settings", "Theme: ocean"}, titles)
+}
+
+func TestRegistered_EvaluatesSources(t *testing.T) {
+	title := "Extra"
+	Register(func() []Command { return []Command{{Title: title}} })
+	t.Cleanup(func() { sources = sources[:len(sources)-1] })
+
+	title = "Renamed"
+	assert.Equal(t, []Command{{Title: "Renamed"}}, Registered(), "sources run when asked, not when registered")
+}
+
+func TestUpdate_FiltersAndRunsBestMatch(t *testing.T) {
+	m := typeText(New(testCommands(), theme.Palette{}), "ocean")
+	require.Len(t, m.Matches(), 1)
+	assert.Contains(t, ansi.Strip(m.View().Content), "Theme: ocean")
+
+	m, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, m.Visible(), "running a command closes the palette")
+	require.NotNil(t, cmd)
+	assert.Equal(t, ranMsg{"ocean"}, cmd())
+}
+
+func TestUpdate_SearchesDescriptions(t *testing.T) {
+	m := typeText(New(testCommands(), theme.Palette{}), "configure")
+	require.Len(t, m.Matches(), 1)
+	assert.Equal(t, "Go to: Settings", m.Matches()[0].Title)
+}
+
+func TestUpdate_MovesCursorAndCloses(t *testing.T) {
+	m := New(testCommands(), theme.Palette{})
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Equal(t, ranMsg{"settings"}, cmd(), "the cursor stops at the last match")
+
+	m, cmd = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.False(t, m.Visible())
+	assert.Nil(t, cmd)
+}
+
+func TestUpdate_NoMatches(t *testing.T) {
+	m := typeText(New(testCommands(), theme.Palette{}), "zzz")
+	assert.Empty(t, m.Matches())
+	m, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.True(t, m.Visible(), "enter with nothing to run keeps the palette open")
+	assert.Nil(t, cmd)
+}
+
+func TestView_HintFollowsRebinding(t *testing.T) {
+	view := ansi.Strip(New(testCommands(), theme.Palette{}).View().Content)
+	assert.Contains(t, view, "↑/↓ select • enter run • esc close")
+
+	require.NoError(t, keys.SetOverrides(keys.Overrides{"palette": {"run": {"tab"}, "close": {"ctrl+g"}}}))
+	t.Cleanup(func() { _ = keys.SetOverrides() })
+	view = ansi.Strip(New(testCommands(), theme.Palette{}).View().Content)
+	assert.Contains(t, view, "tab run • ctrl+g close")
+}
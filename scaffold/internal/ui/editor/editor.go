@@ -0,0 +1,359 @@
+// Package editor provides the built-in multi-line text editor: a bubbles
+// textarea configured by EditorConfig with line numbers, tab stops and
+// autosave. It runs full screen as screens.Editor, embedded in a huh form
+// as a Field, or beside other inputs in any view, where the host forwards
+// messages and toggles focus.
+//
+// Saving goes through the task package, so a slow SaveFunc never blocks
+// the UI. Whenever the buffer starts or stops differing from the last save
+// the editor emits a DirtyMsg, which rootModel shows in the statusbar.
+package editor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+
+	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/task"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/theme"
+)
+
+// SaveFunc persists the buffer. It runs in a task goroutine and should
+// honor ctx.
+type SaveFunc func(ctx context.Context, content string) error
+
+// DirtyMsg reports that the editor with the given ID gained or lost unsaved
+// changes.
+type DirtyMsg struct {
+	ID    string
+	Dirty bool
+}
+
+// autoSaveMsg fires every AutoSaveInterval while autosave is on. gen ties it
+// to the tick loop that scheduled it so a restarted loop drops stale ticks.
+type autoSaveMsg struct {
+	id  string
+	gen int
+}
+
+// defaultTabWidth is used when EditorConfig.TabWidth is not positive.
+const defaultTabWidth = 4
+
+// KeyMap holds the editor's own bindings. Cursor movement and editing use
+// the textarea defaults.
+type KeyMap struct {
+	Save key.Binding
+	Tab  key.Binding
+}
+
+func init() {
+	keys.Register(
+		keys.Def{ID: "editor.save", Keys: []string{"ctrl+s", "ctrl+x ctrl+s"}, Desc: "editor.keys.save"},
+		keys.Def{ID: "editor.indent", Keys: []string{"tab"}, Desc: "editor.keys.indent"},
+	)
+}
+
+// DefaultKeyMap returns the editor bindings with user overrides applied and
+// help text in the current language.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Save: keys.Bind("editor.save"),
+		Tab:  keys.Bind("editor.indent"),
+	}
+}
+
+// Model is the editor component.
+type Model struct {
+	id     string
+	ctx    context.Context
+	cfg    config.EditorConfig
+	save   SaveFunc
+	ta     textarea.Model
+	keys   KeyMap
+	saved  string // buffer as of the last successful save
+	dirty  bool
+	saving bool
+	gen    int // generation of the autosave tick loop
+
+	// The content as loaded, line by line, and as the textarea holds it,
+	// so lines the user did not change are written back byte for byte.
+	loaded   []string
+	shown    []string
+	original map[string]string // shown line → loaded line
+}
+
+// New creates an unfocused editor holding content. id labels its messages
+// and save tasks, so several editors can share one program; ctx cancels
+// running saves. A nil save makes the editor read-only for saving: ctrl+s
+// and autosave do nothing.
+func New(ctx context.Context, id, content string, cfg config.EditorConfig, save SaveFunc) Model {
+	ta := textarea.New()
+	ta.Prompt = ""
+	ta.ShowLineNumbers = cfg.ShowLineNumbers
+	// ctrl+t is the global random-theme key.
+	ta.KeyMap.TransposeCharacterBackward.SetEnabled(false)
+
+	m := Model{id: id, ctx: ctx, cfg: cfg, save: save, ta: ta, keys: DefaultKeyMap()}
+	m.load(content)
+	m.saved = m.Value()
+	return m
+}
+
+// load puts content in the textarea, which cannot hold tabs, with its tabs
+// expanded, and remembers the lines as loaded for Value.
+func (m *Model) load(content string) {
+	m.loaded = strings.Split(content, "\n")
+	m.shown = make([]string, len(m.loaded))
+	m.original = make(map[string]string, len(m.loaded))
+	for i, line := range m.loaded {
+		m.shown[i] = ExpandTabs(line, m.tabWidth())
+		if _, ok := m.original[m.shown[i]]; !ok {
+			m.original[m.shown[i]] = line
+		}
+	}
+	m.ta.SetValue(strings.Join(m.shown, "\n"))
+}
+
+// ID returns the editor's label.
+func (m Model) ID() string { return m.id }
+
+// Init starts the autosave loop when autosave is on, replacing any loop
+// started before.
+func (m *Model) Init() tea.Cmd {
+	return m.autoSaveTick()
+}
+
+// Focus focuses the editor so it receives keys.
+func (m *Model) Focus() tea.Cmd { return m.ta.Focus() }
+
+// Blur removes focus from the editor.
+func (m *Model) Blur() { m.ta.Blur() }
+
+// Focused reports whether the editor receives keys.
+func (m Model) Focused() bool { return m.ta.Focused() }
+
+// Dirty reports whether the buffer differs from the last save.
+func (m Model) Dirty() bool { return m.dirty }
+
+// Value returns the buffer. Lines the user did not change are returned as
+// loaded, tabs and all. The tab setting applies to the others: with
+// ExpandTabs off, their leading indentation is written with tabs.
+func (m Model) Value() string {
+	lines := strings.Split(m.ta.Value(), "\n")
+	for i, line := range lines {
+		if i < len(m.shown) && line == m.shown[i] {
+			lines[i] = m.loaded[i]
+		} else if orig, ok := m.original[line]; ok {
+			lines[i] = orig
+		} else if !m.cfg.ExpandTabs {
+			lines[i] = IndentWithTabs(line, m.tabWidth())
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetValue replaces the buffer. The new content counts as unsaved unless it
+// equals the last save.
+func (m *Model) SetValue(content string) tea.Cmd {
+	m.load(content)
+	return m.updateDirty()
+}
+
+// SetSize sets the editor's outer width and height, line numbers included.
+func (m *Model) SetSize(w, h int) {
+	m.ta.SetWidth(w)
+	m.ta.SetHeight(h)
+}
+
+// ApplyTheme styles the editor from the palette.
+func (m *Model) ApplyTheme(state theme.State) {
+	m.ta.SetStyles(theme.TextAreaStyles(state.Palette))
+}
+
+// ApplyLanguage rebuilds the key help in the current language.
+func (m *Model) ApplyLanguage() {
+	m.keys = DefaultKeyMap()
+}
+
+// ShortHelp returns the bindings for the help bar.
+func (m Model) ShortHelp() []key.Binding {
+	return []key.Binding{m.keys.Save}
+}
+
+// FullHelp returns the bindings for the full-help overlay.
+func (m Model) FullHelp() [][]key.Binding {
+	k := m.ta.KeyMap
+	return [][]key.Binding{
+		{m.keys.Save, m.keys.Tab},
+		{k.LineStart, k.LineEnd, k.WordForward, k.WordBackward, k.PageUp, k.PageDown},
+		{k.DeleteWordBackward, k.DeleteAfterCursor, k.DeleteBeforeCursor, k.Paste},
+	}
+}
+
+// Save starts saving the buffer. It is a no-op while a save is running or
+// without a SaveFunc.
+func (m *Model) Save() tea.Cmd {
+	if m.save == nil || m.saving {
+		return nil
+	}
+	m.saving = true
+	content, save := m.Value(), m.save
+	return task.Run(m.ctx, m.saveLabel(), func(ctx context.Context) (string, error) {
+		if err := save(ctx, content); err != nil {
+			return content, errors.New(i18n.T("editor.saveFailed", err))
+		}
+		return content, nil
+	})
+}
+
+// Update handles keys while focused, and autosave ticks and save results
+// regardless of focus.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case autoSaveMsg:
+		if msg.id != m.id || msg.gen != m.gen {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		if m.dirty {
+			cmd = m.Save()
+		}
+		return m, tea.Batch(cmd, m.nextAutoSave())
+
+	case task.DoneMsg[string]:
+		if msg.Label != m.saveLabel() {
+			return m, nil
+		}
+		m.saving = false
+		m.saved = msg.Value
+		return m, m.updateDirty()
+
+	case task.ErrMsg:
+		if msg.Label != m.saveLabel() {
+			return m, nil
+		}
+		m.saving = false
+		return m, nil
+
+	case keys.ChordMsg:
+		if m.ta.Focused() && key.Matches(msg, m.keys.Save) {
+			return m, m.Save()
+		}
+		return m, nil
+
+	case tea.KeyPressMsg:
+		if !m.ta.Focused() {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, m.keys.Save):
+			return m, m.Save()
+		case key.Matches(msg, m.keys.Tab):
+			// Indent to the next tab stop. The textarea cannot hold tab
+			// characters, so indentation is spaces until Value converts it.
+			w := m.tabWidth()
+			m.ta.InsertString(strings.Repeat(" ", w-m.ta.Column()%w))
+			return m, m.updateDirty()
+		}
+	}
+
+	var cmd tea.Cmd
+	m.ta, cmd = m.ta.Update(msg)
+	return m, tea.Batch(cmd, m.updateDirty())
+}
+
+// View renders the textarea.
+func (m Model) View() string {
+	return m.ta.View()
+}
+
+// updateDirty recomputes the dirty flag and reports a change with a
+// DirtyMsg.
+func (m *Model) updateDirty() tea.Cmd {
+	dirty := m.Value() != m.saved
+	if dirty == m.dirty {
+		return nil
+	}
+	m.dirty = dirty
+	id := m.id
+	return func() tea.Msg { return DirtyMsg{ID: id, Dirty: dirty} }
+}
+
+// autoSaveTick starts a new autosave loop, superseding any running one.
+func (m *Model) autoSaveTick() tea.Cmd {
+	m.gen++
+	return m.nextAutoSave()
+}
+
+// nextAutoSave schedules the next tick of the current loop.
+func (m Model) nextAutoSave() tea.Cmd {
+	if !m.cfg.AutoSave || m.cfg.AutoSaveInterval <= 0 || m.save == nil {
+		return nil
+	}
+	id, gen := m.id, m.gen
+	return tea.Tick(time.Duration(m.cfg.AutoSaveInterval)*time.Second, func(time.Time) tea.Msg {
+		return autoSaveMsg{id: id, gen: gen}
+	})
+}
+
+func (m Model) saveLabel() string {
+	return fmt.Sprintf("editor-save:%s", m.id)
+}
+
+func (m Model) tabWidth() int {
+	if m.cfg.TabWidth > 0 {
+		return m.cfg.TabWidth
+	}
+	return defaultTabWidth
+}
+
+// ExpandTabs replaces every tab in s with spaces up to the next multiple of
+// width on its line.
+func ExpandTabs(s string, width int) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		switch r {
+		case '\t':
+			n := width - col%width
+			b.WriteString(strings.Repeat(" ", n))
+			col += n
+		case '\n':
+			b.WriteRune(r)
+			col = 0
+		default:
+			b.WriteRune(r)
+			col++
+		}
+	}
+	return b.String()
+}
+
+// IndentWithTabs replaces each full run of width spaces in the leading
+// indentation of every line with a tab. Spaces inside a line are kept.
+func IndentWithTabs(s string, width int) string {
+	unit := strings.Repeat(" ", width)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		n := 0
+		for strings.HasPrefix(line[n*width:], unit) {
+			n++
+		}
+		if n > 0 {
+			lines[i] = strings.Repeat("\t", n) + line[n*width:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,230 @@
+package editor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/task"
+	"scaffold/internal/ui/keys"
+)
+
+func testConfig() config.EditorConfig {
+	return config.EditorConfig{TabWidth: 4, ExpandTabs: true, ShowLineNumbers: true}
+}
+
+func typeText(m Model, s string) (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, r := range s {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// findDirty runs cmd and returns the DirtyMsg among its results, if any.
+func findDirty(cmd tea.Cmd) (DirtyMsg, bool) {
+	if cmd == nil {
+		return DirtyMsg{}, false
+	}
+	switch msg := cmd().(type) {
+	case DirtyMsg:
+		return msg, true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if d, ok := findDirty(c); ok {
+				return d, true
+			}
+		}
+	}
+	return DirtyMsg{}, false
+}
+
+func TestExpandTabs_AlignsToTabStops(t *testing.T) {
+	assert.Equal(t, "    a", ExpandTabs("\ta", 4))
+	assert.Equal(t, "ab  c", ExpandTabs("ab\tc", 4))
+	assert.Equal(t, "a\n    b", ExpandTabs("a\n\tb", 4))
+	assert.Equal(t, "no tabs", ExpandTabs("no tabs", 4))
+}
+
+func TestIndentWithTabs_ConvertsLeadingIndentOnly(t *testing.T) {
+	assert.Equal(t, "\t\tx", IndentWithTabs("        x", 4))
+	assert.Equal(t, "\t  x", IndentWithTabs("      x", 4), "partial indent units stay spaces")
+	assert.Equal(t, "a    b", IndentWithTabs("a    b", 4))
+	assert.Equal(t, "\t", IndentWithTabs("    ", 4))
+}
+
+func TestModel_Value_RoundTripsTabsWhenNotExpanding(t *testing.T) {
+	cfg := testConfig()
+	cfg.ExpandTabs = false
+	m := New(context.Background(), "t", "func f() {\n\treturn\n}", cfg, nil)
+	assert.Equal(t, "func f() {\n\treturn\n}", m.Value())
+	assert.False(t, m.Dirty())
+}
+
+func TestModel_Value_KeepsUntouchedLinesAsLoaded(t *testing.T) {
+	spaces := "def f():\n    return 1\n"
+	tabs := "key\tvalue\n\tindented\tcomment\n"
+	for _, expand := range []bool{true, false} {
+		cfg := testConfig()
+		cfg.ExpandTabs = expand
+		for _, content := range []string{spaces, tabs} {
+			m := New(context.Background(), "t", content, cfg, nil)
+			assert.Equal(t, content, m.Value(), "expand=%v", expand)
+			assert.False(t, m.Dirty())
+		}
+	}
+}
+
+func TestModel_Value_AppliesTabSettingToTypedLines(t *testing.T) {
+	cfg := testConfig()
+	cfg.ExpandTabs = false
+	m := New(context.Background(), "t", "x = 1\n    y = 2", cfg, nil)
+	m.Focus()
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	m, _ = typeText(m, "z")
+	assert.Equal(t, "x = 1\n    y = 2\n\tz", m.Value(), "only the typed line is indented with a tab")
+}
+
+func TestModel_Tab_IndentsToNextStop(t *testing.T) {
+	m := New(context.Background(), "t", "", testConfig(), nil)
+	m.Focus()
+	m, _ = typeText(m, "ab")
+	m, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	m, _ = typeText(m, "c")
+	assert.Equal(t, "ab  c", m.Value())
+}
+
+func TestModel_Typing_ReportsDirtyOnce(t *testing.T) {
+	m := New(context.Background(), "notes", "", testConfig(), nil)
+	m.Focus()
+
+	m, cmd := typeText(m, "x")
+	msg, ok := findDirty(cmd)
+	require.True(t, ok)
+	assert.Equal(t, DirtyMsg{ID: "notes", Dirty: true}, msg)
+
+	m, cmd = typeText(m, "y")
+	_, ok = findDirty(cmd)
+	assert.False(t, ok, "no message while the state is unchanged")
+	assert.True(t, m.Dirty())
+}
+
+func TestModel_IgnoresKeysWhenBlurred(t *testing.T) {
+	m := New(context.Background(), "t", "", testConfig(), nil)
+	m, _ = typeText(m, "x")
+	assert.Empty(t, m.Value())
+}
+
+func TestModel_Save_ClearsDirty(t *testing.T) {
+	var saved string
+	save := func(_ context.Context, content string) error {
+		saved = content
+		return nil
+	}
+	m := New(context.Background(), "t", "", testConfig(), save)
+	m.Focus()
+	m, _ = typeText(m, "hi")
+
+	m, cmd := m.Update(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	require.NotNil(t, cmd)
+	m, cmd = m.Update(cmd())
+
+	assert.Equal(t, "hi", saved)
+	assert.False(t, m.Dirty())
+	msg, ok := findDirty(cmd)
+	require.True(t, ok)
+	assert.False(t, msg.Dirty)
+}
+
+func TestModel_SaveChord_Saves(t *testing.T) {
+	var saved string
+	save := func(_ context.Context, content string) error {
+		saved = content
+		return nil
+	}
+	m := New(context.Background(), "t", "", testConfig(), save)
+	m.Focus()
+	m, _ = typeText(m, "hi")
+
+	m, cmd := m.Update(keys.ChordMsg{Keys: "ctrl+x ctrl+s"})
+	require.NotNil(t, cmd)
+	m.Update(cmd())
+	assert.Equal(t, "hi", saved)
+}
+
+func TestModel_SaveError_KeepsDirty(t *testing.T) {
+	save := func(context.Context, string) error { return errors.New("disk full") }
+	m := New(context.Background(), "t", "", testConfig(), save)
+	m.Focus()
+	m, _ = typeText(m, "hi")
+
+	cmd := m.Save()
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(task.ErrMsg)
+	require.True(t, ok)
+	assert.EqualError(t, msg.Err, i18n.T("editor.saveFailed", "disk full"))
+	m, cmd = m.Update(msg)
+
+	assert.True(t, m.Dirty())
+	assert.Nil(t, cmd, "the root model reports failed tasks, not the editor")
+	assert.NotNil(t, m.Save(), "a new save may start after a failure")
+}
+
+func TestModel_AutoSave_SavesOnlyWhenDirty(t *testing.T) {
+	calls := 0
+	save := func(context.Context, string) error {
+		calls++
+		return nil
+	}
+	cfg := testConfig()
+	cfg.AutoSave = true
+	cfg.AutoSaveInterval = 30
+	m := New(context.Background(), "t", "", cfg, save)
+	require.NotNil(t, m.Init())
+	m.Focus()
+
+	_, cmd := m.Update(autoSaveMsg{id: "t", gen: m.gen})
+	require.NotNil(t, cmd, "the loop reschedules itself")
+	assert.Zero(t, calls)
+
+	m, _ = typeText(m, "x")
+	m, cmd = m.Update(autoSaveMsg{id: "t", gen: m.gen})
+	require.NotNil(t, cmd)
+	assert.True(t, m.saving)
+}
+
+func TestModel_AutoSave_DropsStaleTicks(t *testing.T) {
+	cfg := testConfig()
+	cfg.AutoSave = true
+	cfg.AutoSaveInterval = 30
+	m := New(context.Background(), "t", "", cfg, func(context.Context, string) error { return nil })
+	m.Init()
+	stale := m.gen
+	m.Init()
+
+	_, cmd := m.Update(autoSaveMsg{id: "t", gen: stale})
+	assert.Nil(t, cmd)
+	_, cmd = m.Update(autoSaveMsg{id: "other", gen: m.gen})
+	assert.Nil(t, cmd)
+}
+
+func TestModel_AutoSave_OffSchedulesNothing(t *testing.T) {
+	m := New(context.Background(), "t", "", testConfig(), func(context.Context, string) error { return nil })
+	assert.Nil(t, m.Init())
+}
+
+func TestModel_IgnoresOtherTasks(t *testing.T) {
+	m := New(context.Background(), "t", "", testConfig(), nil)
+	m.saving = true
+	m, _ = m.Update(task.DoneMsg[string]{Label: "detail-load", Value: "loaded"})
+	assert.True(t, m.saving)
+}
@@ -0,0 +1,275 @@
+package editor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+	"charm.land/lipgloss/v2"
+
+	"scaffold/config"
+)
+
+// defaultFieldLines is the editing height of a Field whose form sets none.
+const defaultFieldLines = 6
+
+// Field embeds the editor in a huh form as a huh.Field. The form's text
+// key map moves between fields: Next and Prev leave the editor, except for
+// keys that insert a new line, which stay with it. Every other key, and
+// every other message, goes to the editor, so saving and autosave work as
+// they do full screen.
+type Field struct {
+	editor      Model
+	key         string
+	title       string
+	description string
+	value       *string
+	validate    func(string) error
+	err         error
+
+	keymap    huh.TextKeyMap
+	theme     huh.Theme
+	hasDarkBg bool
+	width     int
+	height    int
+	focused   bool
+}
+
+// NewField creates a form field holding content. The arguments are those
+// of New.
+func NewField(ctx context.Context, id, content string, cfg config.EditorConfig, save SaveFunc) *Field {
+	f := &Field{
+		editor:   New(ctx, id, content, cfg, save),
+		keymap:   huh.NewDefaultKeyMap().Text,
+		validate: func(string) error { return nil },
+		height:   defaultFieldLines,
+	}
+	f.editor.ta.SetHeight(f.height)
+	return f
+}
+
+// Key sets the key the form stores the field's value under.
+func (f *Field) Key(key string) *Field {
+	f.key = key
+	return f
+}
+
+// Title sets the field's title.
+func (f *Field) Title(title string) *Field {
+	f.title = title
+	return f
+}
+
+// Description sets the field's description.
+func (f *Field) Description(description string) *Field {
+	f.description = description
+	return f
+}
+
+// Value binds the buffer to v: it starts with *v when set, and *v follows
+// every edit.
+func (f *Field) Value(v *string) *Field {
+	f.value = v
+	if *v != "" {
+		f.editor.load(*v)
+		f.editor.saved = f.editor.Value()
+	}
+	return f
+}
+
+// Validate sets the check run on the buffer when the field loses focus.
+func (f *Field) Validate(validate func(string) error) *Field {
+	f.validate = validate
+	return f
+}
+
+// Editor returns the embedded editor, for theming and relocalizing it.
+func (f *Field) Editor() *Model { return &f.editor }
+
+// Init starts the editor's autosave.
+func (f *Field) Init() tea.Cmd {
+	return f.editor.Init()
+}
+
+// Update moves focus on the form's Next and Prev keys and passes anything
+// else to the editor.
+func (f *Field) Update(msg tea.Msg) (huh.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.BackgroundColorMsg:
+		f.hasDarkBg = msg.IsDark()
+	case tea.KeyPressMsg:
+		if !f.focused {
+			return f, nil
+		}
+		f.err = nil
+		switch {
+		case key.Matches(msg, f.keymap.Prev):
+			return f, f.leave(huh.PrevField)
+		case key.Matches(msg, f.keymap.Next) && !key.Matches(msg, f.editor.ta.KeyMap.InsertNewline):
+			return f, f.leave(huh.NextField)
+		}
+	}
+
+	var cmd tea.Cmd
+	f.editor, cmd = f.editor.Update(msg)
+	f.store()
+	return f, cmd
+}
+
+// leave validates the buffer and, when it passes, moves focus with move.
+func (f *Field) leave(move tea.Cmd) tea.Cmd {
+	if f.err = f.validate(f.editor.Value()); f.err != nil {
+		return nil
+	}
+	return move
+}
+
+// store writes the buffer to the bound value.
+func (f *Field) store() {
+	if f.value != nil {
+		*f.value = f.editor.Value()
+	}
+}
+
+// View renders the title, description and editor in the form's styles.
+func (f *Field) View() string {
+	styles := f.activeStyles()
+	var parts []string
+	if f.title != "" {
+		title := styles.Title.Render(f.title)
+		if f.err != nil {
+			title += styles.ErrorIndicator.String()
+		}
+		parts = append(parts, title)
+	}
+	if f.description != "" {
+		parts = append(parts, styles.Description.Render(f.description))
+	}
+	parts = append(parts, f.editor.View())
+	if f.err != nil {
+		parts = append(parts, styles.ErrorMessage.Render(f.err.Error()))
+	}
+	return styles.Base.Width(f.width).Render(lipgloss.JoinVertical(lipgloss.Left, parts...))
+}
+
+// activeStyles returns the theme's styles for the field's focus state.
+func (f *Field) activeStyles() *huh.FieldStyles {
+	theme := f.theme
+	if theme == nil {
+		theme = huh.ThemeFunc(huh.ThemeCharm)
+	}
+	if f.focused {
+		return &theme.Theme(f.hasDarkBg).Focused
+	}
+	return &theme.Theme(f.hasDarkBg).Blurred
+}
+
+// Focus focuses the editor.
+func (f *Field) Focus() tea.Cmd {
+	f.focused = true
+	return f.editor.Focus()
+}
+
+// Blur unfocuses the editor and validates the buffer.
+func (f *Field) Blur() tea.Cmd {
+	f.focused = false
+	f.editor.Blur()
+	f.err = f.validate(f.editor.Value())
+	return nil
+}
+
+// Error returns the validation error, if any.
+func (f *Field) Error() error { return f.err }
+
+// Run runs the field on its own in a single-field form.
+func (f *Field) Run() error { return huh.Run(f) }
+
+// RunAccessible prints the title and reads the buffer from r up to EOF.
+func (f *Field) RunAccessible(w io.Writer, r io.Reader) error {
+	fmt.Fprintln(w, f.activeStyles().Title.Render(f.title))
+	var lines []string
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return err
+	}
+	content := strings.Join(lines, "\n")
+	if err := f.validate(content); err != nil {
+		return err
+	}
+	f.editor.SetValue(content)
+	f.store()
+	return nil
+}
+
+// Skip returns false: the editor always takes focus.
+func (f *Field) Skip() bool { return false }
+
+// Zoom returns false.
+func (f *Field) Zoom() bool { return false }
+
+// KeyBinds returns the bindings for the form's help.
+func (f *Field) KeyBinds() []key.Binding {
+	return append(f.editor.ShortHelp(), f.keymap.Prev, f.keymap.Next)
+}
+
+// WithTheme sets the theme of the title and description.
+func (f *Field) WithTheme(theme huh.Theme) huh.Field {
+	if f.theme == nil {
+		f.theme = theme
+	}
+	return f
+}
+
+// WithKeyMap sets the keys that move between fields.
+func (f *Field) WithKeyMap(k *huh.KeyMap) huh.Field {
+	f.keymap = k.Text
+	return f
+}
+
+// WithWidth sets the field's width.
+func (f *Field) WithWidth(width int) huh.Field {
+	f.width = width
+	f.resize()
+	return f
+}
+
+// WithHeight sets the field's height, title and description included.
+func (f *Field) WithHeight(height int) huh.Field {
+	for _, s := range []string{f.title, f.description} {
+		if s != "" {
+			height -= lipgloss.Height(s)
+		}
+	}
+	f.height = height
+	f.resize()
+	return f
+}
+
+// resize fits the editor into the field's frame once the form has given it
+// a width.
+func (f *Field) resize() {
+	if f.width == 0 {
+		return
+	}
+	f.editor.SetSize(f.width-f.activeStyles().Base.GetHorizontalFrameSize(), max(f.height, 1))
+}
+
+// WithPosition is a no-op: the field looks the same anywhere in a form.
+func (f *Field) WithPosition(huh.FieldPosition) huh.Field { return f }
+
+// GetKey returns the field's key.
+func (f *Field) GetKey() string { return f.key }
+
+// GetValue returns the buffer.
+func (f *Field) GetValue() any { return f.editor.Value() }
+
+// Ensure Field implements huh.Field.
+var _ huh.Field = (*Field)(nil)
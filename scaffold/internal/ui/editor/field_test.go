@@ -0,0 +1,84 @@
+package editor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// typeInto sends s to f one key at a time.
+func typeInto(f *Field, s string) {
+	for _, r := range s {
+		f.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+func TestField_EditsBoundValue(t *testing.T) {
+	notes := "first"
+	f := NewField(context.Background(), "notes", "", testConfig(), nil).Key("notes").Value(&notes)
+	assert.Equal(t, "first", f.GetValue())
+
+	f.Focus()
+	typeInto(f, "!")
+	f.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	typeInto(f, "x")
+	assert.Equal(t, "first!\nx", notes, "enter inserts a new line instead of leaving")
+	assert.Equal(t, notes, f.GetValue())
+}
+
+func TestField_TabMovesBetweenFields(t *testing.T) {
+	f := NewField(context.Background(), "notes", "", testConfig(), nil)
+	f.Focus()
+
+	_, cmd := f.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	require.NotNil(t, cmd)
+	assert.Equal(t, huh.NextField(), cmd())
+
+	_, cmd = f.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	require.NotNil(t, cmd)
+	assert.Equal(t, huh.PrevField(), cmd())
+}
+
+func TestField_InvalidValueKeepsFocus(t *testing.T) {
+	f := NewField(context.Background(), "notes", "", testConfig(), nil).
+		Validate(func(s string) error {
+			if s == "" {
+				return errors.New("required")
+			}
+			return nil
+		})
+	f.Focus()
+
+	_, cmd := f.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Nil(t, cmd)
+	assert.EqualError(t, f.Error(), "required")
+}
+
+func TestField_InForm(t *testing.T) {
+	var notes, name string
+	form := huh.NewForm(huh.NewGroup(
+		NewField(context.Background(), "notes", "", testConfig(), nil).Key("notes").Title("Notes").Value(&notes),
+		huh.NewInput().Key("name").Title("Name").Value(&name),
+	)).WithWidth(60)
+	form.Init()
+
+	for _, r := range "hi" {
+		form.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	_, cmd := form.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	require.NotNil(t, cmd)
+	form.Update(cmd())
+	for _, r := range "bo" {
+		form.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+
+	assert.Equal(t, "hi", notes)
+	assert.Equal(t, "bo", name)
+	assert.Equal(t, "hi", form.GetString("notes"))
+	assert.Contains(t, form.View(), "Notes")
+}
@@ -0,0 +1,106 @@
+// Package extedit opens files in the user's external editor. The TUI is
+// suspended with tea.ExecProcess while the editor runs and resumes when it
+// exits, receiving the edited content as an EditedMsg.
+//
+// The editor is $VISUAL, then $EDITOR, then EditorConfig.EditorCommand,
+// then vi. Each may carry arguments, e.g. "code --wait".
+package extedit
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// fallbackEditor is used when no editor is configured anywhere.
+const fallbackEditor = "vi"
+
+// EditedMsg is delivered when the editor exits. ID is the caller's label,
+// Path the edited file and Content its contents afterwards. Err is set when
+// the editor could not be started, exited with an error, or the file could
+// not be read back.
+type EditedMsg struct {
+	ID      string
+	Path    string
+	Content []byte
+	Err     error
+}
+
+// Command returns the editor command line: $VISUAL, $EDITOR or
+// editorCommand, whichever is set first, split into program and arguments.
+func Command(editorCommand string) []string {
+	for _, c := range []string{os.Getenv("VISUAL"), os.Getenv("EDITOR"), editorCommand} {
+		if fields := strings.Fields(c); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{fallbackEditor}
+}
+
+// Open edits the file at path and delivers an EditedMsg with the given ID.
+func Open(id, path, editorCommand string) tea.Cmd {
+	return tea.ExecProcess(editorCmd(editorCommand, path), finish(id, path, false))
+}
+
+// OpenTemp writes content to a new temporary file, edits it and delivers an
+// EditedMsg with the result. pattern names the file as in os.CreateTemp; a
+// suffix such as "*.md" lets the editor pick a syntax. The file is removed
+// once read back.
+func OpenTemp(id, content, pattern, editorCommand string) tea.Cmd {
+	path, err := writeTemp(content, pattern)
+	if err != nil {
+		return failed(id, "", err)
+	}
+	return tea.ExecProcess(editorCmd(editorCommand, path), finish(id, path, true))
+}
+
+// writeTemp writes content to a new temporary file named after pattern and
+// returns its path. Nothing is left behind on failure.
+func writeTemp(content, pattern string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", fmt.Errorf("creating temp file: %w", err)
+	}
+	path := f.Name()
+	_, err = f.WriteString(content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+	return path, nil
+}
+
+// editorCmd builds the process that edits path.
+func editorCmd(editorCommand, path string) *exec.Cmd {
+	argv := append(Command(editorCommand), path)
+	return exec.Command(argv[0], argv[1:]...)
+}
+
+// finish returns the ExecProcess callback that reads path back and, for
+// temporary files, removes it.
+func finish(id, path string, temp bool) tea.ExecCallback {
+	return func(runErr error) tea.Msg {
+		if temp {
+			defer os.Remove(path)
+		}
+		if runErr != nil {
+			return EditedMsg{ID: id, Path: path, Err: fmt.Errorf("editor: %w", runErr)}
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return EditedMsg{ID: id, Path: path, Err: fmt.Errorf("reading edited file: %w", err)}
+		}
+		return EditedMsg{ID: id, Path: path, Content: content}
+	}
+}
+
+// failed reports an error without starting the editor.
+func failed(id, path string, err error) tea.Cmd {
+	return func() tea.Msg { return EditedMsg{ID: id, Path: path, Err: err} }
+}
@@ -0,0 +1,73 @@
+package extedit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand_Precedence(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	assert.Equal(t, []string{"vi"}, Command(""))
+	assert.Equal(t, []string{"nano"}, Command("nano"))
+
+	t.Setenv("EDITOR", "emacs -nw")
+	assert.Equal(t, []string{"emacs", "-nw"}, Command("nano"), "$EDITOR beats the config")
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, []string{"code", "--wait"}, Command("nano"), "$VISUAL beats $EDITOR")
+}
+
+func TestEditorCmd_AppendsPath(t *testing.T) {
+	t.Setenv("VISUAL", "code --wait")
+	c := editorCmd("", "/tmp/f.txt")
+	assert.Equal(t, []string{"code", "--wait", "/tmp/f.txt"}, c.Args)
+}
+
+func TestFinish_ReadsBackContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "note.txt")
+	require.NoError(t, os.WriteFile(path, []byte("edited"), 0o600))
+
+	msg := finish("note", path, false)(nil).(EditedMsg)
+	assert.Equal(t, EditedMsg{ID: "note", Path: path, Content: []byte("edited")}, msg)
+	assert.FileExists(t, path, "files the caller owns are kept")
+}
+
+func TestFinish_RemovesTempFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tmp.txt")
+	require.NoError(t, os.WriteFile(path, []byte("x"), 0o600))
+
+	msg := finish("tmp", path, true)(nil).(EditedMsg)
+	assert.Equal(t, "x", string(msg.Content))
+	assert.NoFileExists(t, path)
+}
+
+func TestFinish_ReportsEditorError(t *testing.T) {
+	msg := finish("x", "/nonexistent", false)(errors.New("exit status 1")).(EditedMsg)
+	assert.ErrorContains(t, msg.Err, "exit status 1")
+	assert.Nil(t, msg.Content)
+}
+
+func TestOpenTemp_EditsBufferAndRemovesFile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the stand-in editor is a shell script")
+	}
+	editor := filepath.Join(t.TempDir(), "editor")
+	require.NoError(t, os.WriteFile(editor, []byte("#!/bin/sh\nprintf ' edited' >> \"$1\"\n"), 0o755))
+	t.Setenv("VISUAL", editor)
+
+	path, err := writeTemp("draft", "note-*.md")
+	require.NoError(t, err)
+	assert.Equal(t, ".md", filepath.Ext(path), "the pattern's suffix is kept for syntax detection")
+
+	msg := finish("note", path, true)(editorCmd("", path).Run()).(EditedMsg)
+	require.NoError(t, msg.Err)
+	assert.Equal(t, "draft edited", string(msg.Content))
+	assert.NoFileExists(t, path, "the temp file is removed once read back")
+}
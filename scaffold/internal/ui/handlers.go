@@ -2,19 +2,33 @@
 package ui
 
 import (
+	"context"
+	"errors"
+	"io/fs"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"charm.land/bubbles/v2/help"
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 
 	"scaffold/config"
+	"scaffold/internal/i18n"
 	"scaffold/internal/task"
+	"scaffold/internal/timefmt"
+	"scaffold/internal/ui/extedit"
+	"scaffold/internal/ui/keyhelp"
+	"scaffold/internal/ui/keys"
 	"scaffold/internal/ui/menu"
 	"scaffold/internal/ui/modal"
+	"scaffold/internal/ui/mouse"
 	"scaffold/internal/ui/screens"
 	"scaffold/internal/ui/status"
 	"scaffold/internal/ui/theme"
+	"scaffold/internal/ui/toast"
+	"scaffold/internal/ui/whichkey"
 )
 
 func (m rootModel) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
@@ -38,6 +52,7 @@ func (m rootModel) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd)
 	if setter, ok := m.current.(interface{ SetHeight(int) screens.Screen }); ok {
 		m.current = setter.SetHeight(m.bodyH)
 	}
+	m = m.refreshFullHelp()
 	return m, tea.Batch(append(cmds, m.themeMgr.SetWidth(m.width))...)
 }
 
@@ -47,11 +62,17 @@ func (m rootModel) handleBgColor(msg tea.BackgroundColorMsg) (tea.Model, tea.Cmd
 	return m, m.themeMgr.SetDarkMode(isDark)
 }
 
+// handleColorProfile degrades the palettes to the terminal's color profile,
+// as detected by Bubble Tea at startup.
+func (m rootModel) handleColorProfile(msg tea.ColorProfileMsg) (tea.Model, tea.Cmd) {
+	return m, m.themeMgr.SetProfile(msg.Profile)
+}
+
 func (m rootModel) handleThemeChanged(msg theme.ThemeChangedMsg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
-	m.styles = theme.NewFromPalette(msg.State.Palette, msg.State.Width)
+	m.styles = theme.NewFromState(msg.State)
 	m.help.SetWidth(m.styles.MaxWidth)
 
 	m.header, cmd = m.header.Update(msg)
@@ -59,29 +80,195 @@ func (m rootModel) handleThemeChanged(msg theme.ThemeChangedMsg) (tea.Model, tea
 	m.statusbar, cmd = m.statusbar.Update(msg)
 	cmds = append(cmds, cmd)
 
+	m.toasts.ApplyTheme(msg.State)
 	if t, ok := m.current.(theme.Themeable); ok {
 		t.ApplyTheme(msg.State)
 	}
 
 	m.bodyH = m.bodyHeight()
+	m = m.refreshFullHelp()
+	look := bannerLook{msg.State.Name, msg.State.IsDark, m.effectiveCfg().UI.ShowBanner}
+	if look != m.anims.swept {
+		m.anims.swept = look
+		m, cmd = m.startBannerSweep()
+		cmds = append(cmds, cmd)
+	}
 	return m, tea.Batch(cmds...)
 }
 
+// handleKey dispatches a key press down the focus chain and back up. An
+// open overlay (modal, command palette, full help) takes every key. Below
+// it, keys go through a capture phase, in which the current screen's
+// focused widget may consume a key outright (see screens.KeyCapturer), then
+// chords, then bubble up from the screen to the global key map in
+// dispatchKey. So "q" typed into a text field is text, never quit. Once a
+// chord is pending, the next key goes to it first, so "ctrl+x t" works in
+// a text field too.
 func (m rootModel) handleKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	if m.modal.Visible() {
 		var cmd tea.Cmd
 		m.modal, cmd = m.modal.Update(msg)
 		return m, cmd
 	}
+	if m.palette.Visible() {
+		var cmd tea.Cmd
+		m.palette, cmd = m.palette.Update(msg)
+		return m, cmd
+	}
+	if m.fullHelp.Visible() {
+		switch {
+		case key.Matches(msg, m.keys.Help), key.Matches(msg, m.keys.Back):
+			m.fullHelp = keyhelp.Model{}
+			return m, nil
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		}
+		var cmd tea.Cmd
+		m.fullHelp, cmd = m.fullHelp.Update(msg)
+		return m, cmd
+	}
+	if c, ok := m.current.(screens.KeyCapturer); ok && c.CapturesKey(msg) && m.chords.Pending() == nil {
+		return m.broadcast(msg)
+	}
+	var chordCmd tea.Cmd
+	var replay []tea.KeyPressMsg
+	m.chords, chordCmd, replay = m.chords.Feed(msg, m.combinedKeys().Bindings())
+	m = m.refreshWhichKey()
+	updated, cmd := m.dispatchKeys(replay)
+	return updated, tea.Batch(chordCmd, cmd)
+}
+
+// dispatchKeys handles keys that are not part of a chord in order.
+func (m rootModel) dispatchKeys(msgs []tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for _, msg := range msgs {
+		updated, cmd := m.dispatchKey(msg)
+		m = updated.(rootModel)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// dispatchKey bubbles a key press up from the innermost handler: the toast
+// action (toasts float above the screen), then the current screen's own
+// bindings, then the global key map. Keys nothing binds still reach the
+// screen, whose widgets may use keys they don't list in help.
+func (m rootModel) dispatchKey(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.toasts.HasAction() && key.Matches(msg, m.toasts.Keys().Act) {
+		var cmd tea.Cmd
+		m.toasts, cmd = m.toasts.Update(msg)
+		return m, cmd
+	}
+	if kb, ok := m.current.(screens.KeyBinder); ok {
+		for _, g := range kb.FullHelp() {
+			if key.Matches(msg, g...) {
+				return m.broadcast(msg)
+			}
+		}
+	}
+	if key.Matches(msg, m.keys.Back) && m.stack.Len() > 0 {
+		return m.handleBack(screens.BackMsg{})
+	}
 	if key.Matches(msg, m.keys.Quit) {
 		return m, tea.Quit
 	}
+	if key.Matches(msg, m.keys.Help) {
+		return m.openFullHelp(), nil
+	}
+	if key.Matches(msg, m.keys.Palette) {
+		return m.openPalette(), nil
+	}
 	if key.Matches(msg, m.keys.RandomTheme) {
 		return m.handleRandomTheme()
 	}
 	return m.broadcast(msg)
 }
 
+// handleMouse routes mouse events by hit-testing the layout: the modal or
+// the full-help overlay gets them while it is visible, the command palette
+// ignores them, clicks on a toast go to the toast stack, otherwise events
+// over the body go to the current screen in body coordinates. The wheel
+// scrolls a body that overflows its area instead of reaching the screen.
+func (m rootModel) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if !m.effectiveCfg().UI.MouseEnabled || m.state != rootStateReady {
+		return m, nil
+	}
+	if m.modal.Visible() {
+		x, y := modal.Origin(m.modal.View().Content, m.width, m.height)
+		var cmd tea.Cmd
+		m.modal, cmd = m.modal.Update(mouse.Translate(msg, x, y))
+		return m, cmd
+	}
+	if m.palette.Visible() {
+		return m, nil
+	}
+	if m.fullHelp.Visible() {
+		var cmd tea.Cmd
+		m.fullHelp, cmd = m.fullHelp.Update(msg)
+		return m, cmd
+	}
+	if x, y, ok := mouse.LeftClick(msg); ok {
+		if r := m.toastRect(m.toasts.View()); r.Contains(x, y) {
+			toasts, cmd, hit := m.toasts.Click(x-r.X, y-r.Y)
+			if hit {
+				m.toasts = toasts
+				return m, cmd
+			}
+		}
+	}
+
+	body := m.bodyRect()
+	pos := msg.Mouse()
+	if !body.Contains(pos.X, pos.Y) {
+		return m, nil
+	}
+	if delta := mouse.WheelDelta(msg); delta != 0 && m.bodyOverflow() > 0 {
+		m.scroll = bodyScroll{
+			owner:  m.current,
+			offset: min(max(m.bodyOffset()+delta, 0), m.bodyOverflow()),
+		}
+		return m, nil
+	}
+
+	updated, cmd := m.current.Update(mouse.Translate(msg, body.X, body.Y-m.bodyOffset()))
+	if s, ok := updated.(screens.Screen); ok {
+		m.current = s
+	}
+	return m, cmd
+}
+
+// handleChord runs the global binding a completed chord matches, or passes
+// the chord on to the current screen.
+func (m rootModel) handleChord(msg keys.ChordMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Themes) {
+		return m.openThemePalette(), nil
+	}
+	return m.broadcast(msg)
+}
+
+// handleChordTimeout handles the keys of a chord left unfinished as
+// ordinary key presses.
+func (m rootModel) handleChordTimeout(msg keys.ChordTimeoutMsg) (tea.Model, tea.Cmd) {
+	var replay []tea.KeyPressMsg
+	m.chords, replay = m.chords.Timeout(msg)
+	m = m.refreshWhichKey()
+	return m.dispatchKeys(replay)
+}
+
+// refreshWhichKey rebuilds the which-key popup for the pending chord and
+// the help bar, which lists the chord's continuations meanwhile.
+func (m rootModel) refreshWhichKey() rootModel {
+	pending := m.chords.Pending()
+	if pending == nil {
+		m.whichKey = whichkey.Model{}
+	} else {
+		next := keys.Continuations(pending, m.combinedKeys().Bindings())
+		m.whichKey = whichkey.New(pending, next, m.themeMgr.State().Palette)
+	}
+	m.bodyH = m.bodyHeight()
+	return m
+}
+
 func (m rootModel) handleRandomTheme() (tea.Model, tea.Cmd) {
 	themes := theme.AvailableThemes()
 	if len(themes) == 0 {
@@ -102,52 +289,79 @@ func (m rootModel) handleRandomTheme() (tea.Model, tea.Cmd) {
 		candidates = themes
 	}
 
-	newTheme := candidates[rand.Intn(len(candidates))]
-	m.cfg.UI.ThemeName = newTheme
+	return m.setTheme(candidates[rand.Intn(len(candidates))])
+}
+
+// setTheme switches to the named theme for this session and reports it.
+func (m rootModel) setTheme(name string) (tea.Model, tea.Cmd) {
+	m.cfg.UI.ThemeName = name
 	return m, tea.Batch(
-		status.SetInfo("Theme: "+newTheme, 0),
-		m.themeMgr.SetThemeName(newTheme),
+		status.SetInfo(i18n.T("status.theme", name), 0),
+		m.themeMgr.SetThemeName(name),
 	)
 }
 
 func (m rootModel) handleModalShow(msg modal.ShowMsg) (tea.Model, tea.Cmd) {
 	m.modal = modal.New(msg, m.themeMgr.State().Palette)
-	return m, nil
+	return m.startModalOpen()
 }
 
 func (m rootModel) handleModalDismiss(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, animCmd := m.startModalClose(m.modal.View().Content)
 	m.modal = modal.Model{}
 	updated, cmd := m.current.Update(msg)
 	if s, ok := updated.(screens.Screen); ok {
 		m.current = s
 	}
-	return m, cmd
+	return m, tea.Batch(cmd, animCmd)
 }
 
+// handleTaskErr reports a failed task and routes it to the screens, so the
+// one that started it can recover (an editor can save again, for example).
+// The report here is the only one; screens do not set their own. A task
+// cancelled because its screen was left is not reported.
 func (m rootModel) handleTaskErr(msg task.ErrMsg) (tea.Model, tea.Cmd) {
-	return m, status.SetError(msg.Err.Error(), 0)
+	model, cmd := m.broadcast(msg)
+	if errors.Is(msg.Err, context.Canceled) {
+		return model, cmd
+	}
+	return model, tea.Batch(status.SetError(msg.Err.Error(), 0), cmd, m.notifier.TaskFinished(msg))
+}
+
+// handleTaskDone routes a successful task result to the screens and lets
+// the notifier report it if it ran long.
+func (m rootModel) handleTaskDone(msg task.Finished) (tea.Model, tea.Cmd) {
+	model, cmd := m.broadcast(msg)
+	return model, tea.Batch(cmd, m.notifier.TaskFinished(msg))
 }
 
 func (m rootModel) handleWelcomeDone(_ screens.WelcomeDoneMsg) (tea.Model, tea.Cmd) {
 	m.cfg.ConfigVersion = config.CurrentConfigVersion
+	var secretsCmd tea.Cmd
 	if m.configPath != "" {
-		if err := config.Save(&m.cfg, m.configPath); err != nil {
-			return m, status.SetError("Save failed: "+err.Error(), 0)
+		var err error
+		if secretsCmd, err = m.saveConfig(); err != nil {
+			return m, status.SetError(i18n.T("status.saveFailed", err), 0)
 		}
 	}
-	if m.stack.Len() > 0 {
-		m.current = m.stack.Pop()
-	}
+	m = m.leave()
 	m.bodyH = m.bodyHeight()
 	if m.configPath != "" {
-		return m, status.SetSuccess("Welcome! Config saved.", 0)
+		return m, tea.Batch(status.SetSuccess(i18n.T("status.welcomeSaved"), 0), secretsCmd)
 	}
-	return m, status.SetSuccess("Welcome!", 0)
+	return m, status.SetSuccess(i18n.T("status.welcome"), 0)
 }
 
 func (m rootModel) handleNavigate(msg NavigateMsg) (tea.Model, tea.Cmd) {
 	m.stack.Push(m.current)
-	m.current = msg.Screen
+	m = m.mount(msg.Screen)
+	m, animCmd := m.startTransition(1)
+	return m, tea.Batch(m.current.Init(), animCmd)
+}
+
+// mount makes screen the current screen, sized and themed for the layout.
+func (m rootModel) mount(screen screens.Screen) rootModel {
+	m.current = screen
 	// Recompute bodyH: the incoming screen may have different key bindings,
 	// which changes help height and therefore available body height.
 	m.bodyH = m.bodyHeight()
@@ -160,13 +374,45 @@ func (m rootModel) handleNavigate(msg NavigateMsg) (tea.Model, tea.Cmd) {
 	if t, ok := m.current.(theme.Themeable); ok {
 		t.ApplyTheme(m.themeMgr.State())
 	}
-	return m, m.current.Init()
+	return m
+}
+
+// leave closes the current screen and returns to the one below it, if any.
+func (m rootModel) leave() rootModel {
+	if m.stack.Len() == 0 {
+		return m
+	}
+	if c, ok := m.current.(screens.Closer); ok {
+		c.Close()
+	}
+	m.current = m.stack.Pop()
+	return m
 }
 
 func (m rootModel) handleMenuSelection(msg menu.SelectionMsg) (tea.Model, tea.Cmd) {
+	if name, ok := strings.CutPrefix(msg.Item.ScreenID(), screens.ProfileItemPrefix); ok {
+		return m.handleProfileSwitch(name)
+	}
 	switch msg.Item.ScreenID() {
 	case "settings":
 		return m.Update(NavigateMsg{Screen: screens.NewSettings(m.cfg)})
+	case "profiles":
+		if m.configPath == "" {
+			return m, status.SetWarning(i18n.T("status.profilesNeedFile"), 0)
+		}
+		names, err := config.ListProfiles(m.configPath)
+		if err != nil {
+			return m, status.SetError(err.Error(), 0)
+		}
+		return m.Update(NavigateMsg{Screen: screens.NewProfiles(names, m.cfg.Profile)})
+	case "notes":
+		return m.openNotes(msg.Item.Title())
+	case "keybindings":
+		return m.Update(NavigateMsg{Screen: screens.NewKeyBindings()})
+	case "themes":
+		return m.Update(NavigateMsg{Screen: screens.NewThemeInspector(m.themeMgr.State().Name)})
+	case "messages":
+		return m.Update(NavigateMsg{Screen: screens.NewMessages(m.statusbar.History())})
 	default:
 		detail := screens.NewDetail(
 			msg.Item.Title(), msg.Item.Description(), msg.Item.ScreenID(), m.ctx,
@@ -176,48 +422,454 @@ func (m rootModel) handleMenuSelection(msg menu.SelectionMsg) (tea.Model, tea.Cm
 }
 
 func (m rootModel) handleSettingsSaved(msg screens.SettingsSavedMsg) (tea.Model, tea.Cmd) {
-	themeChanged := m.cfg.UI.ThemeName != msg.Cfg.UI.ThemeName
+	// Keep the settings screen open on values that would fail the next load.
+	if err := msg.Cfg.Validate(); err != nil {
+		return m, status.SetError(err.Error(), 0)
+	}
+	themeChanged := m.effectiveCfg().UI.ThemeName != msg.Cfg.UI.ThemeName
+	m.preview = nil
 	m.cfg = msg.Cfg
+	m = m.applyLanguage(m.cfg.UI.Language)
+	applyTimeFormat(m.cfg)
 
 	// Propagate new config to the header component. WithCfg handles
 	// clearing the banner when ShowBanner is disabled and re-rendering it
 	// when ShowBanner is newly enabled (using the cached theme state).
 	m.header = m.header.WithCfg(m.cfg)
+	m.statusbar = m.statusbar.WithCfg(m.cfg)
+	m.notifier = m.notifier.WithConfig(m.cfg.Notifications)
 
 	var saveCmd tea.Cmd
 	if m.configPath != "" {
-		if err := config.Save(&m.cfg, m.configPath); err != nil {
-			saveCmd = status.SetError("Save failed: "+err.Error(), 0)
+		if secretsCmd, err := m.saveConfig(); err != nil {
+			saveCmd = status.SetError(i18n.T("status.saveFailed", err), 0)
 		} else {
-			saveCmd = status.SetSuccess("Settings saved", 0)
+			saveCmd = tea.Batch(status.SetSuccess(i18n.T("status.settingsSaved"), 0), secretsCmd)
 		}
 	} else {
-		saveCmd = status.SetInfo("Settings applied (no config file)", 0)
+		saveCmd = status.SetInfo(i18n.T("status.settingsApplied"), 0)
 	}
 
+	cmds := []tea.Cmd{saveCmd, m.themeMgr.SetCompact(m.cfg.UI.CompactMode)}
 	if themeChanged {
-		if m.stack.Len() > 0 {
-			m.current = m.stack.Pop()
-		}
-		m.bodyH = m.bodyHeight()
-		return m, tea.Batch(saveCmd, m.themeMgr.SetThemeName(m.cfg.UI.ThemeName))
+		cmds = append(cmds, m.themeMgr.SetThemeName(m.cfg.UI.ThemeName))
 	}
 
-	if m.stack.Len() > 0 {
-		m.current = m.stack.Pop()
+	m = m.leave()
+	m.bodyH = m.bodyHeight()
+	return m, tea.Batch(cmds...)
+}
+
+// handleProfileSwitch loads the named profile (or the base config when name
+// is empty) and its secrets; handleProfileLoaded switches to it.
+func (m rootModel) handleProfileSwitch(name string) (tea.Model, tea.Cmd) {
+	cfg, err := config.LoadProfile(m.configPath, name)
+	if errors.Is(err, config.ErrConfigNotFound) {
+		cfg = config.DefaultConfig()
+	} else if err != nil {
+		return m, status.SetError(i18n.T("status.profileLoadFailed", err), 0)
+	}
+	return m, m.loadSecrets(switchProfileLabel, name, cfg)
+}
+
+// handleProfileLoaded records a loaded profile as the active profile and
+// applies it to the chrome, leaving the profiles screen.
+func (m rootModel) handleProfileLoaded(loaded loadedConfig) (tea.Model, tea.Cmd) {
+	if err := config.SetActiveProfile(m.configPath, loaded.profile); err != nil {
+		return m, status.SetError(i18n.T("status.profileSwitchFailed", err), 0)
+	}
+
+	m, applyCmd := m.applyConfig(*loaded.cfg)
+	m.profileErr = nil
+	m.secretsErr = loaded.secretsErr
+	if _, ok := m.current.(*screens.Profiles); ok {
+		m = m.leave()
 	}
 	m.bodyH = m.bodyHeight()
-	return m, saveCmd
+
+	label := loaded.profile
+	if label == "" {
+		label = i18n.T("status.baseConfig")
+	}
+	statusCmd := status.SetSuccess(i18n.T("status.profile", label), 0)
+	if loaded.secretsErr != nil {
+		statusCmd = secretsWarning(loaded.secretsErr)
+	}
+	return m, tea.Batch(statusCmd, applyCmd)
+}
+
+// loadedConfig is a config whose secrets loadSecrets has read.
+type loadedConfig struct {
+	profile    string // the profile cfg was loaded as; "" for the base config
+	cfg        *config.Config
+	secretsErr error // from config.LoadSecrets; cfg is usable regardless
+}
+
+// Labels of the tasks that load a config's secrets.
+const (
+	switchProfileLabel = "switch-profile"
+	reloadConfigLabel  = "reload-config"
+)
+
+// loadSecrets returns a task that fills cfg's secrets off the event loop:
+// the keyring may wait on an unlock prompt, and deriving the file store's
+// key takes a while. Secrets that cannot be read do not fail the task; as
+// at startup, they are reported in secretsErr and not saved over.
+func (m rootModel) loadSecrets(label, profile string, cfg *config.Config) tea.Cmd {
+	path := m.configPath
+	return task.Run(m.ctx, label, func(context.Context) (loadedConfig, error) {
+		err := config.LoadSecrets(cfg, config.NewSecretStore(path))
+		return loadedConfig{profile: profile, cfg: cfg, secretsErr: err}, nil
+	})
+}
+
+// handleConfigLoaded finishes the load a loadSecrets task was started for.
+func (m rootModel) handleConfigLoaded(msg task.DoneMsg[loadedConfig]) (tea.Model, tea.Cmd) {
+	switch msg.Label {
+	case switchProfileLabel:
+		return m.handleProfileLoaded(msg.Value)
+	case reloadConfigLabel:
+		return m.handleConfigReloaded(msg.Value)
+	}
+	return m, nil
+}
+
+// applyConfig makes cfg the saved config and updates the chrome from it.
+// The returned command applies its density and, if it changed, its theme.
+func (m rootModel) applyConfig(cfg config.Config) (rootModel, tea.Cmd) {
+	themeChanged := m.cfg.UI.ThemeName != cfg.UI.ThemeName
+	m.cfg = cfg
+	m.header = m.header.WithCfg(m.cfg)
+	m.statusbar = m.statusbar.WithCfg(m.cfg)
+	m.notifier = m.notifier.WithConfig(m.cfg.Notifications)
+	keysErr := applyKeyOverrides(m.cfg, m.configPath)
+	i18n.SetLanguage(m.cfg.UI.Language)
+	m = m.relocalize()
+	applyTimeFormat(m.cfg)
+	m.bodyH = m.bodyHeight()
+
+	cmds := []tea.Cmd{m.themeMgr.SetCompact(m.cfg.UI.CompactMode), keyWarning(keysErr)}
+	if themeChanged {
+		cmds = append(cmds, m.themeMgr.SetThemeName(m.cfg.UI.ThemeName))
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// notesFile is the scratch notes document, kept next to the config file.
+const notesFile = "notes.md"
+
+// openNotes opens the scratch notes in the built-in editor, creating the
+// file on first save.
+func (m rootModel) openNotes(title string) (tea.Model, tea.Cmd) {
+	if m.configPath == "" {
+		return m, status.SetWarning(i18n.T("status.notesNeedFile"), 0)
+	}
+	path := filepath.Join(filepath.Dir(m.configPath), notesFile)
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return m, status.SetError(i18n.T("status.notesLoadFailed", err), 0)
+	}
+	save := func(_ context.Context, content string) error {
+		return os.WriteFile(path, []byte(content), 0o644)
+	}
+	screen := screens.NewEditor(m.ctx, "notes", title, string(content), m.cfg.Editor, save)
+	return m.Update(NavigateMsg{Screen: screen})
+}
+
+// configEditID labels the external editor session for the config file.
+const configEditID = "config-file"
+
+// handleEditConfig opens the config file in the external editor. A config
+// that has never been saved is written first so there is a file to edit.
+func (m rootModel) handleEditConfig(_ screens.EditConfigMsg) (tea.Model, tea.Cmd) {
+	if m.configPath == "" {
+		return m, status.SetWarning(i18n.T("status.editNeedsFile"), 0)
+	}
+	var secretsCmd tea.Cmd
+	if _, err := os.Stat(m.configPath); errors.Is(err, fs.ErrNotExist) {
+		if secretsCmd, err = m.saveConfig(); err != nil {
+			return m, status.SetError(i18n.T("status.saveFailed", err), 0)
+		}
+	}
+	return m, tea.Batch(secretsCmd, extedit.Open(configEditID, m.configPath, m.effectiveCfg().Editor.EditorCommand))
+}
+
+// handleEdited reloads and validates the config file after it was edited
+// externally; handleConfigReloaded applies it once its secrets are loaded.
+// An invalid file is reported and the running config is kept. Other editor
+// sessions belong to the screens.
+func (m rootModel) handleEdited(msg extedit.EditedMsg) (tea.Model, tea.Cmd) {
+	if msg.ID != configEditID {
+		return m.broadcast(msg)
+	}
+	if msg.Err != nil {
+		return m, status.SetError(i18n.T("status.editorFailed", msg.Err), 0)
+	}
+	cfg, err := config.LoadProfile(m.configPath, m.cfg.Profile)
+	if err != nil {
+		return m, status.SetError(i18n.T("status.configInvalid", err), 0)
+	}
+	return m, m.loadSecrets(reloadConfigLabel, m.cfg.Profile, cfg)
+}
+
+// handleConfigReloaded applies a config reloaded after an external edit,
+// reopening settings with the new values.
+func (m rootModel) handleConfigReloaded(loaded loadedConfig) (tea.Model, tea.Cmd) {
+	// The file replaces any unsaved settings edits and their preview.
+	m.preview = nil
+	m, applyCmd := m.applyConfig(*loaded.cfg)
+	m.secretsErr = loaded.secretsErr
+	statusCmd := status.SetSuccess(i18n.T("status.configReloaded"), 0)
+	if loaded.secretsErr != nil {
+		statusCmd = secretsWarning(loaded.secretsErr)
+	}
+	cmds := []tea.Cmd{statusCmd, applyCmd}
+	if _, ok := m.current.(*screens.Settings); ok {
+		m = m.mount(screens.NewSettings(m.cfg))
+		cmds = append(cmds, m.current.Init())
+	}
+	return m, tea.Batch(cmds...)
 }
 
 func (m rootModel) handleBack(_ screens.BackMsg) (tea.Model, tea.Cmd) {
-	if m.stack.Len() > 0 {
-		m.current = m.stack.Pop()
+	// Leaving settings without saving reverts any live preview.
+	m, cmd := m.endPreview()
+	m = m.leave()
+	// An editor being left takes its unsaved changes with it.
+	m.statusbar = m.statusbar.WithDirty(false)
+	m.bodyH = m.bodyHeight()
+	m, animCmd := m.startTransition(-1)
+	return m, tea.Batch(cmd, animCmd)
+}
+
+// handleSettingsPreview renders the chrome from an unsaved config. m.cfg is
+// left untouched so the preview can be reverted by endPreview.
+func (m rootModel) handleSettingsPreview(msg screens.SettingsPreviewMsg) (tea.Model, tea.Cmd) {
+	themeChanged := m.effectiveCfg().UI.ThemeName != msg.Cfg.UI.ThemeName
+	cfg := msg.Cfg
+	m.preview = &cfg
+	m.header = m.header.WithCfg(cfg)
+	m = m.applyLanguage(cfg.UI.Language)
+	m.bodyH = m.bodyHeight()
+	densityCmd := m.themeMgr.SetCompact(cfg.UI.CompactMode)
+	if themeChanged {
+		return m, tea.Batch(densityCmd, m.themeMgr.SetThemeName(cfg.UI.ThemeName))
+	}
+	return m, densityCmd
+}
+
+// endPreview drops the settings preview and restores the chrome from m.cfg.
+func (m rootModel) endPreview() (rootModel, tea.Cmd) {
+	if m.preview == nil {
+		return m, nil
+	}
+	themeChanged := m.preview.UI.ThemeName != m.cfg.UI.ThemeName
+	m.preview = nil
+	m.header = m.header.WithCfg(m.cfg)
+	m = m.applyLanguage(m.cfg.UI.Language)
+	densityCmd := m.themeMgr.SetCompact(m.cfg.UI.CompactMode)
+	if themeChanged {
+		return m, tea.Batch(densityCmd, m.themeMgr.SetThemeName(m.cfg.UI.ThemeName))
+	}
+	return m, densityCmd
+}
+
+// applyLanguage switches the UI language and relocalizes the UI, so the
+// change shows without a restart.
+func (m rootModel) applyLanguage(lang string) rootModel {
+	if !i18n.SetLanguage(lang) {
+		return m
+	}
+	return m.relocalize()
+}
+
+// relocalize rebuilds the translated text and key bindings cached by the
+// global keys, the statusbar, the toasts and every screen, including those
+// further down the stack.
+func (m rootModel) relocalize() rootModel {
+	m.keys = keys.DefaultGlobalKeyMap()
+	m.statusbar = m.statusbar.WithLanguage()
+	m.toasts = m.toasts.WithLanguage()
+	for _, s := range append([]screens.Screen{m.current}, m.stack.screens...) {
+		if l, ok := s.(i18n.Localizable); ok {
+			l.ApplyLanguage()
+		}
 	}
 	m.bodyH = m.bodyHeight()
-	return m, nil
+	return m.refreshFullHelp()
+}
+
+// applyKeyOverrides installs the key-binding overrides from the config's
+// keys section and from keys.json next to configPath, which wins. Unknown
+// bindings and an unreadable keys.json are reported; the rest still apply.
+func applyKeyOverrides(cfg config.Config, configPath string) error {
+	layers := []keys.Overrides{cfg.Keys}
+	var loadErr error
+	if configPath != "" {
+		file, err := keys.LoadFile(config.KeysPath(configPath))
+		loadErr = err
+		layers = append(layers, file)
+	}
+	return errors.Join(loadErr, keys.SetOverrides(layers...))
+}
+
+// loadThemes registers the user themes in the themes directory next to
+// configPath in place of those loaded before, returning their names and the
+// problems found with them.
+func loadThemes(configPath string) (names, warnings []string) {
+	if configPath == "" {
+		return nil, nil
+	}
+	return theme.LoadThemes(config.ThemesDir(configPath))
+}
+
+// themeWarning reports the first problem found with the user themes, and
+// how many more there are, in a toast.
+func themeWarning(warnings []string) tea.Cmd {
+	if len(warnings) == 0 {
+		return nil
+	}
+	body := warnings[0]
+	if rest := len(warnings) - 1; rest > 0 {
+		body += "\n" + i18n.T("search.more", rest)
+	}
+	return toast.Warning(i18n.T("toast.themeWarnings"), body, 0)
+}
+
+// handleReloadThemes reloads the user themes and rebuilds the current
+// palette, which picks up changes to a user theme in use.
+func (m rootModel) handleReloadThemes() (tea.Model, tea.Cmd) {
+	names, warnings := loadThemes(m.configPath)
+	report := themeWarning(warnings)
+	if report == nil {
+		report = status.SetSuccess(i18n.N("status.themesReloaded", len(names)), 0)
+	}
+	return m, tea.Batch(m.themeMgr.Reload(), report)
+}
+
+// keyWarning reports a problem with the key-binding overrides, or else the
+// first conflict they cause, on the status line.
+func keyWarning(err error) tea.Cmd {
+	if err != nil {
+		return status.SetWarning(i18n.T("status.keysInvalid", err), 0)
+	}
+	if conflicts := keys.Conflicts(); len(conflicts) > 0 {
+		c := conflicts[0]
+		return status.SetWarning(i18n.T("status.keyConflict", c.Key, strings.Join(c.IDs, ", ")), 0)
+	}
+	return nil
+}
+
+// checkProfile reports why cfg's active profile cannot be loaded, or nil.
+// The config was then built without the overlay; see loadConfig in main.
+func checkProfile(cfg config.Config, configPath string) error {
+	if cfg.Profile == "" || configPath == "" {
+		return nil
+	}
+	_, err := config.LoadProfile(configPath, cfg.Profile)
+	return err
+}
+
+// profileWarning reports an active profile that could not be loaded.
+func profileWarning(name string, err error) tea.Cmd {
+	if err == nil {
+		return nil
+	}
+	return status.SetWarning(i18n.T("status.profileUnavailable", name, err), 0)
+}
+
+// secretsWarning reports secrets that could not be loaded.
+func secretsWarning(err error) tea.Cmd {
+	if err == nil {
+		return nil
+	}
+	return status.SetWarning(i18n.T("status.secretsUnavailable", err), 0)
 }
 
+// handleKeyBindingsChanged applies the overrides edited on the key-bindings
+// screen and saves them to keys.json. A binding reset there is written with
+// its default keys if the config's keys section overrides it, so the reset
+// holds on the next launch.
+func (m rootModel) handleKeyBindingsChanged(msg screens.KeyBindingsChangedMsg) (tea.Model, tea.Cmd) {
+	file := msg.Overrides
+	for scope, names := range m.cfg.Keys {
+		for name := range names {
+			d, ok := keys.Lookup(scope + "." + name)
+			if _, set := file[scope][name]; set || !ok {
+				continue
+			}
+			if file[scope] == nil {
+				file[scope] = map[string][]string{}
+			}
+			file[scope][name] = d.Keys
+		}
+	}
+	err := keys.SetOverrides(file)
+	m = m.relocalize()
+	warn := keyWarning(err)
+	if m.configPath == "" {
+		return m, warn
+	}
+	if err := keys.SaveFile(config.KeysPath(m.configPath), file); err != nil {
+		return m, status.SetError(i18n.T("status.keysSaveFailed", err), 0)
+	}
+	if warn != nil {
+		return m, warn
+	}
+	return m, status.SetSuccess(i18n.T("status.keysSaved"), 0)
+}
+
+// applyTimeFormat installs the timestamp formatter for cfg. An invalid
+// layout or zone keeps the previous formatter; Validate reports it on load
+// and save.
+func applyTimeFormat(cfg config.Config) {
+	if f, err := timefmt.FromConfig(cfg.UI); err == nil {
+		timefmt.Set(f)
+	}
+}
+
+// effectiveCfg returns the config the chrome is rendered from: the settings
+// preview while one is active, otherwise the saved config.
+func (m rootModel) effectiveCfg() config.Config {
+	if m.preview != nil {
+		return *m.preview
+	}
+	return m.cfg
+}
+
+// saveConfig persists m.cfg to m.configPath. While a profile is active only
+// the values that differ from the base config are written, to the profile's
+// overlay, so the base config stays shared between profiles.
+// cfg_secret fields are written to the secret store, never to the JSON file,
+// by the returned task: deriving the store's key can take a while. Secrets
+// that could not be loaded are left as stored.
+// Nothing is saved while the active profile could not be loaded, so its
+// overlay is not overwritten with the base config.
+func (m rootModel) saveConfig() (tea.Cmd, error) {
+	if m.profileErr != nil {
+		return nil, m.profileErr
+	}
+	var err error
+	if m.cfg.Profile != "" {
+		err = config.SaveProfile(&m.cfg, m.configPath)
+	} else {
+		err = config.Save(&m.cfg, m.configPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	cfg, path, unread := m.cfg, m.configPath, config.UnreadSecrets(m.secretsErr)
+	return task.Run(m.ctx, saveSecretsLabel, func(context.Context) (struct{}, error) {
+		if err := config.SaveSecrets(&cfg, config.NewSecretStore(path), unread...); err != nil {
+			return struct{}{}, errors.New(i18n.T("status.saveFailed", err))
+		}
+		return struct{}{}, nil
+	}), nil
+}
+
+// saveSecretsLabel labels the task that writes the secrets of a saved config.
+const saveSecretsLabel = "save-secrets"
+
 // broadcast sends msg to all chrome components (header, statusbar) and the
 // current screen, collecting commands via tea.Batch. It is the fallback for
 // all messages not explicitly handled by the root Update switch — this ensures
@@ -22,6 +22,7 @@ type Model struct {
 	descSty    lipgloss.Style
 	width      int
 	themeState theme.State // cached for banner re-renders after config changes
+	phase      float64     // banner gradient shift, a fraction of a full cycle
 }
 
 // New creates a header Model from the given config.
@@ -39,7 +40,21 @@ func (m Model) WithCfg(cfg config.Config) Model {
 	if !cfg.UI.ShowBanner {
 		m.banner = ""
 	} else if m.banner == "" && m.themeState.Palette.Primary != nil {
-		m.banner = renderBannerStr(cfg, m.themeState)
+		m.banner = renderBannerStr(cfg, m.themeState, m.phase)
+	}
+	return m
+}
+
+// WithPhase returns a new Model whose banner gradient is shifted by phase, a
+// fraction of a full cycle (0 and 1 are the resting gradient). The root
+// model steps it from a frame loop to animate the banner.
+func (m Model) WithPhase(phase float64) Model {
+	if phase == m.phase {
+		return m
+	}
+	m.phase = phase
+	if m.cfg.UI.ShowBanner && m.themeState.Palette.Primary != nil {
+		m.banner = renderBannerStr(m.cfg, m.themeState, phase)
 	}
 	return m
 }
@@ -54,10 +69,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.themeState = msg.State
 		p := msg.State.Palette
 
-		m.headerSty = lipgloss.NewStyle().
-			Padding(2).
-			MarginBottom(0).
-			PaddingBottom(3)
+		m.headerSty = theme.HeaderStyle(msg.State.Compact)
 
 		m.titleSty = lipgloss.NewStyle().
 			Bold(true).
@@ -71,7 +83,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			MarginLeft(3)
 
 		if m.cfg.UI.ShowBanner {
-			m.banner = renderBannerStr(m.cfg, msg.State)
+			m.banner = renderBannerStr(m.cfg, msg.State, m.phase)
 		} else {
 			m.banner = ""
 		}
@@ -105,8 +117,10 @@ func (m Model) Height() int {
 // renderBannerStr renders the ASCII art banner at a fixed large width and
 // returns the result. Using a large width lets lipgloss.Width(banner) reflect
 // the font's true natural width, which View uses to decide whether the terminal
-// is wide enough to display it.
-func renderBannerStr(cfg config.Config, state theme.State) string {
+// is wide enough to display it. phase shifts the themed gradient; see
+// banner.Gradient.Shifted. The colors are reduced to the terminal's profile,
+// and a banner without colors is drawn bold.
+func renderBannerStr(cfg config.Config, state theme.State, phase float64) string {
 	p := state.Palette
 	if p.Primary == nil {
 		p = theme.NewPalette(cfg.UI.ThemeName, state.IsDark)
@@ -116,10 +130,14 @@ func renderBannerStr(cfg config.Config, state theme.State) string {
 		Font:          "larry3d",
 		Width:         100,
 		Justification: 0,
-		Gradient:      banner.GradientThemed(p.Primary, p.Secondary),
+		Gradient:      banner.GradientThemed(p.Primary, p.Secondary).Shifted(phase),
+		Profile:       state.Profile,
 	})
 	if err != nil {
 		return cfg.App.Name
 	}
+	if p.Monochrome() {
+		return lipgloss.NewStyle().Bold(true).Render(b)
+	}
 	return b
 }
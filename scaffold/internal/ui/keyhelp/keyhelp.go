@@ -0,0 +1,134 @@
+// Package keyhelp provides the full-help overlay: every key binding of the
+// global key map and the current screen, grouped into titled sections and
+// shown in a scrollable dialog that rootModel draws over the screen.
+package keyhelp
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/theme"
+)
+
+// Section is a titled set of binding groups, as returned by FullHelp.
+type Section struct {
+	Title  string
+	Groups [][]key.Binding
+}
+
+// styles holds the overlay's themed styles.
+type styles struct {
+	modal   theme.ModalStyles
+	section lipgloss.Style
+	key     lipgloss.Style
+	desc    lipgloss.Style
+}
+
+// dialogWidth is the overlay's width including border and padding.
+const dialogWidth = 56
+
+// Model is the full-help overlay. The zero value is invisible.
+type Model struct {
+	vp      viewport.Model
+	styles  styles
+	visible bool
+}
+
+// New creates a visible overlay listing sections, sized to fit a terminal
+// of height h. Content taller than the dialog scrolls.
+func New(sections []Section, p theme.Palette, h int) Model {
+	m := Model{
+		visible: true,
+		styles: styles{
+			modal:   theme.NewModalStylesFromPalette(p),
+			section: lipgloss.NewStyle().Bold(true).Foreground(p.Secondary),
+			key:     lipgloss.NewStyle().Foreground(p.Primary),
+			desc:    lipgloss.NewStyle().Foreground(p.ForegroundMuted),
+		},
+	}
+	m.styles.modal.Dialog = m.styles.modal.Dialog.Width(dialogWidth)
+
+	content := m.render(sections)
+	// Title, hint and the blank lines after the title and before the hint.
+	chrome := m.styles.modal.Dialog.GetVerticalFrameSize() + 4
+	m.vp = viewport.New(
+		viewport.WithWidth(dialogWidth-m.styles.modal.Dialog.GetHorizontalFrameSize()),
+		viewport.WithHeight(max(min(lipgloss.Height(content), h-chrome), 1)),
+	)
+	m.vp.SetContent(content)
+	return m
+}
+
+// Visible reports whether the overlay is displayed.
+func (m Model) Visible() bool { return m.visible }
+
+// Update scrolls the overlay with the arrow, page and vim keys and the
+// mouse wheel. Closing it is left to the caller.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.vp, cmd = m.vp.Update(msg)
+	return m, cmd
+}
+
+// ScrollOffset returns how many content lines are scrolled out of view.
+func (m Model) ScrollOffset() int {
+	return m.vp.YOffset()
+}
+
+// View renders the dialog.
+func (m Model) View() tea.View {
+	hint := i18n.T("help.hint")
+	if !m.vp.AtTop() || !m.vp.AtBottom() {
+		hint = i18n.T("help.hintScroll")
+	}
+	inner := lipgloss.JoinVertical(lipgloss.Left,
+		m.styles.modal.Title.Render(i18n.T("help.title")),
+		"",
+		m.vp.View(),
+		"",
+		m.styles.modal.Hint.Render(hint),
+	)
+	return tea.NewView(m.styles.modal.Dialog.Render(inner))
+}
+
+// render lists each section under its title, one binding per line, with a
+// blank line between groups. Key columns are aligned across the overlay.
+func (m Model) render(sections []Section) string {
+	keyW := 0
+	for _, s := range sections {
+		for _, g := range s.Groups {
+			for _, b := range g {
+				if b.Enabled() {
+					keyW = max(keyW, lipgloss.Width(b.Help().Key))
+				}
+			}
+		}
+	}
+	keySty := m.styles.key.Width(keyW + 2)
+
+	var blocks []string
+	for _, s := range sections {
+		var groups []string
+		for _, g := range s.Groups {
+			var lines []string
+			for _, b := range g {
+				if !b.Enabled() || b.Help().Key == "" {
+					continue
+				}
+				lines = append(lines, keySty.Render(b.Help().Key)+m.styles.desc.Render(b.Help().Desc))
+			}
+			if len(lines) > 0 {
+				groups = append(groups, strings.Join(lines, "\n"))
+			}
+		}
+		if len(groups) > 0 {
+			blocks = append(blocks, m.styles.section.Render(s.Title)+"\n"+strings.Join(groups, "\n\n"))
+		}
+	}
+	return strings.Join(blocks, "\n\n")
+}
@@ -0,0 +1,139 @@
+package keys
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+)
+
+// ChordTimeout is how long a partly typed chord waits for its next key.
+const ChordTimeout = time.Second
+
+// ChordMsg reports a completed chord. Its String is the chord as bound,
+// e.g. "g g", so key.Matches matches it against bindings like a key press.
+type ChordMsg struct {
+	Keys string
+}
+
+// String implements fmt.Stringer.
+func (c ChordMsg) String() string { return c.Keys }
+
+// Matches reports whether msg is a key press or a completed chord that
+// matches one of bindings, for bindings such as "g g" that may be either.
+func Matches(msg tea.Msg, bindings ...key.Binding) bool {
+	switch msg := msg.(type) {
+	case tea.KeyPressMsg:
+		return key.Matches(msg, bindings...)
+	case ChordMsg:
+		return key.Matches(msg, bindings...)
+	}
+	return false
+}
+
+// ChordTimeoutMsg ends a pending chord that got no further key in time.
+type ChordTimeoutMsg struct {
+	id int
+}
+
+// Steps splits a bound key into its chord steps: "ctrl+x ctrl+s" has two,
+// "q" has one.
+func Steps(k string) []string {
+	return strings.Fields(k)
+}
+
+// IsChord reports whether a bound key takes more than one key press.
+func IsChord(k string) bool {
+	return len(Steps(k)) > 1
+}
+
+// Chords tracks a partly typed chord, such as the leader "ctrl+x" of
+// "ctrl+x t". The zero value is idle.
+type Chords struct {
+	pending []tea.KeyPressMsg
+	id      int // identifies the pending chord for its timeout
+}
+
+// Pending returns the keys typed so far, or nil when no chord is pending.
+func (c Chords) Pending() []string {
+	if len(c.pending) == 0 {
+		return nil
+	}
+	steps := make([]string, len(c.pending))
+	for i, p := range c.pending {
+		steps[i] = p.String()
+	}
+	return steps
+}
+
+// Feed offers msg to the chords bound in bindings. A key that starts or
+// continues a chord is held and a timeout scheduled; one that completes a
+// chord yields a command sending its ChordMsg; esc cancels a pending chord.
+// Otherwise the returned keys are to be handled as ordinary key presses:
+// msg alone, or after the pending keys it failed to continue.
+func (c Chords) Feed(msg tea.KeyPressMsg, bindings []key.Binding) (Chords, tea.Cmd, []tea.KeyPressMsg) {
+	if len(c.pending) > 0 && msg.String() == "esc" {
+		return Chords{id: c.id}, nil, nil
+	}
+	typed := append(c.Pending(), msg.String())
+	prefix := false
+	for _, b := range bindings {
+		if !b.Enabled() {
+			continue
+		}
+		for _, k := range b.Keys() {
+			steps := Steps(k)
+			if len(steps) < 2 || len(steps) < len(typed) || !slices.Equal(steps[:len(typed)], typed) {
+				continue
+			}
+			if len(steps) == len(typed) {
+				return Chords{id: c.id}, func() tea.Msg { return ChordMsg{Keys: k} }, nil
+			}
+			prefix = true
+		}
+	}
+	if !prefix {
+		return Chords{id: c.id}, nil, append(slices.Clip(c.pending), msg)
+	}
+	c.pending = append(slices.Clip(c.pending), msg)
+	c.id++
+	id := c.id
+	return c, tea.Tick(ChordTimeout, func(time.Time) tea.Msg { return ChordTimeoutMsg{id: id} }), nil
+}
+
+// Timeout ends the pending chord if msg belongs to it, returning its keys
+// to be handled as ordinary key presses.
+func (c Chords) Timeout(msg ChordTimeoutMsg) (Chords, []tea.KeyPressMsg) {
+	if msg.id != c.id || len(c.pending) == 0 {
+		return c, nil
+	}
+	return Chords{id: c.id}, c.pending
+}
+
+// Continuations returns a binding per way to finish a chord starting with
+// pending, keyed by the remaining steps and described like the chord's
+// binding. Bindings hidden from help are left out.
+func Continuations(pending []string, bindings []key.Binding) []key.Binding {
+	var next []key.Binding
+	seen := map[string]bool{}
+	for _, b := range bindings {
+		if !b.Enabled() || b.Help().Desc == "" {
+			continue
+		}
+		for _, k := range b.Keys() {
+			steps := Steps(k)
+			if len(steps) <= len(pending) || !slices.Equal(steps[:len(pending)], pending) {
+				continue
+			}
+			rest := strings.Join(steps[len(pending):], " ")
+			if seen[rest] {
+				continue
+			}
+			seen[rest] = true
+			next = append(next, key.NewBinding(key.WithKeys(k), key.WithHelp(rest, b.Help().Desc)))
+		}
+	}
+	return next
+}
@@ -0,0 +1,97 @@
+package keys
+
+import (
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func chordBindings() []key.Binding {
+	return []key.Binding{
+		key.NewBinding(key.WithKeys("g g", "home"), key.WithHelp("g g", "top")),
+		key.NewBinding(key.WithKeys("ctrl+x ctrl+s"), key.WithHelp("ctrl+x ctrl+s", "save")),
+		key.NewBinding(key.WithKeys("ctrl+x ctrl+c"), key.WithHelp("ctrl+x ctrl+c", "quit")),
+		key.NewBinding(key.WithKeys("q")),
+	}
+}
+
+func press(t *testing.T, name string) tea.KeyPressMsg {
+	t.Helper()
+	msg, ok := Press(name)
+	require.True(t, ok, name)
+	return msg
+}
+
+func TestChords_CompletesChord(t *testing.T) {
+	var c Chords
+	c, cmd, replay := c.Feed(press(t, "ctrl+x"), chordBindings())
+	assert.Nil(t, replay)
+	assert.NotNil(t, cmd, "a pending chord schedules its timeout")
+	assert.Equal(t, []string{"ctrl+x"}, c.Pending())
+
+	c, cmd, replay = c.Feed(press(t, "ctrl+s"), chordBindings())
+	assert.Nil(t, replay)
+	assert.Nil(t, c.Pending())
+	require.NotNil(t, cmd)
+	msg := cmd().(ChordMsg)
+	assert.Equal(t, "ctrl+x ctrl+s", msg.Keys)
+	assert.True(t, key.Matches(msg, chordBindings()[1]))
+}
+
+func TestChords_PassesOtherKeysThrough(t *testing.T) {
+	var c Chords
+	c, cmd, replay := c.Feed(press(t, "q"), chordBindings())
+	assert.Nil(t, cmd)
+	assert.Equal(t, []tea.KeyPressMsg{press(t, "q")}, replay)
+
+	c, _, _ = c.Feed(press(t, "g"), chordBindings())
+	c, cmd, replay = c.Feed(press(t, "q"), chordBindings())
+	assert.Nil(t, cmd)
+	assert.Equal(t, []tea.KeyPressMsg{press(t, "g"), press(t, "q")}, replay, "a broken chord replays its keys")
+	assert.Nil(t, c.Pending())
+}
+
+func TestChords_EscCancels(t *testing.T) {
+	var c Chords
+	c, _, _ = c.Feed(press(t, "g"), chordBindings())
+	c, cmd, replay := c.Feed(press(t, "esc"), chordBindings())
+	assert.Nil(t, cmd)
+	assert.Nil(t, replay)
+	assert.Nil(t, c.Pending())
+}
+
+func TestChords_TimeoutReplaysPendingKeys(t *testing.T) {
+	var c Chords
+	c, _, _ = c.Feed(press(t, "g"), chordBindings())
+	stale := ChordTimeoutMsg{id: c.id - 1}
+	c, replay := c.Timeout(stale)
+	assert.Nil(t, replay, "a timeout of an earlier chord is ignored")
+
+	c, replay = c.Timeout(ChordTimeoutMsg{id: c.id})
+	assert.Equal(t, []tea.KeyPressMsg{press(t, "g")}, replay)
+	assert.Nil(t, c.Pending())
+}
+
+func TestContinuations_ListsRemainingSteps(t *testing.T) {
+	next := Continuations([]string{"ctrl+x"}, chordBindings())
+	require.Len(t, next, 2)
+	assert.Equal(t, "ctrl+s", next[0].Help().Key)
+	assert.Equal(t, "save", next[0].Help().Desc)
+	assert.Equal(t, []string{"ctrl+x ctrl+s"}, next[0].Keys())
+	assert.Equal(t, "ctrl+c", next[1].Help().Key)
+}
+
+func TestRegistry_ChordPrefixConflicts(t *testing.T) {
+	r := NewRegistry()
+	r.Register(
+		Def{ID: "list.top", Keys: []string{"g g"}},
+		Def{ID: "list.go", Keys: []string{"g"}},
+		Def{ID: "list.save", Keys: []string{"ctrl+x ctrl+s"}},
+		Def{ID: "list.quit", Keys: []string{"ctrl+x ctrl+c"}},
+	)
+	assert.Equal(t, []Conflict{{Key: "g", IDs: []string{"list.go", "list.top"}}}, r.Conflicts(),
+		"chords sharing a prefix don't conflict; a key that starts a chord does")
+}
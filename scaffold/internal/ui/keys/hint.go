@@ -0,0 +1,38 @@
+package keys
+
+import (
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/help"
+	"charm.land/bubbles/v2/key"
+)
+
+// Hint renders bindings as an unstyled one-line key hint, such as
+// "↑/↓ select • enter run", from their current keys, so hints follow
+// rebinding. Disabled bindings are left out.
+func Hint(bindings ...key.Binding) string {
+	h := help.New()
+	h.Styles = help.Styles{}
+	return h.ShortHelpView(bindings)
+}
+
+// Group joins bindings into one hint entry described by desc, as in
+// "↑/↓ select" for separate up and down bindings. It is disabled when
+// every binding is.
+func Group(desc string, bindings ...key.Binding) key.Binding {
+	var ks, labels []string
+	for _, b := range bindings {
+		if !b.Enabled() {
+			continue
+		}
+		ks = append(ks, b.Keys()...)
+		if l := b.Help().Key; l != "" && !slices.Contains(labels, l) {
+			labels = append(labels, l)
+		}
+	}
+	if len(ks) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+	return key.NewBinding(key.WithKeys(ks...), key.WithHelp(strings.Join(labels, "/"), desc))
+}
@@ -1,4 +1,6 @@
-// Package keys provides global key bindings for the TUI.
+// Package keys provides the global key bindings for the TUI, the registry
+// through which every component's bindings can be rebound, and chords:
+// bindings such as "g g" or "ctrl+x ctrl+s" that take several key presses.
 package keys
 
 import "charm.land/bubbles/v2/key"
@@ -7,32 +9,49 @@ import "charm.land/bubbles/v2/key"
 type GlobalKeyMap struct {
 	Quit        key.Binding
 	Back        key.Binding
+	Help        key.Binding // toggles the full-help overlay
+	Palette     key.Binding // opens the command palette
+	Themes      key.Binding // opens the command palette on the themes
 	RandomTheme key.Binding // hidden
 }
 
-// DefaultGlobalKeyMap returns the default global key bindings.
+func init() {
+	Register(
+		Def{ID: "global.quit", Keys: []string{"q", "ctrl+c"}, Help: "q/ctrl+c", Desc: "keys.quit"},
+		Def{ID: "global.back", Keys: []string{"esc"}, Desc: "keys.back"},
+		Def{ID: "global.help", Keys: []string{"?"}, Desc: "keys.help"},
+		Def{ID: "global.palette", Keys: []string{"ctrl+p"}, Desc: "keys.palette"},
+		// ctrl+x is the leader: unlike space, it means nothing else on any
+		// screen, so no key is held back waiting for a chord.
+		Def{ID: "global.themes", Keys: []string{"ctrl+x t"}, Desc: "keys.themes"},
+		Def{ID: "global.randomTheme", Keys: []string{"ctrl+t"}},
+	)
+}
+
+// DefaultGlobalKeyMap returns the global key bindings, with user overrides
+// applied and help text in the current language.
 func DefaultGlobalKeyMap() GlobalKeyMap {
 	return GlobalKeyMap{
-		Quit: key.NewBinding(
-			key.WithKeys("q", "ctrl+c"),
-			key.WithHelp("q/ctrl+c", "quit"),
-		),
-		Back: key.NewBinding(
-			key.WithKeys("esc"),
-			key.WithHelp("esc", "back"),
-		),
-		RandomTheme: key.NewBinding(
-			key.WithKeys("ctrl+t"),
-		),
+		Quit:        Bind("global.quit"),
+		Back:        Bind("global.back"),
+		Help:        Bind("global.help"),
+		Palette:     Bind("global.palette"),
+		Themes:      Bind("global.themes"),
+		RandomTheme: Bind("global.randomTheme"),
 	}
 }
 
 // ShortHelp returns a slice of bindings for short help view.
 func (k GlobalKeyMap) ShortHelp() []key.Binding {
-	return []key.Binding{k.Back, k.Quit}
+	return []key.Binding{k.Back, k.Help, k.Quit}
 }
 
 // FullHelp returns grouped bindings for full help view.
 func (k GlobalKeyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Back, k.Quit}}
+	return [][]key.Binding{{k.Back, k.Help, k.Palette, k.Themes, k.Quit}}
+}
+
+// Bindings returns every global binding, hidden ones included.
+func (k GlobalKeyMap) Bindings() []key.Binding {
+	return []key.Binding{k.Quit, k.Back, k.Help, k.Palette, k.Themes, k.RandomTheme}
 }
@@ -0,0 +1,68 @@
+package keys
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// namedKeys maps the key names used in bindings to key codes.
+var namedKeys = map[string]rune{
+	"enter":     tea.KeyEnter,
+	"esc":       tea.KeyEscape,
+	"tab":       tea.KeyTab,
+	"backspace": tea.KeyBackspace,
+	"delete":    tea.KeyDelete,
+	"insert":    tea.KeyInsert,
+	"space":     tea.KeySpace,
+	"up":        tea.KeyUp,
+	"down":      tea.KeyDown,
+	"left":      tea.KeyLeft,
+	"right":     tea.KeyRight,
+	"home":      tea.KeyHome,
+	"end":       tea.KeyEnd,
+	"pgup":      tea.KeyPgUp,
+	"pgdown":    tea.KeyPgDown,
+}
+
+// modifiers maps the modifier prefixes of key names to key modifiers.
+var modifiers = map[string]tea.KeyMod{
+	"ctrl":  tea.ModCtrl,
+	"alt":   tea.ModAlt,
+	"shift": tea.ModShift,
+	"meta":  tea.ModMeta,
+	"super": tea.ModSuper,
+}
+
+// Press returns the key press whose String is name, such as "ctrl+s",
+// "enter" or "?", so a binding can be triggered without the keyboard. ok is
+// false for names it cannot represent.
+func Press(name string) (msg tea.KeyPressMsg, ok bool) {
+	rest := name
+	for {
+		prefix, after, found := strings.Cut(rest, "+")
+		mod, isMod := modifiers[prefix]
+		if !found || !isMod || after == "" {
+			break
+		}
+		msg.Mod |= mod
+		rest = after
+	}
+	if code, named := namedKeys[rest]; named {
+		msg.Code = code
+		if code == tea.KeySpace && msg.Mod == 0 {
+			msg.Text = " "
+		}
+		return msg, true
+	}
+	r, size := utf8.DecodeRuneInString(rest)
+	if r == utf8.RuneError || size != len(rest) {
+		return tea.KeyPressMsg{}, false
+	}
+	msg.Code = r
+	if msg.Mod&^tea.ModShift == 0 {
+		msg.Text = rest
+	}
+	return msg, true
+}
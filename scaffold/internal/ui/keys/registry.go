@@ -0,0 +1,292 @@
+package keys
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"charm.land/bubbles/v2/key"
+
+	"scaffold/internal/i18n"
+)
+
+// Def declares a rebindable key binding. Components register their Defs
+// from an init function and build their key maps with Bind, so user
+// overrides apply everywhere a binding is used.
+type Def struct {
+	// ID is "<scope>.<name>", e.g. "menu.select". Bindings in one scope are
+	// active at the same time, so they must not share a key.
+	ID string
+	// Keys are the default keys.
+	Keys []string
+	// Help is the help label for the default keys, e.g. "↑/k". Empty uses
+	// the keys joined with "/".
+	Help string
+	// Desc is the catalog key of the help description. Empty hides the
+	// binding from help.
+	Desc string
+}
+
+// Scope returns the part of the ID before the first dot.
+func (d Def) Scope() string {
+	scope, _, _ := strings.Cut(d.ID, ".")
+	return scope
+}
+
+// Name returns the part of the ID after the first dot.
+func (d Def) Name() string {
+	_, name, _ := strings.Cut(d.ID, ".")
+	return name
+}
+
+// Overrides maps scope to binding name to keys, the shape of the config's
+// "keys" section and of keys.json. An empty key list unbinds a binding.
+type Overrides map[string]map[string][]string
+
+// Conflict is a key bound more than once within a scope.
+type Conflict struct {
+	Key string
+	IDs []string // sorted
+}
+
+// ErrUnknownBinding is returned for overrides naming no registered binding.
+var ErrUnknownBinding = errors.New("unknown key binding")
+
+// Registry holds binding definitions and the user's overrides. It is safe
+// for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	defs      map[string]Def
+	order     []string // registration order
+	overrides map[string][]string
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{defs: map[string]Def{}, overrides: map[string][]string{}}
+}
+
+// defaultRegistry is the registry used by the package-level functions.
+var defaultRegistry = NewRegistry()
+
+// Register adds defs to the default registry.
+func Register(defs ...Def) { defaultRegistry.Register(defs...) }
+
+// Bind builds a binding from the default registry.
+func Bind(id string) key.Binding { return defaultRegistry.Bind(id) }
+
+// Defs returns the definitions in the default registry.
+func Defs() []Def { return defaultRegistry.Defs() }
+
+// Lookup returns the definition of id in the default registry.
+func Lookup(id string) (Def, bool) { return defaultRegistry.Lookup(id) }
+
+// Keys returns the effective keys of a binding in the default registry.
+func Keys(id string) []string { return defaultRegistry.Keys(id) }
+
+// SetOverrides replaces the overrides of the default registry.
+func SetOverrides(layers ...Overrides) error { return defaultRegistry.SetOverrides(layers...) }
+
+// CurrentOverrides returns the overrides of the default registry.
+func CurrentOverrides() Overrides { return defaultRegistry.Overrides() }
+
+// Conflicts returns the conflicts in the default registry.
+func Conflicts() []Conflict { return defaultRegistry.Conflicts() }
+
+// Register adds defs. Registering an ID twice is a programming error.
+func (r *Registry) Register(defs ...Def) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, d := range defs {
+		if _, dup := r.defs[d.ID]; dup {
+			panic("keys: binding registered twice: " + d.ID)
+		}
+		r.defs[d.ID] = d
+		r.order = append(r.order, d.ID)
+	}
+}
+
+// Defs returns the definitions sorted by scope, in registration order
+// within a scope.
+func (r *Registry) Defs() []Def {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	defs := make([]Def, 0, len(r.order))
+	for _, id := range r.order {
+		defs = append(defs, r.defs[id])
+	}
+	sort.SliceStable(defs, func(i, j int) bool { return defs[i].Scope() < defs[j].Scope() })
+	return defs
+}
+
+// Lookup returns the definition of id.
+func (r *Registry) Lookup(id string) (Def, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.defs[id]
+	return d, ok
+}
+
+// Keys returns the effective keys of id: the override if there is one,
+// else the defaults.
+func (r *Registry) Keys(id string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.keysLocked(id)
+}
+
+func (r *Registry) keysLocked(id string) []string {
+	if keys, ok := r.overrides[id]; ok {
+		return keys
+	}
+	return r.defs[id].Keys
+}
+
+// Bind builds the binding id with its effective keys and help text in the
+// current language. Binding an unregistered ID is a programming error. An
+// override with no keys yields a disabled binding.
+func (r *Registry) Bind(id string) key.Binding {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	d, ok := r.defs[id]
+	if !ok {
+		panic("keys: unregistered binding: " + id)
+	}
+	keys := r.keysLocked(id)
+	if len(keys) == 0 {
+		return key.NewBinding(key.WithDisabled())
+	}
+	opts := []key.BindingOpt{key.WithKeys(keys...)}
+	if d.Desc != "" {
+		label := d.Help
+		if _, overridden := r.overrides[id]; overridden || label == "" {
+			label = strings.Join(keys, "/")
+		}
+		opts = append(opts, key.WithHelp(label, i18n.T(d.Desc)))
+	}
+	return key.NewBinding(opts...)
+}
+
+// SetOverrides replaces the overrides with layers merged in order, later
+// layers winning per binding. Entries naming unknown bindings are skipped
+// and reported in an error wrapping ErrUnknownBinding; the rest still
+// apply.
+func (r *Registry) SetOverrides(layers ...Overrides) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	merged := map[string][]string{}
+	var unknown []string
+	for _, layer := range layers {
+		for scope, names := range layer {
+			for name, keys := range names {
+				id := scope + "." + name
+				if _, ok := r.defs[id]; !ok {
+					unknown = append(unknown, id)
+					continue
+				}
+				merged[id] = slices.Clone(keys)
+			}
+		}
+	}
+	r.overrides = merged
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: %s", ErrUnknownBinding, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// Overrides returns a copy of the current overrides.
+func (r *Registry) Overrides() Overrides {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := Overrides{}
+	for id, keys := range r.overrides {
+		d := r.defs[id]
+		if out[d.Scope()] == nil {
+			out[d.Scope()] = map[string][]string{}
+		}
+		out[d.Scope()][d.Name()] = slices.Clone(keys)
+	}
+	return out
+}
+
+// Conflicts returns the keys bound to more than one binding in the same
+// scope, sorted by scope and key. A key that also starts another binding's
+// chord conflicts with it too, since the chord would shadow it.
+func (r *Registry) Conflicts() []Conflict {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	owners := map[string][]string{}   // "scope\x00key" → IDs
+	prefixes := map[string][]string{} // "scope\x00key" → IDs of chords starting with key
+	add := func(m map[string][]string, slot, id string) {
+		if !slices.Contains(m[slot], id) {
+			m[slot] = append(m[slot], id)
+		}
+	}
+	for _, id := range r.order {
+		d := r.defs[id]
+		for _, k := range r.keysLocked(id) {
+			add(owners, d.Scope()+"\x00"+k, id)
+			steps := Steps(k)
+			for n := 1; n < len(steps); n++ {
+				add(prefixes, d.Scope()+"\x00"+strings.Join(steps[:n], " "), id)
+			}
+		}
+	}
+	var slots []string
+	for slot, ids := range owners {
+		for _, id := range prefixes[slot] {
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		if len(ids) > 1 {
+			owners[slot] = ids
+			slots = append(slots, slot)
+		}
+	}
+	sort.Strings(slots)
+	conflicts := make([]Conflict, 0, len(slots))
+	for _, slot := range slots {
+		_, k, _ := strings.Cut(slot, "\x00")
+		ids := owners[slot]
+		sort.Strings(ids)
+		conflicts = append(conflicts, Conflict{Key: k, IDs: ids})
+	}
+	return conflicts
+}
+
+// LoadFile reads overrides from a keys.json file. A missing file yields no
+// overrides.
+func LoadFile(path string) (Overrides, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("keys: reading %s: %w", path, err)
+	}
+	var o Overrides
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("keys: parsing %s: %w", path, err)
+	}
+	return o, nil
+}
+
+// SaveFile writes overrides to a keys.json file.
+func SaveFile(path string, o Overrides) error {
+	data, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return fmt.Errorf("keys: encoding overrides: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("keys: writing %s: %w", path, err)
+	}
+	return nil
+}
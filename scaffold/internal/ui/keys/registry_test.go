@@ -0,0 +1,117 @@
+package keys
+
+import (
+	"path/filepath"
+	"testing"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRegistry() *Registry {
+	r := NewRegistry()
+	r.Register(
+		Def{ID: "list.up", Keys: []string{"up", "k"}, Help: "↑/k", Desc: "list.up"},
+		Def{ID: "list.down", Keys: []string{"down", "j"}, Help: "↓/j", Desc: "list.down"},
+		Def{ID: "other.go", Keys: []string{"k"}},
+	)
+	return r
+}
+
+func TestRegistry_BindUsesDefaultsAndOverrides(t *testing.T) {
+	r := testRegistry()
+	up := r.Bind("list.up")
+	assert.Equal(t, []string{"up", "k"}, up.Keys())
+	assert.Equal(t, "↑/k", up.Help().Key)
+
+	require.NoError(t, r.SetOverrides(Overrides{"list": {"up": {"ctrl+p", "p"}}}))
+	up = r.Bind("list.up")
+	assert.True(t, key.Matches(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl}, up))
+	assert.Equal(t, "ctrl+p/p", up.Help().Key, "overridden keys are listed as bound")
+
+	hidden := r.Bind("other.go")
+	assert.Empty(t, hidden.Help().Key, "bindings without a description stay out of help")
+}
+
+func TestRegistry_EmptyOverrideUnbinds(t *testing.T) {
+	r := testRegistry()
+	require.NoError(t, r.SetOverrides(Overrides{"list": {"down": {}}}))
+	assert.False(t, r.Bind("list.down").Enabled())
+	assert.Empty(t, r.Keys("list.down"))
+}
+
+func TestRegistry_LaterLayersWin(t *testing.T) {
+	r := testRegistry()
+	require.NoError(t, r.SetOverrides(
+		Overrides{"list": {"up": {"a"}, "down": {"b"}}},
+		Overrides{"list": {"up": {"c"}}},
+	))
+	assert.Equal(t, []string{"c"}, r.Keys("list.up"))
+	assert.Equal(t, []string{"b"}, r.Keys("list.down"))
+	assert.Equal(t, Overrides{"list": {"up": {"c"}, "down": {"b"}}}, r.Overrides())
+}
+
+func TestRegistry_UnknownBindingsReported(t *testing.T) {
+	r := testRegistry()
+	err := r.SetOverrides(Overrides{"list": {"up": {"w"}, "sideways": {"s"}}, "nope": {"x": {"x"}}})
+	require.ErrorIs(t, err, ErrUnknownBinding)
+	assert.Contains(t, err.Error(), "list.sideways, nope.x")
+	assert.Equal(t, []string{"w"}, r.Keys("list.up"), "known overrides still apply")
+}
+
+func TestRegistry_ConflictsWithinScope(t *testing.T) {
+	r := testRegistry()
+	assert.Empty(t, r.Conflicts(), `"k" in another scope is no conflict`)
+
+	require.NoError(t, r.SetOverrides(Overrides{"list": {"down": {"k"}}}))
+	assert.Equal(t, []Conflict{{Key: "k", IDs: []string{"list.down", "list.up"}}}, r.Conflicts())
+}
+
+func TestRegistry_DefsGroupedByScope(t *testing.T) {
+	r := NewRegistry()
+	r.Register(Def{ID: "b.one"}, Def{ID: "a.one"}, Def{ID: "b.two"})
+	var ids []string
+	for _, d := range r.Defs() {
+		ids = append(ids, d.ID)
+	}
+	assert.Equal(t, []string{"a.one", "b.one", "b.two"}, ids)
+	assert.Panics(t, func() { r.Register(Def{ID: "a.one"}) })
+	assert.Panics(t, func() { r.Bind("a.missing") })
+}
+
+func TestFile_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	o, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Nil(t, o, "a missing file has no overrides")
+
+	want := Overrides{"global": {"quit": {"ctrl+q"}}}
+	require.NoError(t, SaveFile(path, want))
+	got, err := LoadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestPress_RoundTripsKeyNames(t *testing.T) {
+	for _, name := range []string{"q", "?", "Y", "ctrl+s", "enter", "esc", "space", "shift+tab", "alt+x", "ctrl+shift+up", "pgdown", "+"} {
+		msg, ok := Press(name)
+		require.True(t, ok, name)
+		assert.Equal(t, name, msg.String())
+	}
+	for _, name := range []string{"ctrl+", "nonsense", ""} {
+		_, ok := Press(name)
+		assert.False(t, ok, name)
+	}
+}
+
+func TestHint_FollowsRebinding(t *testing.T) {
+	r := testRegistry()
+	run := key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "run"))
+	assert.Equal(t, "↑/k/↓/j select • enter run", Hint(Group("select", r.Bind("list.up"), r.Bind("list.down")), run))
+
+	require.NoError(t, r.SetOverrides(Overrides{"list": {"up": {"ctrl+p"}, "down": {}}}))
+	assert.Equal(t, "ctrl+p select • enter run", Hint(Group("select", r.Bind("list.up"), r.Bind("list.down")), run))
+	assert.Equal(t, "enter run", Hint(Group("select", r.Bind("list.down")), run), "unbound groups are left out")
+}
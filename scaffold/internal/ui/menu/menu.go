@@ -2,6 +2,8 @@
 package menu
 
 import (
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/mouse"
 	"scaffold/internal/ui/theme"
 
 	"charm.land/bubbles/v2/key"
@@ -42,23 +44,28 @@ type keyMap struct {
 	Select key.Binding
 	Up     key.Binding
 	Down   key.Binding
+	Top    key.Binding
+	Bottom key.Binding
 }
 
-// defaultKeyMap returns the default key bindings.
+func init() {
+	keys.Register(
+		keys.Def{ID: "menu.select", Keys: []string{"enter", "l"}, Help: "enter/l", Desc: "menu.select"},
+		keys.Def{ID: "menu.up", Keys: []string{"up", "k"}, Help: "↑/k", Desc: "menu.up"},
+		keys.Def{ID: "menu.down", Keys: []string{"down", "j"}, Help: "↓/j", Desc: "menu.down"},
+		keys.Def{ID: "menu.top", Keys: []string{"g g", "home"}, Desc: "menu.top"},
+		keys.Def{ID: "menu.bottom", Keys: []string{"G", "end"}, Desc: "menu.bottom"},
+	)
+}
+
+// defaultKeyMap returns the menu bindings with user overrides applied.
 func defaultKeyMap() keyMap {
 	return keyMap{
-		Select: key.NewBinding(
-			key.WithKeys("enter", "l"),
-			key.WithHelp("enter/l", "select"),
-		),
-		Up: key.NewBinding(
-			key.WithKeys("up", "k"),
-			key.WithHelp("↑/k", "up"),
-		),
-		Down: key.NewBinding(
-			key.WithKeys("down", "j"),
-			key.WithHelp("↓/j", "down"),
-		),
+		Select: keys.Bind("menu.select"),
+		Up:     keys.Bind("menu.up"),
+		Down:   keys.Bind("menu.down"),
+		Top:    keys.Bind("menu.top"),
+		Bottom: keys.Bind("menu.bottom"),
 	}
 }
 
@@ -69,7 +76,7 @@ func (k keyMap) ShortHelp() []key.Binding {
 
 // FullHelp implements help.KeyMap.
 func (k keyMap) FullHelp() [][]key.Binding {
-	return [][]key.Binding{{k.Up, k.Down, k.Select}}
+	return [][]key.Binding{{k.Up, k.Down, k.Top, k.Bottom, k.Select}}
 }
 
 // SelectionMsg is emitted when a menu item is selected.
@@ -121,8 +128,7 @@ func (m Model) SetItems(items []Item) Model {
 		if p.Primary == nil {
 			p = theme.NewPalette("default", false) // fallback
 		}
-		m.delegate = list.NewDefaultDelegate()
-		m.delegate.Styles = theme.ListItemStyles(p)
+		m.delegate = newDelegate(p, m.ThemeState().Compact)
 
 		m.list = list.New(listItems, m.delegate, m.width, m.height)
 		m.list.Title = "Menu"
@@ -133,11 +139,22 @@ func (m Model) SetItems(items []Item) Model {
 		m.list.SetShowTitle(false)
 		m.list.SetShowHelp(false) // Hide list's help, use global help
 		m.list.DisableQuitKeybindings()
+		// Top and Bottom replace the list's own, so they can be rebound.
+		m.list.KeyMap.GoToStart.SetEnabled(false)
+		m.list.KeyMap.GoToEnd.SetEnabled(false)
 		m.ready = true
 	}
 	return m
 }
 
+// Select moves the cursor to the item at index i.
+func (m Model) Select(i int) Model {
+	if m.ready {
+		m.list.Select(i)
+	}
+	return m
+}
+
 // ApplyTheme implements theme.Themeable.
 func (m *Model) ApplyTheme(state theme.State) {
 	m.ApplyThemeState(state)
@@ -146,12 +163,29 @@ func (m *Model) ApplyTheme(state theme.State) {
 		p := state.Palette
 		m.list.Styles = theme.ListStyles(p)
 
-		m.delegate = list.NewDefaultDelegate()
-		m.delegate.Styles = theme.ListItemStyles(p)
+		m.delegate = newDelegate(p, state.Compact)
 		m.list.SetDelegate(m.delegate)
 	}
 }
 
+// newDelegate returns the themed item delegate. Compact items are a single
+// title line with no spacing between them.
+func newDelegate(p theme.Palette, compact bool) list.DefaultDelegate {
+	d := list.NewDefaultDelegate()
+	d.Styles = theme.ListItemStyles(p)
+	if compact {
+		d.ShowDescription = false
+		d.SetSpacing(0)
+	}
+	return d
+}
+
+// ApplyLanguage implements i18n.Localizable. Item text belongs to the
+// owning screen, which re-sets the items itself.
+func (m *Model) ApplyLanguage() {
+	m.keys = defaultKeyMap()
+}
+
 // Init initializes the menu.
 func (m Model) Init() tea.Cmd {
 	return nil
@@ -163,6 +197,30 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Mouse coordinates are relative to the menu's top-left corner.
+	if delta := mouse.WheelDelta(msg); delta != 0 {
+		if delta < 0 {
+			m.list.CursorUp()
+		} else {
+			m.list.CursorDown()
+		}
+		return m, nil
+	}
+	if x, y, ok := mouse.LeftClick(msg); ok {
+		return m.handleClick(x, y)
+	}
+
+	if !m.Filtering() {
+		switch {
+		case keys.Matches(msg, m.keys.Top):
+			m.list.Select(0)
+			return m, nil
+		case keys.Matches(msg, m.keys.Bottom):
+			m.list.Select(max(len(m.list.VisibleItems())-1, 0))
+			return m, nil
+		}
+	}
+
 	var cmd tea.Cmd
 	m.list, cmd = m.list.Update(msg)
 
@@ -180,6 +238,36 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, cmd
 }
 
+// Filtering reports whether the user is typing a filter query, which
+// takes printable keys as text.
+func (m Model) Filtering() bool {
+	return m.ready && m.list.SettingFilter()
+}
+
+// handleClick selects the item under (x, y) and emits a SelectionMsg for it,
+// as if it had been chosen with the Select key. Clicks on the gap between
+// items are ignored.
+func (m Model) handleClick(x, y int) (Model, tea.Cmd) {
+	if x < 0 || x >= m.width || y < 0 {
+		return m, nil
+	}
+	rowH := m.delegate.Height() + m.delegate.Spacing()
+	if rowH <= 0 || y%rowH >= m.delegate.Height() {
+		return m, nil
+	}
+	index := m.list.Paginator.Page*m.list.Paginator.PerPage + y/rowH
+	items := m.list.VisibleItems()
+	if index >= len(items) || y/rowH >= m.list.Paginator.PerPage {
+		return m, nil
+	}
+	m.list.Select(index)
+	item, ok := items[index].(Item)
+	if !ok {
+		return m, nil
+	}
+	return m, func() tea.Msg { return SelectionMsg{Item: item} }
+}
+
 // View renders the menu.
 func (m Model) View() tea.View {
 	if !m.ready {
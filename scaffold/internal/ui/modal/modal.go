@@ -1,11 +1,16 @@
 package modal
 
 import (
+	"strings"
+
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/mouse"
 	"scaffold/internal/ui/theme"
 )
 
@@ -23,16 +28,17 @@ type keyMap struct {
 	Cancel  key.Binding
 }
 
+func init() {
+	keys.Register(
+		keys.Def{ID: "modal.confirm", Keys: []string{"y", "Y", "enter"}, Help: "y/enter", Desc: "modal.keys.confirm"},
+		keys.Def{ID: "modal.cancel", Keys: []string{"n", "N", "esc"}, Help: "n/esc", Desc: "modal.keys.cancel"},
+	)
+}
+
 func defaultKeyMap() keyMap {
 	return keyMap{
-		Confirm: key.NewBinding(
-			key.WithKeys("y", "Y", "enter"),
-			key.WithHelp("y/enter", "confirm"),
-		),
-		Cancel: key.NewBinding(
-			key.WithKeys("n", "N", "esc"),
-			key.WithHelp("n/esc", "cancel"),
-		),
+		Confirm: keys.Bind("modal.confirm"),
+		Cancel:  keys.Bind("modal.cancel"),
 	}
 }
 
@@ -71,40 +77,43 @@ func New(msg ShowMsg, p theme.Palette) Model {
 // Visible reports whether the modal is currently displayed.
 func (m Model) Visible() bool { return m.visible }
 
-// Update handles key presses, routing to ConfirmedMsg, CancelledMsg, or
-// PromptSubmittedMsg depending on the modal Kind.
+// Update handles key presses and button clicks, routing to ConfirmedMsg,
+// CancelledMsg, or PromptSubmittedMsg depending on the modal Kind. Mouse
+// coordinates are relative to the dialog's top-left corner.
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if x, y, ok := mouse.LeftClick(msg); ok {
+		for i, r := range m.buttonBounds() {
+			if r.Contains(x, y) {
+				return m.buttons()[i].action(m)
+			}
+		}
+		return m, nil
+	}
+
 	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
 		switch m.kind {
 		case KindConfirm:
 			if key.Matches(keyMsg, m.keys.Confirm) {
-				m.visible = false
-				id := m.id
-				return m, func() tea.Msg { return ConfirmedMsg{ID: id} }
+				return m.confirm()
 			}
 			if key.Matches(keyMsg, m.keys.Cancel) {
-				m.visible = false
-				id := m.id
-				return m, func() tea.Msg { return CancelledMsg{ID: id} }
+				return m.cancel()
 			}
 		case KindAlert:
 			// Any confirm or cancel key dismisses the alert
 			if key.Matches(keyMsg, m.keys.Confirm) || key.Matches(keyMsg, m.keys.Cancel) {
-				m.visible = false
-				id := m.id
-				return m, func() tea.Msg { return CancelledMsg{ID: id} }
+				return m.cancel()
 			}
 		case KindPrompt:
+			// Text goes to the input first, so "n" is a letter, not cancel.
+			if keyMsg.Text != "" {
+				break
+			}
 			if key.Matches(keyMsg, m.keys.Cancel) {
-				m.visible = false
-				id := m.id
-				return m, func() tea.Msg { return CancelledMsg{ID: id} }
+				return m.cancel()
 			}
 			if keyMsg.String() == "enter" {
-				val := m.input.Value()
-				m.visible = false
-				id := m.id
-				return m, func() tea.Msg { return PromptSubmittedMsg{ID: id, Value: val} }
+				return m.submit()
 			}
 		}
 	}
@@ -117,6 +126,101 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// confirm hides the modal and emits ConfirmedMsg.
+func (m Model) confirm() (Model, tea.Cmd) {
+	m.visible = false
+	id := m.id
+	return m, func() tea.Msg { return ConfirmedMsg{ID: id} }
+}
+
+// cancel hides the modal and emits CancelledMsg.
+func (m Model) cancel() (Model, tea.Cmd) {
+	m.visible = false
+	id := m.id
+	return m, func() tea.Msg { return CancelledMsg{ID: id} }
+}
+
+// submit hides the modal and emits PromptSubmittedMsg with the input value.
+func (m Model) submit() (Model, tea.Cmd) {
+	val := m.input.Value()
+	m.visible = false
+	id := m.id
+	return m, func() tea.Msg { return PromptSubmittedMsg{ID: id, Value: val} }
+}
+
+// button is a clickable action in the dialog's hint row.
+type button struct {
+	label  string
+	action func(Model) (Model, tea.Cmd)
+}
+
+// buttonGap separates buttons in the hint row.
+const buttonGap = "   "
+
+// buttons returns the hint-row buttons for the modal Kind, each labelled
+// with the keys that press it.
+func (m Model) buttons() []button {
+	switch m.kind {
+	case KindAlert:
+		return []button{{buttonLabel(m.keys.Confirm.Help().Key, i18n.T("modal.ok")), Model.cancel}}
+	case KindPrompt:
+		return []button{
+			{buttonLabel("enter", i18n.T("modal.submit")), Model.submit},
+			{buttonLabel(untypedKeys(m.keys.Cancel), i18n.T("modal.cancel")), Model.cancel},
+		}
+	default:
+		return []button{
+			{buttonLabel(m.keys.Confirm.Help().Key, i18n.T("modal.yes")), Model.confirm},
+			{buttonLabel(m.keys.Cancel.Help().Key, i18n.T("modal.no")), Model.cancel},
+		}
+	}
+}
+
+// buttonLabel renders a button as "[keys] text".
+func buttonLabel(keys, text string) string {
+	return "[" + keys + "] " + text
+}
+
+// untypedKeys returns the keys of b that type no text, joined by "/". In
+// a prompt the others are text for the input.
+func untypedKeys(b key.Binding) string {
+	var names []string
+	for _, k := range b.Keys() {
+		if press, ok := keys.Press(k); ok && press.Text == "" {
+			names = append(names, k)
+		}
+	}
+	return strings.Join(names, "/")
+}
+
+// buttonBounds returns the dialog-relative bounds of each button. The hint
+// row is always the last content row, just above the bottom padding and
+// border.
+func (m Model) buttonBounds() []mouse.Rect {
+	d := m.styles.Dialog
+	y := lipgloss.Height(m.View().Content) - d.GetBorderBottomSize() - d.GetPaddingBottom() - 1
+	x := d.GetBorderLeftSize() + d.GetPaddingLeft()
+
+	btns := m.buttons()
+	bounds := make([]mouse.Rect, len(btns))
+	for i, b := range btns {
+		w := lipgloss.Width(b.label)
+		bounds[i] = mouse.Rect{X: x, Y: y, W: w, H: 1}
+		x += w + len(buttonGap)
+	}
+	return bounds
+}
+
+// hintRow renders the buttons as a single hint line.
+func (m Model) hintRow() string {
+	btns := m.buttons()
+	labels := make([]string, len(btns))
+	for i, b := range btns {
+		labels[i] = b.label
+	}
+	return m.styles.Hint.Render(strings.Join(labels, buttonGap))
+}
+
 // View renders the dialog box.
 func (m Model) View() tea.View {
 	var rows []string
@@ -127,16 +231,11 @@ func (m Model) View() tea.View {
 	}
 	rows = append(rows, "")
 
-	switch m.kind {
-	case KindConfirm:
-		rows = append(rows, m.styles.Hint.Render("[y] Yes   [n] No"))
-	case KindAlert:
-		rows = append(rows, m.styles.Hint.Render("[enter] OK"))
-	case KindPrompt:
+	if m.kind == KindPrompt {
 		rows = append(rows, m.input.View())
 		rows = append(rows, "")
-		rows = append(rows, m.styles.Hint.Render("[enter] Submit   [esc] Cancel"))
 	}
+	rows = append(rows, m.hintRow())
 
 	inner := lipgloss.JoinVertical(lipgloss.Left, rows...)
 	return tea.NewView(m.styles.Dialog.Render(inner))
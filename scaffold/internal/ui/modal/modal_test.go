@@ -0,0 +1,49 @@
+package modal
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/theme"
+)
+
+func TestPrompt_TypedCancelKeysAreText(t *testing.T) {
+	m := New(ShowMsg{ID: "name", Kind: KindPrompt, Title: "Name"}, theme.Palette{})
+	for _, r := range "Nina" {
+		var cmd tea.Cmd
+		m, cmd = m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		if cmd != nil {
+			_, cancelled := cmd().(CancelledMsg)
+			require.False(t, cancelled, "%q cancelled the prompt", r)
+		}
+	}
+	require.True(t, m.Visible())
+
+	m, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, m.Visible())
+	assert.Equal(t, PromptSubmittedMsg{ID: "name", Value: "Nina"}, cmd())
+}
+
+func TestConfirm_LetterKeysAnswer(t *testing.T) {
+	m := New(ShowMsg{ID: "ok", Kind: KindConfirm}, theme.Palette{})
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, CancelledMsg{ID: "ok"}, cmd())
+}
+
+func TestButtons_FollowRebinding(t *testing.T) {
+	require.NoError(t, keys.SetOverrides(keys.Overrides{"modal": {"confirm": {"ctrl+y"}, "cancel": {"x", "ctrl+c"}}}))
+	t.Cleanup(func() { _ = keys.SetOverrides() })
+
+	view := New(ShowMsg{ID: "ok", Kind: KindConfirm}, theme.Palette{}).View().Content
+	assert.Contains(t, view, "[ctrl+y]")
+	assert.Contains(t, view, "[x/ctrl+c]")
+	assert.NotContains(t, view, "[y]")
+
+	view = New(ShowMsg{ID: "name", Kind: KindPrompt}, theme.Palette{}).View().Content
+	assert.Contains(t, view, "[ctrl+c]", "x is text in a prompt")
+}
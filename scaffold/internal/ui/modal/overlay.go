@@ -1,11 +1,63 @@
 package modal
 
-import "charm.land/lipgloss/v2"
+import (
+	"math"
+	"strings"
 
-// Overlay centers popup over a w×h area using lipgloss.Place.
-// The base content is not currently composited — popup is shown on a
-// whitespace backdrop. Pass the rendered base if dimming is added later.
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Overlay draws popup centered over base, a rendered w×h screen. The cells
+// of base around the popup stay visible.
 func Overlay(base, popup string, w, h int) string {
-	_ = base // reserved for future dimming/compositing
-	return lipgloss.Place(w, h, lipgloss.Center, lipgloss.Center, popup)
+	x, y := Origin(popup, w, h)
+	return Composite(base, popup, x, y)
+}
+
+// Composite draws popup over base with its top-left corner at cell (x, y),
+// replacing the cells it covers. Lines of base that are too short are padded
+// with spaces; rows below base are added as needed.
+func Composite(base, popup string, x, y int) string {
+	lines := strings.Split(base, "\n")
+	for i, over := range strings.Split(popup, "\n") {
+		row := y + i
+		if row < 0 {
+			continue
+		}
+		for len(lines) <= row {
+			lines = append(lines, "")
+		}
+		lines[row] = splice(lines[row], over, max(x, 0))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splice replaces the cells of line from column x on with over. Styles are
+// reset around over so neither side bleeds into the other.
+func splice(line, over string, x int) string {
+	left := ansi.Truncate(line, x, "")
+	if w := ansi.StringWidth(left); w < x {
+		left += strings.Repeat(" ", x-w)
+	}
+	var right string
+	if end := x + ansi.StringWidth(over); end < ansi.StringWidth(line) {
+		right = ansi.TruncateLeft(line, end, "")
+	}
+	return left + ansi.ResetStyle + over + ansi.ResetStyle + right
+}
+
+// Origin returns the top-left cell at which Overlay draws popup in a w×h
+// area, so mouse events can be translated into dialog coordinates.
+func Origin(popup string, w, h int) (x, y int) {
+	return centerOffset(w, lipgloss.Width(popup)), centerOffset(h, lipgloss.Height(popup))
+}
+
+// centerOffset mirrors lipgloss.Place's rounding for lipgloss.Center.
+func centerOffset(outer, inner int) int {
+	gap := outer - inner
+	if gap <= 0 {
+		return 0
+	}
+	return gap - int(math.Round(float64(gap)*float64(lipgloss.Center)))
 }
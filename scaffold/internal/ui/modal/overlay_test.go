@@ -0,0 +1,19 @@
+package modal
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposite_KeepsBaseAroundPopup(t *testing.T) {
+	base := "abcdefgh\n\x1b[1mijklmnop\x1b[0m\nqr"
+	got := ansi.Strip(Composite(base, "XY\nZW\nUV", 3, 1))
+	assert.Equal(t, "abcdefgh\nijkXYnop\nqr ZW\n   UV", got)
+}
+
+func TestOverlay_CentersPopup(t *testing.T) {
+	got := ansi.Strip(Overlay(".....\n.....\n.....", "#", 5, 3))
+	assert.Equal(t, ".....\n..#..\n.....", got)
+}
@@ -8,14 +8,23 @@ import (
 	"charm.land/lipgloss/v2"
 
 	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/notify"
 	"scaffold/internal/task"
+	"scaffold/internal/ui/anim"
+	"scaffold/internal/ui/cmdpalette"
+	"scaffold/internal/ui/editor"
+	"scaffold/internal/ui/extedit"
 	"scaffold/internal/ui/header"
+	"scaffold/internal/ui/keyhelp"
 	"scaffold/internal/ui/keys"
 	"scaffold/internal/ui/menu"
 	"scaffold/internal/ui/modal"
 	"scaffold/internal/ui/screens"
 	"scaffold/internal/ui/statusbar"
 	"scaffold/internal/ui/theme"
+	"scaffold/internal/ui/toast"
+	"scaffold/internal/ui/whichkey"
 )
 
 // NavigateMsg is a message to navigate to a new screen.
@@ -71,17 +80,30 @@ type rootModel struct {
 	ctx        context.Context
 	cancel     context.CancelFunc // shutdown only; cancels all running tasks on quit
 	cfg        config.Config
-	configPath string // empty = no persistent save
+	preview    *config.Config // unsaved settings being previewed; nil when none
+	configPath string         // empty = no persistent save
 	firstRun   bool
 	width      int
 	height     int
 	bodyH      int // cached body height, updated on resize/navigation/theme change
+	scroll     bodyScroll
+	anims      animations
 	themeMgr   *theme.Manager
 	state      rootState
 	styles     theme.Styles
 	keys       keys.GlobalKeyMap
 	help       help.Model
 	modal      modal.Model
+	toasts     toast.Model
+	fullHelp   keyhelp.Model
+	palette    cmdpalette.Model
+	chords     keys.Chords    // partly typed chord
+	whichKey   whichkey.Model // continuations of the pending chord
+	notifier   notify.Notifier
+	keysErr    error    // problem with the key-binding overrides, reported on start
+	themeWarns []string // problems with the user themes, reported on start
+	profileErr error    // why the active profile could not be loaded; blocks saving
+	secretsErr error    // why some secrets could not be loaded; they are not saved over
 	header     header.Model
 	statusbar  statusbar.Model
 	current    screens.Screen
@@ -90,6 +112,11 @@ type rootModel struct {
 
 // newRootModel creates a new root model.
 func newRootModel(ctx context.Context, cancel context.CancelFunc, cfg config.Config, configPath string, firstRun bool) rootModel {
+	// Screens and key maps read the catalog as they are built.
+	i18n.SetLanguage(cfg.UI.Language)
+	applyTimeFormat(cfg)
+	keysErr := applyKeyOverrides(cfg, configPath)
+	_, themeWarns := loadThemes(configPath)
 	return rootModel{
 		ctx:        ctx,
 		cancel:     cancel,
@@ -100,6 +127,11 @@ func newRootModel(ctx context.Context, cancel context.CancelFunc, cfg config.Con
 		current:    screens.NewHome(),
 		keys:       keys.DefaultGlobalKeyMap(),
 		help:       help.New(),
+		toasts:     toast.New(),
+		notifier:   notify.New(cfg.Notifications, append([]notify.Backend{toast.Backend{}}, notify.Detect(cfg.App.Name)...)...),
+		keysErr:    keysErr,
+		themeWarns: themeWarns,
+		profileErr: checkProfile(cfg, configPath),
 		header:     header.New(cfg),
 		statusbar:  statusbar.New(cfg),
 	}
@@ -109,7 +141,11 @@ func newRootModel(ctx context.Context, cancel context.CancelFunc, cfg config.Con
 func (m rootModel) Init() tea.Cmd {
 	cmds := tea.Batch(
 		tea.RequestBackgroundColor,
-		m.themeMgr.Init(m.cfg.UI.ThemeName, false, m.width),
+		m.themeMgr.Init(m.cfg.UI.ThemeName, false, m.cfg.UI.CompactMode, m.width),
+		keyWarning(m.keysErr),
+		themeWarning(m.themeWarns),
+		profileWarning(m.cfg.Profile, m.profileErr),
+		secretsWarning(m.secretsErr),
 	)
 	if m.firstRun {
 		return tea.Batch(cmds, func() tea.Msg {
@@ -126,14 +162,30 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleWindowSize(msg)
 	case tea.BackgroundColorMsg:
 		return m.handleBgColor(msg)
+	case tea.ColorProfileMsg:
+		return m.handleColorProfile(msg)
 	case theme.ThemeChangedMsg:
 		return m.handleThemeChanged(msg)
 	case tea.KeyPressMsg:
 		return m.handleKey(msg)
+	case paletteKeyMsg:
+		return m.dispatchKey(msg.press)
+	case keys.ChordMsg:
+		return m.handleChord(msg)
+	case keys.ChordTimeoutMsg:
+		return m.handleChordTimeout(msg)
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+	case anim.FrameMsg:
+		return m.handleFrame(msg)
 	case modal.ShowMsg:
 		return m.handleModalShow(msg)
 	case modal.ConfirmedMsg, modal.CancelledMsg, modal.PromptSubmittedMsg:
 		return m.handleModalDismiss(msg)
+	case toast.ShowMsg, toast.TickMsg:
+		var cmd tea.Cmd
+		m.toasts, cmd = m.toasts.Update(msg)
+		return m, cmd
 	case task.ErrMsg:
 		return m.handleTaskErr(msg)
 	case screens.WelcomeDoneMsg:
@@ -144,8 +196,27 @@ func (m rootModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleMenuSelection(msg)
 	case screens.SettingsSavedMsg:
 		return m.handleSettingsSaved(msg)
+	case screens.SettingsPreviewMsg:
+		return m.handleSettingsPreview(msg)
+	case screens.KeyBindingsChangedMsg:
+		return m.handleKeyBindingsChanged(msg)
+	case screens.UseThemeMsg:
+		return m.setTheme(msg.Name)
+	case reloadThemesMsg:
+		return m.handleReloadThemes()
+	case screens.EditConfigMsg:
+		return m.handleEditConfig(msg)
+	case extedit.EditedMsg:
+		return m.handleEdited(msg)
+	case editor.DirtyMsg:
+		m.statusbar = m.statusbar.WithDirty(msg.Dirty)
+		return m, nil
 	case screens.BackMsg:
 		return m.handleBack(msg)
+	case task.DoneMsg[loadedConfig]:
+		return m.handleConfigLoaded(msg)
+	case task.Finished:
+		return m.handleTaskDone(msg)
 	}
 	return m.broadcast(msg)
 }
@@ -156,17 +227,36 @@ func (m rootModel) View() tea.View {
 		return tea.NewView("")
 	}
 
-	content := lipgloss.JoinVertical(lipgloss.Left,
+	parts := []string{
 		m.header.View().Content,
-		m.styles.Body.MaxHeight(m.bodyH).Render(m.current.Body()),
-		m.helpView(),
-		m.statusbar.View().Content,
-	)
+		m.styles.Body.MaxHeight(m.bodyH).Render(m.slideBody(m.bodyView())),
+	}
+	if help := m.helpView(); help != "" {
+		parts = append(parts, help)
+	}
+	parts = append(parts, m.statusbar.View().Content)
+	content := lipgloss.JoinVertical(lipgloss.Left, parts...)
 
 	base := m.styles.App.Render(content)
+	if stack := m.toasts.View(); stack != "" {
+		r := m.toastRect(stack)
+		base = modal.Composite(base, stack, r.X, r.Y)
+	}
+	if popup := m.whichKey.View(); popup != "" {
+		body := m.bodyRect()
+		base = modal.Composite(base, popup, body.X, max(body.Y+body.H-lipgloss.Height(popup), 0))
+	}
 
-	if m.modal.Visible() {
-		return tea.NewView(modal.Overlay(base, m.modal.View().Content, m.width, m.height))
+	if popup := m.modalPopup(); popup != "" || m.modal.Visible() {
+		base = modal.Overlay(base, popup, m.width, m.height)
+	} else if m.palette.Visible() {
+		base = modal.Overlay(base, m.palette.View().Content, m.width, m.height)
+	} else if m.fullHelp.Visible() {
+		base = modal.Overlay(base, m.fullHelp.View().Content, m.width, m.height)
+	}
+	v := tea.NewView(base)
+	if m.effectiveCfg().UI.MouseEnabled {
+		v.MouseMode = tea.MouseModeCellMotion
 	}
-	return tea.NewView(base)
+	return v
 }
@@ -2,14 +2,34 @@ package ui
 
 import (
 	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/task"
+	"scaffold/internal/ui/anim"
+	"scaffold/internal/ui/editor"
+	"scaffold/internal/ui/extedit"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/menu"
+	"scaffold/internal/ui/modal"
 	"scaffold/internal/ui/screens"
 	"scaffold/internal/ui/status"
+	"scaffold/internal/ui/theme"
+	"scaffold/internal/ui/toast"
 )
 
 // testModel returns a minimal rootModel suitable for unit tests.
@@ -151,3 +171,1143 @@ func TestScreenStack_PeekDoesNotRemove(t *testing.T) {
 	assert.Equal(t, a, s.Peek())
 	assert.Equal(t, 1, s.Len(), "Peek should not remove the element")
 }
+
+// --- SettingsPreviewMsg ---
+
+func TestRootModel_SettingsPreview_LeavesSavedConfig(t *testing.T) {
+	m := testModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+
+	preview := m.cfg
+	preview.UI.ShowBanner = true
+	updated, _ = m.Update(screens.SettingsPreviewMsg{Cfg: preview})
+	root := updated.(rootModel)
+
+	if assert.NotNil(t, root.preview) {
+		assert.True(t, root.preview.UI.ShowBanner)
+	}
+	assert.False(t, root.cfg.UI.ShowBanner, "saved config must not change during preview")
+	assert.True(t, root.effectiveCfg().UI.ShowBanner)
+}
+
+func TestRootModel_BackMsg_RevertsPreview(t *testing.T) {
+	m := testModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+	updated, _ = m.Update(NavigateMsg{Screen: screens.NewSettings(m.cfg)})
+	m = updated.(rootModel)
+
+	preview := m.cfg
+	preview.UI.ShowBanner = true
+	updated, _ = m.Update(screens.SettingsPreviewMsg{Cfg: preview})
+	updated, _ = updated.(rootModel).Update(screens.BackMsg{})
+	root := updated.(rootModel)
+
+	assert.Nil(t, root.preview, "leaving settings must end the preview")
+	assert.False(t, root.effectiveCfg().UI.ShowBanner)
+}
+
+func TestRootModel_SettingsSaved_CommitsPreview(t *testing.T) {
+	m := testModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+
+	next := m.cfg
+	next.UI.ShowBanner = true
+	updated, _ = m.Update(screens.SettingsPreviewMsg{Cfg: next})
+	updated, _ = updated.(rootModel).Update(screens.SettingsSavedMsg{Cfg: next})
+	root := updated.(rootModel)
+
+	assert.Nil(t, root.preview)
+	assert.True(t, root.cfg.UI.ShowBanner)
+}
+
+func TestRootModel_SettingsSaved_RejectsInvalidDateFormat(t *testing.T) {
+	m := testModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	settings := screens.NewSettings(m.cfg)
+	updated, _ = updated.(rootModel).Update(NavigateMsg{Screen: settings})
+	m = updated.(rootModel)
+
+	next := m.cfg
+	next.UI.DateFormat = "DD/MM/YYYY"
+	updated, cmd := m.Update(screens.SettingsSavedMsg{Cfg: next})
+	root := updated.(rootModel)
+
+	assert.Empty(t, root.cfg.UI.DateFormat, "invalid settings are not applied")
+	assert.Equal(t, settings, root.current, "settings stay open for correction")
+	require.NotNil(t, cmd)
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+	assert.Equal(t, status.KindError, batch[0]().(status.Msg).Kind)
+}
+
+// --- external config editing ---
+
+func TestRootModel_EditConfig_NeedsConfigFile(t *testing.T) {
+	m := testModel(t)
+	_, cmd := m.Update(screens.EditConfigMsg{})
+	require.NotNil(t, cmd)
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+	assert.Equal(t, status.KindWarning, batch[0]().(status.Msg).Kind)
+}
+
+func TestRootModel_ConfigEdited_ReloadsConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"logLevel": "debug", "ui": {"compactMode": true}}`), 0o600))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	m := newRootModel(ctx, cancel, *config.DefaultConfig(), path, false)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+	updated, _ = m.Update(NavigateMsg{Screen: screens.NewSettings(m.cfg)})
+	m = updated.(rootModel)
+	before := m.current
+
+	updated, load := m.Update(extedit.EditedMsg{ID: configEditID, Path: path})
+	require.NotNil(t, load, "secrets are loaded in a task")
+	assert.Equal(t, config.DefaultConfig().LogLevel, updated.(rootModel).cfg.LogLevel, "applied once they are loaded")
+	updated, cmd := updated.(rootModel).Update(load())
+	root := updated.(rootModel)
+
+	assert.Equal(t, "debug", root.cfg.LogLevel)
+	assert.True(t, root.cfg.UI.CompactMode)
+	assert.NotSame(t, before, root.current, "settings reopen with the reloaded values")
+	require.NotNil(t, cmd)
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+	inner, ok := batch[0]().(tea.BatchMsg)
+	require.True(t, ok)
+	assert.Equal(t, status.KindSuccess, inner[0]().(status.Msg).Kind)
+}
+
+func TestRootModel_ConfigEdited_KeepsConfigWhenInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"logLevel": "loud"}`), 0o600))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	m := newRootModel(ctx, cancel, *config.DefaultConfig(), path, false)
+
+	updated, cmd := m.Update(extedit.EditedMsg{ID: configEditID, Path: path})
+	root := updated.(rootModel)
+
+	assert.Equal(t, config.DefaultConfig().LogLevel, root.cfg.LogLevel)
+	require.NotNil(t, cmd)
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+	assert.Equal(t, status.KindError, batch[0]().(status.Msg).Kind)
+}
+
+// --- key bindings ---
+
+func TestRootModel_DefaultKeyBindings_NoConflicts(t *testing.T) {
+	testModel(t)
+	assert.Empty(t, keys.Conflicts())
+}
+
+func TestRootModel_KeyOverrides_FileWinsOverConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, keys.SaveFile(config.KeysPath(path), keys.Overrides{"global": {"quit": {"ctrl+q"}}}))
+	cfg := *config.DefaultConfig()
+	cfg.Keys = map[string]map[string][]string{"global": {"quit": {"x"}, "help": {"h"}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	t.Cleanup(func() { _ = keys.SetOverrides() })
+
+	m := newRootModel(ctx, cancel, cfg, path, false)
+
+	assert.True(t, key.Matches(tea.KeyPressMsg{Code: 'q', Mod: tea.ModCtrl}, m.keys.Quit))
+	assert.False(t, key.Matches(tea.KeyPressMsg{Code: 'x', Text: "x"}, m.keys.Quit))
+	assert.True(t, key.Matches(tea.KeyPressMsg{Code: 'h', Text: "h"}, m.keys.Help))
+}
+
+func TestRootModel_KeyBindingsChanged_SavesAndRebinds(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := *config.DefaultConfig()
+	cfg.Keys = map[string]map[string][]string{"global": {"help": {"h"}}}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	t.Cleanup(func() { _ = keys.SetOverrides() })
+	m := newRootModel(ctx, cancel, cfg, path, false)
+
+	// help was reset on the screen, so it is missing from the overrides.
+	updated, cmd := m.Update(screens.KeyBindingsChangedMsg{Overrides: keys.Overrides{"global": {"quit": {"ctrl+q"}}}})
+	root := updated.(rootModel)
+
+	assert.True(t, key.Matches(tea.KeyPressMsg{Code: 'q', Mod: tea.ModCtrl}, root.keys.Quit))
+	assert.True(t, key.Matches(tea.KeyPressMsg{Code: '?', Text: "?"}, root.keys.Help))
+	saved, err := keys.LoadFile(config.KeysPath(path))
+	require.NoError(t, err)
+	assert.Equal(t, keys.Overrides{"global": {"quit": {"ctrl+q"}, "help": {"?"}}}, saved,
+		"a reset binding is saved with its defaults over the config's keys section")
+	require.NotNil(t, cmd)
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+	assert.Equal(t, status.KindSuccess, batch[0]().(status.Msg).Kind)
+}
+
+func TestRootModel_UserThemes_LoadedAndReloaded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	dir := config.ThemesDir(path)
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	write := func(name, primary string) {
+		body := `{"primary": "` + primary + `", "secondary": "#5B8DEF", "background": "#1B1A22", "surface": "#24232D", "foreground": "#F2F0F7"}`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name+".json"), []byte(body), 0o644))
+	}
+	write("dusk", "#E0A526")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.toml"), []byte("primary = "), 0o644))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	t.Cleanup(func() { theme.LoadThemes(t.TempDir()) })
+
+	m := newRootModel(ctx, cancel, *config.DefaultConfig(), path, false)
+	assert.Contains(t, theme.AvailableThemes(), "dusk")
+	require.NotEmpty(t, m.themeWarns)
+	assert.Contains(t, m.themeWarns[0], "broken.toml")
+
+	updated, _ := m.setTheme("dusk")
+	m = updated.(rootModel)
+	write("dusk", "#D04848")
+	write("dawn", "#E0A526")
+	updated, cmd := m.Update(reloadThemesMsg{})
+	m = updated.(rootModel)
+	require.NotNil(t, cmd)
+	assert.Contains(t, theme.AvailableThemes(), "dawn")
+	assert.Equal(t, lipgloss.Color("#D04848"), m.themeMgr.State().Palette.Primary,
+		"the theme in use is rebuilt from its file")
+}
+
+func TestRootModel_ColorProfile_DegradesPalette(t *testing.T) {
+	m := readyModel(t)
+	t.Cleanup(func() { m.themeMgr.SetProfile(colorprofile.Unknown) })
+
+	updated, cmd := m.Update(tea.ColorProfileMsg{Profile: colorprofile.ASCII})
+	require.NotNil(t, cmd)
+	changed, ok := cmd().(theme.ThemeChangedMsg)
+	require.True(t, ok)
+	assert.True(t, changed.State.Palette.Monochrome(), "NO_COLOR selects the monochrome palette")
+
+	updated, _ = updated.(rootModel).Update(changed)
+	assert.True(t, updated.(rootModel).styles.StatusLeft.GetReverse(), "fills turn into reverse video")
+
+	_, cmd = updated.(rootModel).Update(tea.ColorProfileMsg{Profile: colorprofile.ANSI})
+	require.NotNil(t, cmd)
+	assert.IsType(t, ansi.BasicColor(0), cmd().(theme.ThemeChangedMsg).State.Palette.Primary)
+}
+
+func TestRootModel_SaveConfig_WritesSecretsInTask(t *testing.T) {
+	t.Setenv(config.SecretStoreEnv, "file")
+	t.Setenv(config.SecretKeyEnv, "")
+	m := testModel(t)
+	m.configPath = filepath.Join(t.TempDir(), "config.json")
+	m.cfg.Network.APIToken = "tok-123"
+
+	cmd, err := m.saveConfig()
+	require.NoError(t, err)
+	require.NotNil(t, cmd)
+	store := config.NewSecretStore(m.configPath)
+	_, err = store.Get("network.apiToken")
+	assert.ErrorIs(t, err, config.ErrSecretNotFound, "secrets are not written on the UI loop")
+
+	_, ok := cmd().(task.DoneMsg[struct{}])
+	require.True(t, ok)
+	got, err := store.Get("network.apiToken")
+	require.NoError(t, err)
+	assert.Equal(t, "tok-123", got)
+}
+
+func TestRootModel_WelcomeDone_WithProfileWritesBaseConfig(t *testing.T) {
+	m := testModel(t)
+	m.configPath = filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, config.CreateProfile(m.configPath, "dev"))
+	m.cfg = *config.DefaultConfig()
+	m.cfg.Profile = "dev"
+
+	updated, _ := m.Update(screens.WelcomeDoneMsg{})
+	root := updated.(rootModel)
+
+	assert.False(t, config.IsFirstRun(root.configPath), "the welcome screen is not shown again")
+	base, err := config.Load(root.configPath)
+	require.NoError(t, err)
+	assert.False(t, config.NeedsUpgrade(base))
+}
+
+func TestRootModel_UnavailableProfile_BlocksSavingUntilPicked(t *testing.T) {
+	t.Setenv(config.SecretStoreEnv, "file")
+	t.Setenv(config.SecretKeyEnv, "")
+	path := filepath.Join(t.TempDir(), "config.json")
+	cfg := config.DefaultConfig()
+	cfg.Profile = "gone"
+	require.NoError(t, config.Save(cfg, path))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	m := newRootModel(ctx, cancel, *cfg, path, false)
+	require.ErrorIs(t, m.profileErr, config.ErrProfileNotFound)
+	assert.Equal(t, "gone", m.cfg.Profile, "the selection is kept")
+
+	_, err := m.saveConfig()
+	assert.ErrorIs(t, err, config.ErrProfileNotFound)
+	assert.NoFileExists(t, config.ProfilePath(path, "gone"), "no overlay is written for the missing profile")
+
+	updated, load := m.handleProfileSwitch("")
+	require.NotNil(t, load)
+	updated, _ = updated.(rootModel).Update(load())
+	m = updated.(rootModel)
+	_, err = m.saveConfig()
+	assert.NoError(t, err, "picking a profile allows saving again")
+}
+
+func TestRootModel_ProfileSwitch_KeepsGoingWithUnreadSecrets(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	require.NoError(t, config.Save(config.DefaultConfig(), path))
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	m := newRootModel(ctx, cancel, *config.DefaultConfig(), path, false)
+	m.profileErr = config.ErrProfileNotFound
+
+	cfg := config.DefaultConfig()
+	cfg.LogLevel = "debug"
+	unread := &config.SecretsError{Keys: []string{"network.apiToken"}, Err: errors.New("keyring locked")}
+	updated, cmd := m.Update(task.DoneMsg[loadedConfig]{
+		Label: switchProfileLabel,
+		Value: loadedConfig{cfg: cfg, secretsErr: unread},
+	})
+	m = updated.(rootModel)
+
+	assert.Equal(t, "debug", m.cfg.LogLevel, "the profile is switched to")
+	assert.Nil(t, m.profileErr)
+	assert.Equal(t, []string{"network.apiToken"}, config.UnreadSecrets(m.secretsErr), "unread secrets are not saved over")
+	require.NotNil(t, cmd)
+	batch, ok := cmd().(tea.BatchMsg)
+	require.True(t, ok)
+	assert.Equal(t, status.KindWarning, batch[0]().(status.Msg).Kind)
+}
+
+func TestRootModel_KeyBindings_RecordsGlobalKeys(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(NavigateMsg{Screen: screens.NewKeyBindings()})
+	updated, _ = updated.(rootModel).Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	_, cmd := updated.(rootModel).Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	require.NotNil(t, cmd)
+	_, ok := cmd().(screens.KeyBindingsChangedMsg)
+	assert.True(t, ok, "q is recorded, not quit")
+}
+
+// --- built-in editor ---
+
+func TestRootModel_Editor_CapturesPrintableKeys(t *testing.T) {
+	m := testModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+	ed := screens.NewEditor(m.ctx, "t", "Notes", "", m.cfg.Editor, nil)
+	updated, _ = m.Update(NavigateMsg{Screen: ed})
+	m = updated.(rootModel)
+	ed.Init()
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	root := updated.(rootModel)
+
+	if cmd != nil {
+		_, quit := cmd().(tea.QuitMsg)
+		assert.False(t, quit, "q is text while the editor is focused")
+	}
+	assert.Contains(t, root.current.Body(), "q")
+}
+
+func TestRootModel_Editor_SavesAgainAfterFailure(t *testing.T) {
+	m := testModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+	failing := func(context.Context, string) error { return errors.New("disk full") }
+	ed := screens.NewEditor(m.ctx, "t", "Notes", "", m.cfg.Editor, failing)
+	updated, _ = m.Update(NavigateMsg{Screen: ed})
+	m = updated.(rootModel)
+	ed.Init()
+
+	ctrlS := tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl}
+	updated, cmd := m.Update(ctrlS)
+	m = updated.(rootModel)
+	require.NotNil(t, cmd, "the first ctrl+s saves")
+
+	updated, _ = m.Update(task.ErrMsg{Label: "editor-save:t", Err: errors.New("disk full")})
+	m = updated.(rootModel)
+
+	_, cmd = m.Update(ctrlS)
+	assert.NotNil(t, cmd, "the failed save no longer blocks ctrl+s")
+}
+
+func TestRootModel_Editor_FitsInsideBodyFrame(t *testing.T) {
+	m := readyModel(t)
+	ed := screens.NewEditor(m.ctx, "t", "Notes", strings.Repeat("x", 200), m.cfg.Editor, nil)
+	updated, _ := m.Update(NavigateMsg{Screen: ed})
+	m = updated.(rootModel)
+
+	inner := m.styles.MaxWidth - m.styles.Body.GetHorizontalFrameSize()
+	for _, line := range strings.Split(m.current.Body(), "\n") {
+		assert.LessOrEqual(t, lipgloss.Width(line), inner)
+	}
+}
+
+func TestRootModel_EditorDirty_ShownUntilLeft(t *testing.T) {
+	m := testModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+	updated, _ = m.Update(theme.ThemeChangedMsg{State: m.themeMgr.State()})
+	m = updated.(rootModel)
+
+	updated, _ = m.Update(editor.DirtyMsg{ID: "notes", Dirty: true})
+	root := updated.(rootModel)
+	assert.Contains(t, root.statusbar.View().Content, "[modified]")
+
+	updated, _ = root.Update(screens.BackMsg{})
+	root = updated.(rootModel)
+	assert.NotContains(t, root.statusbar.View().Content, "[modified]")
+}
+
+func TestRootModel_LanguagePreview_RelocalizesEveryScreen(t *testing.T) {
+	t.Cleanup(func() { i18n.SetLanguage(i18n.Fallback) })
+	m := testModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+	home := m.current.(*screens.Home)
+	updated, _ = m.Update(NavigateMsg{Screen: screens.NewSettings(m.cfg)})
+	m = updated.(rootModel)
+
+	preview := m.cfg
+	preview.UI.Language = "de"
+	updated, _ = m.Update(screens.SettingsPreviewMsg{Cfg: preview})
+	root := updated.(rootModel)
+
+	assert.Equal(t, "de", i18n.Language())
+	assert.Equal(t, "beenden", root.keys.Quit.Help().Desc)
+	assert.Contains(t, home.Body(), "Übersicht", "screens below the top of the stack are relocalized too")
+
+	updated, _ = root.Update(screens.BackMsg{})
+	root = updated.(rootModel)
+	assert.Equal(t, i18n.Fallback, i18n.Language(), "leaving settings reverts the language preview")
+	assert.Equal(t, "quit", root.keys.Quit.Help().Desc)
+	assert.Contains(t, home.Body(), "Dashboard")
+}
+
+// --- compact mode ---
+
+// themed sends a ThemeChangedMsg for the default theme at 80 columns.
+func themed(t *testing.T, m rootModel, compact bool) rootModel {
+	t.Helper()
+	updated, _ := m.Update(theme.ThemeChangedMsg{State: theme.State{
+		Name:    "default",
+		IsDark:  true,
+		Palette: theme.NewPalette("default", true),
+		Width:   80,
+		Compact: compact,
+	}})
+	return updated.(rootModel)
+}
+
+func TestRootModel_CompactMode_GainsBodyRows(t *testing.T) {
+	m := testModel(t)
+	m.cfg.App.Name = "Scaffold"
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+
+	normal := themed(t, m, false)
+	// Screens are shared between the two models, so measure before switching.
+	normalMenuH := lipgloss.Height(normal.current.Body())
+	m.cfg.UI.CompactMode = true
+	compact := themed(t, m, true)
+
+	assert.Equal(t, 1, compact.statusbar.Height(), "compact footer has no margin or border")
+	assert.Less(t, compact.header.Height(), normal.header.Height())
+	assert.Greater(t, compact.bodyH, normal.bodyH)
+	assert.Less(t, lipgloss.Height(compact.current.Body()), normalMenuH,
+		"compact menu items are single lines without spacing")
+}
+
+func TestRootModel_CompactMode_RelaxesCapOnlyOnShortTerminals(t *testing.T) {
+	floor := minBodyLines + compactSavings(theme.NewPalette("default", true))
+	for _, tc := range []struct {
+		name   string
+		height int
+		want   int
+	}{
+		{"short", 14, floor},
+		{"tall", 80, 80 * maxBodyPercent / 100},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			m := testModel(t)
+			m.cfg.App.Name = "Scaffold"
+			updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: tc.height})
+			m = updated.(rootModel)
+			m.cfg.UI.CompactMode = true
+			m.cfg.UI.ShowHelpBar = true
+			compact := themed(t, m, true)
+
+			require.Greater(t, tc.height-compact.header.Height()-compact.statusbar.Height()-lipgloss.Height(compact.helpBar()),
+				tc.want, "the chrome leaves more rows than the cap allows")
+			assert.Equal(t, tc.want, compact.bodyHeight())
+		})
+	}
+	assert.Greater(t, floor, 14*maxBodyPercent/100, "the short terminal is above the normal cap")
+}
+
+func TestRootModel_CompactPreview_RequestsDensityChange(t *testing.T) {
+	m := testModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = updated.(rootModel)
+	m.themeMgr.SetCompact(false)
+	t.Cleanup(func() { m.themeMgr.SetCompact(false) })
+
+	preview := m.cfg
+	preview.UI.CompactMode = true
+	_, cmd := m.Update(screens.SettingsPreviewMsg{Cfg: preview})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(theme.ThemeChangedMsg)
+	require.True(t, ok)
+	assert.True(t, msg.State.Compact)
+}
+
+// --- mouse ---
+
+// readyModel returns a model sized 80×24 and themed, with mouse support
+// enabled.
+func readyModel(t *testing.T) rootModel {
+	t.Helper()
+	m := testModel(t)
+	m.cfg.UI.MouseEnabled = true
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	updated, _ = updated.(rootModel).Update(theme.ThemeChangedMsg{State: theme.State{
+		Name:    "default",
+		IsDark:  true,
+		Palette: theme.NewPalette("default", true),
+		Width:   80,
+	}})
+	return updated.(rootModel)
+}
+
+func TestRootModel_View_MouseModeFollowsConfig(t *testing.T) {
+	m := readyModel(t)
+	assert.Equal(t, tea.MouseModeCellMotion, m.View().MouseMode)
+
+	m.cfg.UI.MouseEnabled = false
+	assert.Equal(t, tea.MouseModeNone, m.View().MouseMode)
+}
+
+func TestRootModel_Mouse_IgnoredWhenDisabled(t *testing.T) {
+	m := readyModel(t)
+	m.cfg.UI.MouseEnabled = false
+	updated, _ := m.Update(modal.ShowMsg{ID: "x", Kind: modal.KindConfirm, Title: "T"})
+	m = updated.(rootModel)
+
+	x, y := modal.Origin(m.modal.View().Content, m.width, m.height)
+	_, cmd := m.Update(tea.MouseClickMsg{X: x + 3, Y: y + 5, Button: tea.MouseLeft})
+	assert.Nil(t, cmd)
+}
+
+func TestRootModel_Mouse_ClickModalButton(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(modal.ShowMsg{ID: "quit", Kind: modal.KindConfirm, Title: "Sure?"})
+	m = updated.(rootModel)
+	require.True(t, m.modal.Visible())
+
+	// The hint row "[y/enter] Yes   [n/esc] No" is the last content row of
+	// the dialog: border (1) + padding (1) above the bottom edge.
+	popup := m.modal.View().Content
+	x, y := modal.Origin(popup, m.width, m.height)
+	hintY := y + lipgloss.Height(popup) - 3
+	hintX := x + 3 // border (1) + padding (2)
+	noX := lipgloss.Width("[y/enter] "+i18n.T("modal.yes")+"   ") + 1
+
+	_, cmd := m.Update(tea.MouseClickMsg{X: hintX + noX, Y: hintY, Button: tea.MouseLeft})
+	require.NotNil(t, cmd)
+	assert.Equal(t, modal.CancelledMsg{ID: "quit"}, cmd(), "clicking [n] No must cancel")
+
+	_, cmd = m.Update(tea.MouseClickMsg{X: hintX + 1, Y: hintY, Button: tea.MouseLeft})
+	require.NotNil(t, cmd)
+	assert.Equal(t, modal.ConfirmedMsg{ID: "quit"}, cmd(), "clicking [y] Yes must confirm")
+}
+
+// tallScreen is a body taller than any terminal, for scroll tests.
+type tallScreen struct{ screens.Screen }
+
+func (tallScreen) Body() string { return strings.Repeat("line\n", 99) + "last" }
+
+func TestRootModel_Mouse_WheelScrollsOverflowingBody(t *testing.T) {
+	m := readyModel(t)
+	m.current = tallScreen{Screen: screens.NewHome()}
+	body := m.bodyRect()
+
+	updated, _ := m.Update(tea.MouseWheelMsg{X: body.X, Y: body.Y, Button: tea.MouseWheelDown})
+	m = updated.(rootModel)
+	assert.Equal(t, 1, m.bodyOffset())
+
+	updated, _ = m.Update(tea.MouseWheelMsg{X: body.X, Y: body.Y, Button: tea.MouseWheelUp})
+	updated, _ = updated.(rootModel).Update(tea.MouseWheelMsg{X: body.X, Y: body.Y, Button: tea.MouseWheelUp})
+	assert.Equal(t, 0, updated.(rootModel).bodyOffset(), "offset is clamped at the top")
+}
+
+func TestRootModel_Mouse_ClickMenuItemSelects(t *testing.T) {
+	m := readyModel(t)
+	home := screens.NewHome()
+	updated, _ := m.Update(NavigateMsg{Screen: home})
+	m = updated.(rootModel)
+	body := m.bodyRect()
+
+	// Items are two lines tall with one line of spacing; row 3 is item 1.
+	_, cmd := m.Update(tea.MouseClickMsg{X: body.X + 2, Y: body.Y + 3, Button: tea.MouseLeft})
+	require.NotNil(t, cmd)
+	sel, ok := cmd().(menu.SelectionMsg)
+	require.True(t, ok)
+	assert.Equal(t, "settings", sel.Item.ScreenID())
+}
+
+func TestRootModel_Home_JumpsToLastAndFirstItem(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(NavigateMsg{Screen: screens.NewHome()})
+	m = updated.(rootModel)
+	items := screens.HomeItems()
+	selected := func(m rootModel) string {
+		_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+		require.NotNil(t, cmd)
+		sel, ok := cmd().(menu.SelectionMsg)
+		require.True(t, ok)
+		return sel.Item.ScreenID()
+	}
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'G', Text: "G"})
+	m = updated.(rootModel)
+	assert.Equal(t, items[len(items)-1].ScreenID(), selected(m))
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	updated, cmd := updated.(rootModel).Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	require.NotNil(t, cmd)
+	msg := cmd()
+	assert.Equal(t, keys.ChordMsg{Keys: "g g"}, msg, "g g is a chord on the home menu")
+	updated, _ = updated.(rootModel).Update(msg)
+	assert.Equal(t, items[0].ScreenID(), selected(updated.(rootModel)))
+}
+
+func TestRootModel_Messages_ShowsStatusHistory(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(status.Msg{Text: "Settings saved", Kind: status.KindSuccess, At: time.Now()})
+	m = updated.(rootModel)
+
+	updated, _ = m.Update(menu.SelectionMsg{Item: menu.NewItem("Messages", "", "messages")})
+	m = updated.(rootModel)
+	require.IsType(t, &screens.Messages{}, m.current)
+	assert.Contains(t, ansi.Strip(m.current.Body()), "Settings saved")
+}
+
+// --- animation ---
+
+// finishFrames delivers frames until the animation loop stops, returning the
+// model and the number of frames it took.
+func finishFrames(t *testing.T, m rootModel) (rootModel, int) {
+	t.Helper()
+	at := time.Now()
+	for frames := 1; frames < 1000; frames++ {
+		at = at.Add(anim.FrameInterval)
+		updated, cmd := m.Update(anim.FrameMsg{At: at})
+		m = updated.(rootModel)
+		if cmd == nil {
+			return m, frames
+		}
+	}
+	t.Fatal("animation never finished")
+	return m, 0
+}
+
+func TestRootModel_Navigate_SlidesBodyIn(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(NavigateMsg{Screen: screens.NewHome()})
+	m = updated.(rootModel)
+
+	require.True(t, m.anims.ticking, "navigation starts the frame loop")
+	assert.InDelta(t, 1, m.anims.slide.Value(), 1e-9, "body starts off to the right")
+
+	m, _ = finishFrames(t, m)
+	assert.False(t, m.anims.ticking)
+	assert.Equal(t, m.current.Body(), m.slideBody(m.current.Body()), "finished slide leaves the body in place")
+
+	updated, _ = m.Update(screens.BackMsg{})
+	m = updated.(rootModel)
+	assert.InDelta(t, -1, m.anims.slide.Value(), 1e-9, "going back slides in from the left")
+}
+
+func TestRootModel_AnimationSpeedScalesFrames(t *testing.T) {
+	frames := map[string]int{}
+	for _, speed := range []string{"slow", "normal", "fast"} {
+		m := readyModel(t)
+		m.cfg.UI.AnimationSpeed = speed
+		updated, _ := m.Update(NavigateMsg{Screen: screens.NewHome()})
+		_, frames[speed] = finishFrames(t, updated.(rootModel))
+	}
+	assert.Greater(t, frames["slow"], frames["normal"])
+	assert.Greater(t, frames["normal"], frames["fast"])
+}
+
+func TestRootModel_AnimationSpeedNone_DisablesMotion(t *testing.T) {
+	m := readyModel(t)
+	m.cfg.UI.AnimationSpeed = "none"
+	m.cfg.UI.ShowBanner = true
+
+	updated, _ := m.Update(theme.ThemeChangedMsg{State: m.themeMgr.State()})
+	updated, _ = updated.(rootModel).Update(NavigateMsg{Screen: screens.NewHome()})
+	updated, _ = updated.(rootModel).Update(modal.ShowMsg{ID: "x", Kind: modal.KindAlert, Title: "T"})
+	m = updated.(rootModel)
+
+	assert.False(t, m.anims.ticking, "no frames are scheduled")
+	assert.Equal(t, m.modal.View().Content, m.modalPopup(), "modal appears at full size")
+
+	updated, _ = m.Update(modal.ConfirmedMsg{ID: "x"})
+	assert.Empty(t, updated.(rootModel).modalPopup(), "modal disappears immediately")
+}
+
+func TestRootModel_Modal_OpensAndCloses(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(modal.ShowMsg{ID: "x", Kind: modal.KindConfirm, Title: "T", Body: "body"})
+	m = updated.(rootModel)
+	full := m.modal.View().Content
+	assert.Empty(t, m.modalPopup(), "modal grows from nothing")
+
+	m, _ = finishFrames(t, m)
+	assert.Equal(t, full, m.modalPopup())
+
+	updated, _ = m.Update(modal.CancelledMsg{ID: "x"})
+	m = updated.(rootModel)
+	assert.False(t, m.modal.Visible())
+	assert.Equal(t, full, m.modalPopup(), "dismissed modal is still drawn while it shrinks")
+
+	m, _ = finishFrames(t, m)
+	assert.Empty(t, m.modalPopup())
+}
+
+// --- toasts ---
+
+func TestRootModel_Toast_DrawnOverBodyCorner(t *testing.T) {
+	m := readyModel(t)
+	updated, cmd := m.Update(toast.Info("Sync done", "42 items", 0)())
+	m = updated.(rootModel)
+	require.NotNil(t, cmd, "the toast starts its lifetime tick")
+
+	stack := m.toasts.View()
+	r, body := m.toastRect(stack), m.bodyRect()
+	assert.Equal(t, body.X+body.W, r.X+r.W, "flush with the body's right edge")
+	assert.Equal(t, body.Y+body.H, r.Y+r.H, "flush with the body's bottom edge")
+	assert.Contains(t, m.View().Content, "Sync done")
+}
+
+func TestRootModel_Toast_ClickRunsAction(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(toast.ShowMsg{Toast: toast.Toast{
+		Title:  "Config saved",
+		Action: &toast.Action{Label: "Open", Msg: screens.EditConfigMsg{}},
+	}})
+	m = updated.(rootModel)
+
+	r := m.toastRect(m.toasts.View())
+	updated, cmd := m.Update(tea.MouseClickMsg{X: r.X + 2, Y: r.Y + 1, Button: tea.MouseLeft})
+	require.NotNil(t, cmd)
+	assert.Equal(t, screens.EditConfigMsg{}, cmd())
+	assert.Zero(t, updated.(rootModel).toasts.Len())
+}
+
+func TestRootModel_ThemeChange_SweepsBanner(t *testing.T) {
+	m := readyModel(t)
+	m.cfg.UI.ShowBanner = true
+	updated, cmd := m.Update(theme.ThemeChangedMsg{State: m.themeMgr.State()})
+	m = updated.(rootModel)
+	require.NotNil(t, cmd)
+	assert.False(t, m.anims.banner.Done())
+
+	m, _ = finishFrames(t, m)
+	assert.True(t, m.anims.banner.Done())
+
+	state := m.themeMgr.State()
+	state.Width += 10
+	updated, _ = m.Update(theme.ThemeChangedMsg{State: state})
+	m = updated.(rootModel)
+	assert.True(t, m.anims.banner.Done(), "a resize does not sweep again")
+
+	state.IsDark = !state.IsDark
+	updated, _ = m.Update(theme.ThemeChangedMsg{State: state})
+	assert.False(t, updated.(rootModel).anims.banner.Done(), "switching the mode sweeps")
+}
+
+// --- help bar / full help ---
+
+func TestRootModel_HiddenHelpBar_ReclaimsRows(t *testing.T) {
+	m := readyModel(t)
+	m.cfg.UI.ShowHelpBar = true
+	shown := m.bodyHeight()
+	shownView := m.View().Content
+
+	m.cfg.UI.ShowHelpBar = false
+	assert.Empty(t, m.helpView())
+	assert.Equal(t, shown+lipgloss.Height(themedHelp(t, m)), m.bodyHeight())
+	assert.NotEqual(t, shownView, m.View().Content)
+}
+
+func TestRootModel_HiddenHelpBar_RaisesBodyCap(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 80})
+	m = updated.(rootModel)
+	m.cfg.UI.ShowHelpBar = true
+	shown := m.bodyHeight()
+	require.Equal(t, 80*maxBodyPercent/100, shown, "a tall terminal hits the cap")
+
+	m.cfg.UI.ShowHelpBar = false
+	assert.Equal(t, shown+lipgloss.Height(themedHelp(t, m)), m.bodyHeight(), "the hidden help bar's rows go to the body")
+}
+
+// themedHelp renders the help bar as it appears with ShowHelpBar on.
+func themedHelp(t *testing.T, m rootModel) string {
+	t.Helper()
+	m.cfg.UI.ShowHelpBar = true
+	return m.helpView()
+}
+
+func TestRootModel_QuestionMark_TogglesFullHelp(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(tea.KeyPressMsg{Code: '?', Text: "?"})
+	m = updated.(rootModel)
+	require.True(t, m.fullHelp.Visible())
+
+	view := m.View().Content
+	assert.Contains(t, view, i18n.T("help.global"))
+	assert.Contains(t, view, i18n.T("help.screen"), "the current screen's bindings are listed")
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	require.NotNil(t, cmd, "quit still works over the overlay")
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.False(t, updated.(rootModel).fullHelp.Visible(), "esc closes the overlay")
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: '?', Text: "?"})
+	assert.False(t, updated.(rootModel).fullHelp.Visible(), "? toggles the overlay")
+}
+
+func TestRootModel_FullHelp_ScrollsInShortTerminal(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 12})
+	updated, _ = updated.(rootModel).Update(NavigateMsg{Screen: screens.NewSettings(*config.DefaultConfig())})
+	updated, _ = updated.(rootModel).Update(tea.KeyPressMsg{Code: '?', Text: "?"})
+	m = updated.(rootModel)
+	require.True(t, m.fullHelp.Visible())
+	assert.LessOrEqual(t, lipgloss.Height(m.fullHelp.View().Content), 12)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	assert.Equal(t, 1, updated.(rootModel).fullHelp.ScrollOffset())
+}
+
+// --- command palette ---
+
+// paletteTitles returns the titles of the palette's current matches.
+func paletteTitles(m rootModel) []string {
+	var titles []string
+	for _, c := range m.palette.Matches() {
+		titles = append(titles, c.Title)
+	}
+	return titles
+}
+
+func TestRootModel_Palette_ListsBindingsScreensAndThemes(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	m = updated.(rootModel)
+	require.True(t, m.palette.Visible())
+
+	titles := paletteTitles(m)
+	assert.Contains(t, titles, i18n.T("keys.quit"))
+	assert.Contains(t, titles, i18n.T("palette.randomTheme"))
+	assert.Contains(t, titles, i18n.T("palette.screen", i18n.T("home.settings.title")))
+	assert.Contains(t, titles, i18n.T("palette.editConfig"), "registered commands are listed")
+	for _, name := range theme.AvailableThemes() {
+		assert.Contains(t, titles, i18n.T("palette.theme", name))
+	}
+	assert.NotContains(t, titles, i18n.T("keys.palette"), "the palette does not list itself")
+	assert.Contains(t, m.View().Content, i18n.T("palette.title"))
+}
+
+func TestRootModel_Palette_RunsThemeCommand(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	m = updated.(rootModel)
+	name := theme.AvailableThemes()[0]
+	for _, r := range i18n.T("palette.theme", name) {
+		updated, _ = m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		m = updated.(rootModel)
+	}
+	require.True(t, m.palette.Visible())
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(rootModel)
+	assert.False(t, m.palette.Visible(), "running a command closes the palette")
+	require.NotNil(t, cmd)
+	msg := cmd()
+	require.Equal(t, screens.UseThemeMsg{Name: name}, msg)
+
+	updated, _ = m.Update(msg)
+	assert.Equal(t, name, updated.(rootModel).cfg.UI.ThemeName)
+}
+
+func TestRootModel_Palette_ReplaysBindingKeys(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	m = updated.(rootModel)
+	for _, c := range m.palette.Matches() {
+		if c.Title == i18n.T("keys.help") {
+			msg := c.Cmd()
+			assert.True(t, key.Matches(msg.(paletteKeyMsg).press, m.keys.Help))
+			return
+		}
+	}
+	t.Fatal("help binding not listed")
+}
+
+func TestRootModel_Palette_BindingsSkipFocusedTextField(t *testing.T) {
+	m := readyModel(t)
+	ed := screens.NewEditor(m.ctx, "t", "Notes", "", m.cfg.Editor, nil)
+	updated, _ := m.Update(NavigateMsg{Screen: ed})
+	m = updated.(rootModel)
+	ed.Init()
+
+	run := func(m rootModel, title string) (rootModel, tea.Cmd) {
+		t.Helper()
+		updated, _ := m.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+		m = updated.(rootModel)
+		for _, c := range m.palette.Matches() {
+			if c.Title == title {
+				updated, run := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+				require.NotNil(t, run)
+				updated, cmd := updated.(rootModel).Update(run())
+				return updated.(rootModel), cmd
+			}
+			updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+			m = updated.(rootModel)
+		}
+		t.Fatalf("%s not listed", title)
+		return m, nil
+	}
+
+	m, _ = run(m, i18n.T("keys.help"))
+	assert.True(t, m.fullHelp.Visible(), "help opens instead of typing ?")
+	assert.NotContains(t, m.current.Body(), "?")
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = updated.(rootModel)
+	require.False(t, m.fullHelp.Visible())
+
+	m, cmd := run(m, i18n.T("keys.quit"))
+	assert.True(t, isQuit(cmd), "quit quits instead of typing q")
+	assert.NotContains(t, m.current.Body(), "q")
+}
+
+func TestRootModel_Palette_TypingQDoesNotQuit(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(tea.KeyPressMsg{Code: 'p', Mod: tea.ModCtrl})
+	updated, cmd := updated.(rootModel).Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	m = updated.(rootModel)
+	assert.True(t, m.palette.Visible())
+	if cmd != nil {
+		assert.NotEqual(t, tea.Quit(), cmd())
+	}
+	assert.Contains(t, paletteTitles(m), i18n.T("keys.quit"), `"q" filters the list`)
+}
+
+// --- chords ---
+
+func TestRootModel_LeaderChord_ShowsWhichKeyAndOpensThemes(t *testing.T) {
+	m := readyModel(t)
+	m.cfg.UI.ShowHelpBar = true
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'x', Mod: tea.ModCtrl})
+	m = updated.(rootModel)
+	require.NotNil(t, cmd, "the pending chord times out")
+	require.True(t, m.whichKey.Visible())
+	view := m.View().Content
+	assert.Contains(t, view, i18n.T("keys.themes"), "the popup lists the continuations")
+	assert.Contains(t, m.helpView(), "ctrl+x t", "the help bar shows the whole chord")
+
+	updated, cmd = m.Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+	m = updated.(rootModel)
+	assert.False(t, m.whichKey.Visible())
+	require.NotNil(t, cmd)
+	updated, _ = m.Update(cmd())
+	m = updated.(rootModel)
+	require.True(t, m.palette.Visible())
+	for _, c := range m.palette.Matches() {
+		assert.True(t, strings.HasPrefix(c.Title, i18n.T("palette.theme", "")), c.Title)
+	}
+}
+
+func TestRootModel_Space_IsNotHeldForAChord(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+	assert.Nil(t, updated.(rootModel).chords.Pending(), "space reaches the screen at once")
+}
+
+func TestRootModel_LeaderChord_WorksInTextField(t *testing.T) {
+	m := readyModel(t)
+	ed := screens.NewEditor(m.ctx, "t", "Notes", "", m.cfg.Editor, nil)
+	updated, _ := m.Update(NavigateMsg{Screen: ed})
+	m = updated.(rootModel)
+	ed.Init()
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'x', Mod: tea.ModCtrl})
+	updated, cmd := updated.(rootModel).Update(tea.KeyPressMsg{Code: 't', Text: "t"})
+	m = updated.(rootModel)
+	require.NotNil(t, cmd)
+	updated, _ = m.Update(cmd())
+	m = updated.(rootModel)
+	assert.True(t, m.palette.Visible(), "the chord opens the themes")
+	buffer := strings.Split(ansi.Strip(m.current.Body()), "\n")[2]
+	assert.Empty(t, strings.TrimSpace(buffer), "t completes the chord instead of being typed")
+}
+
+func TestRootModel_Chord_ReachesScreen(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(NavigateMsg{Screen: screens.NewKeyBindings()})
+	updated, _ = updated.(rootModel).Update(tea.KeyPressMsg{Code: 'G', Text: "G"})
+	m = updated.(rootModel)
+	kb := m.current.(*screens.KeyBindings)
+	atBottom := kb.Body()
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	updated, cmd := updated.(rootModel).Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	m = updated.(rootModel)
+	require.NotNil(t, cmd)
+	msg := cmd()
+	assert.Equal(t, keys.ChordMsg{Keys: "g g"}, msg)
+	m.Update(msg)
+	assert.NotEqual(t, atBottom, kb.Body(), "g g moved to the top")
+}
+
+func TestRootModel_BrokenChord_ReplaysKeys(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(NavigateMsg{Screen: screens.NewKeyBindings()})
+	updated, _ = updated.(rootModel).Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	m = updated.(rootModel)
+	require.Equal(t, []string{"g"}, m.chords.Pending())
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	assert.Nil(t, updated.(rootModel).chords.Pending())
+	require.NotNil(t, cmd)
+	_, ok := cmd().(screens.KeyBindingsChangedMsg)
+	assert.True(t, ok, "x still unbinds after the unfinished g")
+}
+
+// --- key dispatch ---
+
+// isQuit reports whether cmd quits the program.
+func isQuit(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	switch msg := cmd().(type) {
+	case tea.QuitMsg:
+		return true
+	case tea.BatchMsg:
+		for _, c := range msg {
+			if isQuit(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func TestRootModel_SettingsSearch_CapturesGlobalKeys(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(NavigateMsg{Screen: screens.NewSettings(*config.DefaultConfig())})
+	updated, _ = updated.(rootModel).Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	m = updated.(rootModel)
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	m = updated.(rootModel)
+	assert.False(t, isQuit(cmd), "q is part of the query")
+	updated, _ = m.Update(tea.KeyPressMsg{Code: '?', Text: "?"})
+	m = updated.(rootModel)
+	assert.False(t, m.fullHelp.Visible(), "? is part of the query")
+	assert.Contains(t, ansi.Strip(m.current.Body()), "/ q?")
+}
+
+func TestRootModel_MenuFilter_CapturesGlobalKeys(t *testing.T) {
+	m := readyModel(t)
+	updated, _ := m.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	updated, cmd := updated.(rootModel).Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	assert.False(t, isQuit(cmd), "q is the filter query")
+	assert.True(t, updated.(rootModel).current.(screens.KeyCapturer).CapturesKey(tea.KeyPressMsg{}))
+}
+
+func TestRootModel_Esc_BubblesToGlobalBack(t *testing.T) {
+	m := readyModel(t)
+	home := m.current
+	updated, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = updated.(rootModel)
+	assert.Same(t, home, m.current, "esc on the root screen does nothing")
+
+	updated, _ = m.Update(NavigateMsg{Screen: screens.NewKeyBindings()})
+	updated, _ = updated.(rootModel).Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = updated.(rootModel)
+	assert.Same(t, home, m.current, "a screen without its own esc handling goes back")
+	assert.Zero(t, m.stack.Len())
+}
+
+func TestRootModel_Esc_LeavesLoadingDetailAndCancelsLoad(t *testing.T) {
+	m := readyModel(t)
+	home := m.current
+	detail := screens.NewDetail("title", "desc", "id", m.ctx)
+	updated, _ := m.Update(NavigateMsg{Screen: detail})
+	m = updated.(rootModel)
+	initCmd := detail.Init()
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = updated.(rootModel)
+	assert.Same(t, home, m.current, "esc leaves the screen while it loads")
+
+	// The load, which takes 1.5s when left alone, ends at once.
+	results := make(chan tea.Msg, 8)
+	for _, cmd := range initCmd().(tea.BatchMsg) {
+		go func() { results <- cmd() }()
+	}
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case msg := <-results:
+			if errMsg, ok := msg.(task.ErrMsg); ok {
+				assert.ErrorIs(t, errMsg.Err, context.Canceled)
+				updated, cmd := m.Update(errMsg)
+				assert.Same(t, home, updated.(rootModel).current)
+				assert.Nil(t, cmd, "a cancelled load is not reported")
+				return
+			}
+		case <-timeout:
+			t.Fatal("the load was not cancelled")
+		}
+	}
+}
+
+func TestRootModel_ScreenBindingsBeforeGlobal(t *testing.T) {
+	m := readyModel(t)
+	ed := screens.NewEditor(m.ctx, "t", "Notes", "", m.cfg.Editor, nil)
+	updated, _ := m.Update(NavigateMsg{Screen: ed})
+	m = updated.(rootModel)
+	ed.Init()
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	m = updated.(rootModel)
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	m = updated.(rootModel)
+	assert.Same(t, ed, m.current, "the editor's own back binding asks first")
+	require.NotNil(t, cmd)
+	show, ok := cmd().(modal.ShowMsg)
+	require.True(t, ok, "unsaved changes are guarded by a confirm modal")
+	assert.Equal(t, "discard-editor", show.ID)
+
+	updated, cmd = m.Update(modal.ConfirmedMsg{ID: "discard-editor"})
+	require.NotNil(t, cmd)
+	updated, _ = updated.(rootModel).Update(cmd())
+	assert.NotSame(t, ed, updated.(rootModel).current, "confirming discards and leaves")
+}
@@ -0,0 +1,88 @@
+// Package mouse provides hit-testing helpers for mouse events.
+//
+// rootModel translates terminal coordinates into component-local ones with
+// Translate before forwarding a mouse message, so every component hit-tests
+// against its own rendered layout with Rect and Zones.
+package mouse
+
+import tea "charm.land/bubbletea/v2"
+
+// Rect is a rectangular region in cell coordinates.
+type Rect struct {
+	X, Y, W, H int
+}
+
+// Contains reports whether the cell (x, y) lies inside r.
+func (r Rect) Contains(x, y int) bool {
+	return x >= r.X && x < r.X+r.W && y >= r.Y && y < r.Y+r.H
+}
+
+// Zones is an ordered set of named hit regions. Later zones win when
+// regions overlap, matching how later-drawn content sits on top.
+type Zones struct {
+	ids   []string
+	rects []Rect
+}
+
+// Add registers a named region.
+func (z *Zones) Add(id string, r Rect) {
+	z.ids = append(z.ids, id)
+	z.rects = append(z.rects, r)
+}
+
+// Hit returns the ID of the topmost zone containing (x, y).
+func (z Zones) Hit(x, y int) (string, bool) {
+	for i := len(z.rects) - 1; i >= 0; i-- {
+		if z.rects[i].Contains(x, y) {
+			return z.ids[i], true
+		}
+	}
+	return "", false
+}
+
+// Translate returns msg with its position moved by (-dx, -dy), making it
+// relative to a component whose top-left corner is at (dx, dy). Messages
+// other than the four tea mouse events are returned unchanged.
+func Translate(msg tea.MouseMsg, dx, dy int) tea.MouseMsg {
+	switch m := msg.(type) {
+	case tea.MouseClickMsg:
+		m.X, m.Y = m.X-dx, m.Y-dy
+		return m
+	case tea.MouseReleaseMsg:
+		m.X, m.Y = m.X-dx, m.Y-dy
+		return m
+	case tea.MouseWheelMsg:
+		m.X, m.Y = m.X-dx, m.Y-dy
+		return m
+	case tea.MouseMotionMsg:
+		m.X, m.Y = m.X-dx, m.Y-dy
+		return m
+	}
+	return msg
+}
+
+// LeftClick reports whether msg is a left-button press and returns its
+// position.
+func LeftClick(msg tea.Msg) (x, y int, ok bool) {
+	click, ok := msg.(tea.MouseClickMsg)
+	if !ok || click.Button != tea.MouseLeft {
+		return 0, 0, false
+	}
+	return click.X, click.Y, true
+}
+
+// WheelDelta returns -1 for wheel-up, +1 for wheel-down and 0 for any other
+// message.
+func WheelDelta(msg tea.Msg) int {
+	wheel, ok := msg.(tea.MouseWheelMsg)
+	if !ok {
+		return 0
+	}
+	switch wheel.Button {
+	case tea.MouseWheelUp:
+		return -1
+	case tea.MouseWheelDown:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,55 @@
+package mouse
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRect_Contains(t *testing.T) {
+	r := Rect{X: 2, Y: 1, W: 3, H: 2}
+	assert.True(t, r.Contains(2, 1))
+	assert.True(t, r.Contains(4, 2))
+	assert.False(t, r.Contains(5, 1), "right edge is exclusive")
+	assert.False(t, r.Contains(2, 3), "bottom edge is exclusive")
+	assert.False(t, r.Contains(1, 1))
+}
+
+func TestZones_LaterZoneWins(t *testing.T) {
+	var z Zones
+	z.Add("back", Rect{X: 0, Y: 0, W: 10, H: 10})
+	z.Add("front", Rect{X: 2, Y: 2, W: 2, H: 2})
+
+	id, ok := z.Hit(3, 3)
+	assert.True(t, ok)
+	assert.Equal(t, "front", id)
+
+	id, _ = z.Hit(0, 0)
+	assert.Equal(t, "back", id)
+
+	_, ok = z.Hit(20, 20)
+	assert.False(t, ok)
+}
+
+func TestTranslate_PreservesEventType(t *testing.T) {
+	click := tea.MouseClickMsg{X: 10, Y: 5, Button: tea.MouseLeft}
+	got := Translate(click, 3, 2)
+	assert.Equal(t, tea.MouseClickMsg{X: 7, Y: 3, Button: tea.MouseLeft}, got)
+
+	wheel := tea.MouseWheelMsg{X: 1, Y: 1, Button: tea.MouseWheelDown}
+	assert.IsType(t, tea.MouseWheelMsg{}, Translate(wheel, 1, 1))
+}
+
+func TestLeftClick_And_WheelDelta(t *testing.T) {
+	_, _, ok := LeftClick(tea.MouseClickMsg{Button: tea.MouseRight})
+	assert.False(t, ok, "right button is not a left click")
+
+	x, y, ok := LeftClick(tea.MouseClickMsg{X: 4, Y: 2, Button: tea.MouseLeft})
+	assert.True(t, ok)
+	assert.Equal(t, [2]int{4, 2}, [2]int{x, y})
+
+	assert.Equal(t, -1, WheelDelta(tea.MouseWheelMsg{Button: tea.MouseWheelUp}))
+	assert.Equal(t, 1, WheelDelta(tea.MouseWheelMsg{Button: tea.MouseWheelDown}))
+	assert.Equal(t, 0, WheelDelta(tea.KeyPressMsg{}))
+}
@@ -0,0 +1,132 @@
+// Package ui — command palette contents.
+package ui
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/cmdpalette"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/menu"
+	"scaffold/internal/ui/screens"
+	"scaffold/internal/ui/theme"
+)
+
+// reloadThemesMsg reloads the user theme files.
+type reloadThemesMsg struct{}
+
+// paletteKeyMsg is a key press replayed by a palette command. It skips the
+// capture phase and chords, so choosing "Quit" quits even while a text
+// field has focus, instead of typing "q" into it.
+type paletteKeyMsg struct {
+	press tea.KeyPressMsg
+}
+
+func init() {
+	cmdpalette.Register(func() []cmdpalette.Command {
+		return []cmdpalette.Command{{
+			Title: i18n.T("palette.editConfig"),
+			Cmd:   func() tea.Msg { return screens.EditConfigMsg{} },
+		}, {
+			Title: i18n.T("palette.reloadThemes"),
+			Cmd:   func() tea.Msg { return reloadThemesMsg{} },
+		}}
+	})
+}
+
+// openPalette opens the command palette with the global bindings, the
+// current screen's bindings, the home screens, the themes and the
+// registered commands.
+func (m rootModel) openPalette() rootModel {
+	cmds := append(m.paletteCommands(), cmdpalette.Registered()...)
+	m.palette = cmdpalette.New(cmds, m.themeMgr.State().Palette)
+	return m
+}
+
+// openThemePalette opens the command palette on the themes alone.
+func (m rootModel) openThemePalette() rootModel {
+	m.palette = cmdpalette.New(themeCommands(), m.themeMgr.State().Palette)
+	return m
+}
+
+// keyCmd returns a command that acts as if the binding of k had been
+// pressed: a paletteKeyMsg, or a ChordMsg for a chord.
+func keyCmd(k string) (tea.Cmd, bool) {
+	if keys.IsChord(k) {
+		return func() tea.Msg { return keys.ChordMsg{Keys: k} }, true
+	}
+	press, ok := keys.Press(k)
+	if !ok {
+		return nil, false
+	}
+	return func() tea.Msg { return paletteKeyMsg{press: press} }, true
+}
+
+// paletteCommands collects the commands rootModel contributes to the
+// palette. Bindings run by replaying their first key past the focused
+// widget, so they do what the binding does rather than what the key would
+// type.
+func (m rootModel) paletteCommands() []cmdpalette.Command {
+	var cmds []cmdpalette.Command
+	seen := map[string]bool{}
+	addBindings := func(section string, groups [][]key.Binding) {
+		for _, g := range groups {
+			for _, b := range g {
+				h := b.Help()
+				if !b.Enabled() || h.Desc == "" || seen[h.Key] {
+					continue
+				}
+				cmd, ok := keyCmd(b.Keys()[0])
+				if !ok {
+					continue
+				}
+				seen[h.Key] = true
+				cmds = append(cmds, cmdpalette.Command{
+					Title: h.Desc,
+					Desc:  section,
+					Key:   h.Key,
+					Cmd:   cmd,
+				})
+			}
+		}
+	}
+
+	global := m.keys
+	global.Palette.SetEnabled(false)
+	if kb, ok := m.current.(screens.KeyBinder); ok {
+		addBindings(i18n.T("help.screen"), kb.FullHelp())
+	}
+	addBindings(i18n.T("help.global"), global.FullHelp())
+	if global.RandomTheme.Enabled() {
+		if cmd, ok := keyCmd(global.RandomTheme.Keys()[0]); ok {
+			cmds = append(cmds, cmdpalette.Command{
+				Title: i18n.T("palette.randomTheme"),
+				Desc:  i18n.T("help.global"),
+				Key:   global.RandomTheme.Keys()[0],
+				Cmd:   cmd,
+			})
+		}
+	}
+
+	for _, item := range screens.HomeItems() {
+		cmds = append(cmds, cmdpalette.Command{
+			Title: i18n.T("palette.screen", item.Title()),
+			Desc:  item.Description(),
+			Cmd:   func() tea.Msg { return menu.SelectionMsg{Item: item} },
+		})
+	}
+	return append(cmds, themeCommands()...)
+}
+
+// themeCommands returns a command per theme that switches to it.
+func themeCommands() []cmdpalette.Command {
+	var cmds []cmdpalette.Command
+	for _, name := range theme.AvailableThemes() {
+		cmds = append(cmds, cmdpalette.Command{
+			Title: i18n.T("palette.theme", name),
+			Cmd:   func() tea.Msg { return screens.UseThemeMsg{Name: name} },
+		})
+	}
+	return cmds
+}
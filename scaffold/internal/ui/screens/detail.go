@@ -2,13 +2,14 @@ package screens
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"scaffold/internal/i18n"
 	"scaffold/internal/task"
+	"scaffold/internal/timefmt"
 	"scaffold/internal/ui/spinner"
 	"scaffold/internal/ui/theme"
 )
@@ -21,6 +22,7 @@ type Detail struct {
 	theme.ThemeAware
 
 	ctx         context.Context
+	cancel      context.CancelFunc
 	title       string
 	description string
 	screenID    string
@@ -30,11 +32,13 @@ type Detail struct {
 	styles      theme.DetailStyles
 }
 
-// NewDetail creates a new Detail screen. ctx is used to cancel the load task
-// if the user navigates away or quits before it completes.
+// NewDetail creates a new Detail screen. The load task is cancelled when ctx
+// is, on quit, or when the screen is closed by navigating away.
 func NewDetail(title, description, screenID string, ctx context.Context) *Detail {
+	ctx, cancel := context.WithCancel(ctx)
 	return &Detail{
 		ctx:         ctx,
+		cancel:      cancel,
 		title:       title,
 		description: description,
 		screenID:    screenID,
@@ -55,6 +59,11 @@ func (d *Detail) ApplyTheme(state theme.State) {
 	d.styles = theme.NewDetailStylesFromPalette(state.Palette)
 }
 
+// Close implements Closer: it cancels the load task.
+func (d *Detail) Close() {
+	d.cancel()
+}
+
 // tickCmd returns a command that fires detailTickMsg after one second,
 // demonstrating the canonical periodic-task pattern with tea.Tick.
 func tickCmd() tea.Cmd {
@@ -112,12 +121,6 @@ func (d *Detail) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return d, cmd
 	}
 
-	if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
-		switch keyMsg.String() {
-		case "esc":
-			return d, func() tea.Msg { return BackMsg{} }
-		}
-	}
 	return d, nil
 }
 
@@ -129,16 +132,16 @@ func (d *Detail) View() tea.View {
 // Body returns the body content for layout composition.
 func (d *Detail) Body() string {
 	if d.load.Active() {
-		label := fmt.Sprintf("Loading… %ds", d.elapsed)
+		label := i18n.T("detail.loading", timefmt.Elapsed(time.Duration(d.elapsed)*time.Second))
 		return d.load.View(label, d.Palette())
 	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		d.styles.Title.Render(d.title),
 		d.styles.Desc.Render(d.description),
-		d.styles.Content.Render(fmt.Sprintf("Screen ID: %s", d.screenID)),
+		d.styles.Content.Render(i18n.T("detail.screenID", d.screenID)),
 		"Test",
-		d.styles.Info.Render("Press Esc to go back to the menu"),
+		d.styles.Info.Render(i18n.T("detail.hint")),
 	)
 
 	return content
@@ -66,29 +66,19 @@ func TestDetail_ErrMsg_WrongLabel_IgnoresMessage(t *testing.T) {
 
 // --- Esc key ---
 
-func TestDetail_EscKey_SendsBackMsg(t *testing.T) {
+func TestDetail_EscKey_LeftToGlobalBack(t *testing.T) {
 	d := NewDetail("title", "desc", "id", context.Background())
-	// Loading is not active: Esc should produce a BackMsg.
+	// Detail binds no keys of its own: rootModel's global Back binding
+	// handles Esc.
 
 	_, cmd := d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
-	assert.NotNil(t, cmd, "Esc should return a command")
-
-	msg := cmd()
-	_, ok := msg.(BackMsg)
-	assert.True(t, ok, "command should produce a BackMsg")
+	assert.Nil(t, cmd, "Esc is not handled by the screen")
 }
 
-func TestDetail_EscKey_WhileLoading_IsConsumedBySpinner(t *testing.T) {
+func TestDetail_Close_CancelsLoad(t *testing.T) {
 	d := newLoadingDetail(t)
-
-	// While loading, key presses are forwarded to the spinner, not to the
-	// Esc handler, so no BackMsg is sent.
-	_, cmd := d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
-	if cmd != nil {
-		msg := cmd()
-		_, isBack := msg.(BackMsg)
-		assert.False(t, isBack, "BackMsg should not be sent while loading")
-	}
+	d.Close()
+	assert.ErrorIs(t, d.ctx.Err(), context.Canceled)
 }
 
 // --- Tick ---
@@ -125,6 +115,9 @@ func TestDetail_Body_ShowsElapsedWhileLoading(t *testing.T) {
 
 	body := d.Body()
 	assert.Contains(t, body, "2s", "body should display elapsed seconds while loading")
+
+	d.elapsed = 65
+	assert.Contains(t, d.Body(), "1m 5s", "minutes are split out once a minute has passed")
 }
 
 func TestDetail_Body_ShowsContentAfterLoad(t *testing.T) {
@@ -0,0 +1,143 @@
+package screens
+
+import (
+	"context"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/editor"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/modal"
+	"scaffold/internal/ui/theme"
+)
+
+// Editor is a full-screen text editor for one document, titled above the
+// editing area. Esc leaves; with unsaved changes it first asks whether to
+// discard them.
+type Editor struct {
+	theme.ThemeAware
+
+	title  string
+	editor editor.Model
+	back   key.Binding
+	width  int
+	height int
+	frameW int // horizontal frame of the body style the root draws around the screen
+	styles theme.DetailStyles
+}
+
+// NewEditor creates an editor screen for content. id labels the editor's
+// messages and save tasks; save persists the buffer on ctrl+s and autosave.
+func NewEditor(ctx context.Context, id, title, content string, cfg config.EditorConfig, save editor.SaveFunc) *Editor {
+	return &Editor{
+		title:  title,
+		editor: editor.New(ctx, id, content, cfg, save),
+		back:   keys.Bind("global.back"),
+	}
+}
+
+// SetWidth sets the screen width.
+func (e *Editor) SetWidth(w int) Screen {
+	e.width = w
+	e.resize()
+	return e
+}
+
+// SetHeight sets the available body height.
+func (e *Editor) SetHeight(h int) Screen {
+	e.height = h
+	e.resize()
+	return e
+}
+
+// resize fits the editor below the title.
+func (e *Editor) resize() {
+	titleH := lipgloss.Height(e.titleView())
+	e.editor.SetSize(theme.MaxWidth(e.width)-e.frameW, e.height-titleH)
+}
+
+// ApplyTheme implements theme.Themeable.
+func (e *Editor) ApplyTheme(state theme.State) {
+	e.ApplyThemeState(state)
+	e.styles = theme.NewDetailStylesFromPalette(state.Palette)
+	e.frameW = theme.NewFromState(state).Body.GetHorizontalFrameSize()
+	e.editor.ApplyTheme(state)
+	e.resize()
+}
+
+// ApplyLanguage implements i18n.Localizable.
+func (e *Editor) ApplyLanguage() {
+	e.back = keys.Bind("global.back")
+	e.editor.ApplyLanguage()
+}
+
+// CapturesKey implements KeyCapturer: printable keys are text for the
+// focused editor, not shortcuts.
+func (e *Editor) CapturesKey(msg tea.KeyPressMsg) bool {
+	return e.editor.Focused() && msg.Text != ""
+}
+
+// Init focuses the editor and starts autosave.
+func (e *Editor) Init() tea.Cmd {
+	return tea.Batch(e.editor.Focus(), e.editor.Init())
+}
+
+// Update handles messages for the editor screen.
+func (e *Editor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case modal.ConfirmedMsg:
+		if msg.ID == "discard-editor" {
+			return e, func() tea.Msg { return BackMsg{} }
+		}
+	case tea.KeyPressMsg:
+		if key.Matches(msg, e.back) {
+			return e, e.confirmDiscard()
+		}
+	}
+
+	var cmd tea.Cmd
+	e.editor, cmd = e.editor.Update(msg)
+	return e, cmd
+}
+
+// confirmDiscard asks before throwing away unsaved changes, and goes back
+// straight away when there are none.
+func (e *Editor) confirmDiscard() tea.Cmd {
+	if !e.editor.Dirty() {
+		return func() tea.Msg { return BackMsg{} }
+	}
+	return modal.ShowConfirm("discard-editor", i18n.T("editor.discard.title"), i18n.T("editor.discard.body", e.title))
+}
+
+// View renders the editor screen.
+func (e *Editor) View() tea.View {
+	return tea.NewView(e.Body())
+}
+
+// Body returns the body content for layout composition.
+func (e *Editor) Body() string {
+	return lipgloss.JoinVertical(lipgloss.Left, e.titleView(), e.editor.View())
+}
+
+// titleView renders the title, marked while there are unsaved changes.
+func (e *Editor) titleView() string {
+	title := e.title
+	if e.editor.Dirty() {
+		title = i18n.T("editor.titleModified", title)
+	}
+	return e.styles.Title.Render(title)
+}
+
+// ShortHelp returns the bindings for the help bar.
+func (e *Editor) ShortHelp() []key.Binding {
+	return append(e.editor.ShortHelp(), e.back)
+}
+
+// FullHelp returns the bindings for the full-help overlay.
+func (e *Editor) FullHelp() [][]key.Binding {
+	return append(e.editor.FullHelp(), []key.Binding{e.back})
+}
@@ -5,6 +5,7 @@ import (
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 
+	"scaffold/internal/i18n"
 	"scaffold/internal/ui/menu"
 	"scaffold/internal/ui/theme"
 )
@@ -16,11 +17,27 @@ type Screen interface {
 }
 
 // KeyBinder is an optional interface for screens that provide key bindings.
+// The bindings in FullHelp are the screen's own: a key press matching one
+// goes to the screen before the global key map sees it.
 type KeyBinder interface {
 	ShortHelp() []key.Binding
 	FullHelp() [][]key.Binding
 }
 
+// KeyCapturer is an optional interface for screens whose focused widget
+// consumes some key presses outright, such as a text input taking
+// printable keys as text or a key recorder taking any key. A captured key
+// goes to the screen only; no chord, screen or global binding sees it.
+type KeyCapturer interface {
+	CapturesKey(msg tea.KeyPressMsg) bool
+}
+
+// Closer is an optional interface for screens that hold resources, such as
+// a running task, to release when they are left.
+type Closer interface {
+	Close()
+}
+
 // Home is the home screen with a menu.
 type Home struct {
 	theme.ThemeAware
@@ -33,17 +50,26 @@ type Home struct {
 // NewHome creates a new Home screen.
 func NewHome() *Home {
 	m := menu.New()
-	m = m.SetItems([]menu.Item{
-		menu.NewItem("Dashboard", "View application dashboard", "dashboard"),
-		menu.NewItem("Settings", "Configure application settings", "settings"),
-		menu.NewItem("Profile", "Manage your profile", "profile"),
-		menu.NewItem("About", "About this application", "about"),
-	})
+	m = m.SetItems(HomeItems())
 	return &Home{
 		menu: m,
 	}
 }
 
+// HomeItems returns the home menu in the current language.
+func HomeItems() []menu.Item {
+	return []menu.Item{
+		menu.NewItem(i18n.T("home.dashboard.title"), i18n.T("home.dashboard.desc"), "dashboard"),
+		menu.NewItem(i18n.T("home.settings.title"), i18n.T("home.settings.desc"), "settings"),
+		menu.NewItem(i18n.T("home.profiles.title"), i18n.T("home.profiles.desc"), "profiles"),
+		menu.NewItem(i18n.T("home.notes.title"), i18n.T("home.notes.desc"), "notes"),
+		menu.NewItem(i18n.T("home.keybindings.title"), i18n.T("home.keybindings.desc"), "keybindings"),
+		menu.NewItem(i18n.T("home.themes.title"), i18n.T("home.themes.desc"), "themes"),
+		menu.NewItem(i18n.T("home.messages.title"), i18n.T("home.messages.desc"), "messages"),
+		menu.NewItem(i18n.T("home.about.title"), i18n.T("home.about.desc"), "about"),
+	}
+}
+
 // SetWidth sets the screen width.
 func (h *Home) SetWidth(w int) Screen {
 	h.width = w
@@ -60,6 +86,16 @@ func (h *Home) SetWidth(w int) Screen {
 func (h *Home) ApplyTheme(state theme.State) {
 	h.ApplyThemeState(state)
 	h.menu.ApplyTheme(state)
+	// Density changes the item height, so the menu is resized to fit.
+	if h.width > 0 {
+		h.SetWidth(h.width)
+	}
+}
+
+// ApplyLanguage implements i18n.Localizable.
+func (h *Home) ApplyLanguage() {
+	h.menu = h.menu.SetItems(HomeItems())
+	h.menu.ApplyLanguage()
 }
 
 // Init initializes the home screen.
@@ -84,6 +120,12 @@ func (h *Home) Body() string {
 	return h.menu.View().Content
 }
 
+// CapturesKey implements KeyCapturer: keys typed into the menu's filter
+// are the query.
+func (h *Home) CapturesKey(tea.KeyPressMsg) bool {
+	return h.menu.Filtering()
+}
+
 // ShortHelp returns short help key bindings for the home screen.
 func (h *Home) ShortHelp() []key.Binding {
 	return h.menu.Keys().ShortHelp()
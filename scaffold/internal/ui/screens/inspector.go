@@ -0,0 +1,223 @@
+package screens
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	colorful "github.com/lucasb-eyer/go-colorful"
+
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/theme"
+)
+
+func init() {
+	keys.Register(
+		keys.Def{ID: "inspector.prev", Keys: []string{"left", "h"}, Help: "←/h", Desc: "inspector.keys.prev"},
+		keys.Def{ID: "inspector.next", Keys: []string{"right", "l"}, Help: "→/l", Desc: "inspector.keys.next"},
+		keys.Def{ID: "inspector.use", Keys: []string{"enter"}, Desc: "inspector.keys.use"},
+	)
+}
+
+type inspectorKeyMap struct {
+	scrollKeyMap
+	Prev key.Binding
+	Next key.Binding
+	Use  key.Binding
+}
+
+func defaultInspectorKeyMap() inspectorKeyMap {
+	return inspectorKeyMap{
+		scrollKeyMap: defaultScrollKeyMap(),
+		Prev:         keys.Bind("inspector.prev"),
+		Next:         keys.Bind("inspector.next"),
+		Use:          keys.Bind("inspector.use"),
+	}
+}
+
+// inspectorLabelWidth is the width of the row labels; inspectorCellWidth
+// that of the dark and light columns.
+const (
+	inspectorLabelWidth = 24
+	inspectorCellWidth  = 22
+)
+
+// ThemeInspector shows the derived palette of a theme, which need not be
+// the one in use: a swatch for every Palette field, the contrast checks of
+// theme.ValidatePalette and the harmonies of the accent colors, each for
+// the dark and light variants side by side.
+type ThemeInspector struct {
+	theme.ThemeAware
+
+	names []string
+	index int // inspected theme
+	list  scrollList
+	keys  inspectorKeyMap
+}
+
+// NewThemeInspector creates the inspector on the named theme.
+func NewThemeInspector(name string) *ThemeInspector {
+	t := &ThemeInspector{list: scrollList{titled: true}, keys: defaultInspectorKeyMap()}
+	t.setNames(name)
+	return t
+}
+
+// setNames lists the registered themes and selects name, or the first.
+func (t *ThemeInspector) setNames(name string) {
+	t.names = theme.AvailableThemes()
+	t.index = max(slices.Index(t.names, name), 0)
+}
+
+// Inspected returns the name of the inspected theme.
+func (t *ThemeInspector) Inspected() string {
+	if len(t.names) == 0 {
+		return ""
+	}
+	return t.names[t.index]
+}
+
+// SetHeight sets the available body height.
+func (t *ThemeInspector) SetHeight(h int) Screen {
+	t.list.setHeight(h, len(t.rows()))
+	return t
+}
+
+// ApplyTheme implements theme.Themeable. Themes may have been reloaded, so
+// the list is refreshed, keeping the inspected theme.
+func (t *ThemeInspector) ApplyTheme(state theme.State) {
+	t.ApplyThemeState(state)
+	t.setNames(t.Inspected())
+}
+
+// ApplyLanguage implements i18n.Localizable.
+func (t *ThemeInspector) ApplyLanguage() {
+	t.keys = defaultInspectorKeyMap()
+}
+
+// Init is a no-op.
+func (t *ThemeInspector) Init() tea.Cmd { return nil }
+
+// Update switches the inspected theme, scrolls, and asks to use the
+// inspected theme.
+func (t *ThemeInspector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if len(t.names) == 0 {
+		return t, nil
+	}
+	switch {
+	case keys.Matches(msg, t.keys.Prev):
+		t.index = (t.index + len(t.names) - 1) % len(t.names)
+	case keys.Matches(msg, t.keys.Next):
+		t.index = (t.index + 1) % len(t.names)
+	case keys.Matches(msg, t.keys.Use):
+		name := t.Inspected()
+		return t, func() tea.Msg { return UseThemeMsg{Name: name} }
+	default:
+		t.list.scroll(msg, t.keys.scrollKeyMap, len(t.rows()))
+	}
+	return t, nil
+}
+
+// View renders the screen.
+func (t *ThemeInspector) View() tea.View {
+	return tea.NewView(t.Body())
+}
+
+// Body returns the body content for layout composition.
+func (t *ThemeInspector) Body() string {
+	p := t.Palette()
+	title := lipgloss.NewStyle().Bold(true).Foreground(p.Primary)
+	muted := lipgloss.NewStyle().Foreground(p.ForegroundMuted)
+	if len(t.names) == 0 {
+		return muted.Render(i18n.T("inspector.none"))
+	}
+
+	head := title.Render(t.Inspected()) + muted.Render(fmt.Sprintf("  ‹ %d/%d ›", t.index+1, len(t.names)))
+	return t.list.render(head, t.rows(), muted.Render(t.hint()))
+}
+
+// hint lists the screen's keys as currently bound.
+func (t *ThemeInspector) hint() string {
+	return keys.Hint(
+		keys.Group(i18n.T("inspector.hint.theme"), t.keys.Prev, t.keys.Next),
+		keys.Group(i18n.T("inspector.hint.scroll"), t.keys.Up, t.keys.Down),
+		keys.Group(i18n.T("inspector.hint.use"), t.keys.Use),
+	)
+}
+
+// rows renders the sections of the inspected theme, one line per row.
+func (t *ThemeInspector) rows() []string {
+	p := t.Palette()
+	section := lipgloss.NewStyle().Bold(true).Foreground(p.Secondary)
+	label := lipgloss.NewStyle().Foreground(p.Foreground).Width(inspectorLabelWidth)
+	cell := lipgloss.NewStyle().Foreground(p.ForegroundMuted).Width(inspectorCellWidth)
+	ok := lipgloss.NewStyle().Foreground(p.Success)
+	bad := lipgloss.NewStyle().Foreground(p.Warning)
+
+	name := t.Inspected()
+	dark, light := theme.NewPalette(name, true), theme.NewPalette(name, false)
+	columns := func(title string) string {
+		return section.Render(lipgloss.NewStyle().Width(inspectorLabelWidth).Render(title)) +
+			cell.Render(i18n.T("inspector.dark")) + cell.Render(i18n.T("inspector.light"))
+	}
+	swatch := func(c color.Color) string {
+		return lipgloss.NewStyle().Background(c).Render("    ") + " " + hexString(c)
+	}
+
+	rows := []string{columns(i18n.T("inspector.colors"))}
+	lightColors := light.Colors()
+	for i, c := range dark.Colors() {
+		rows = append(rows, label.Render("  "+c.Name)+cell.Render(swatch(c.Color))+cell.Render(swatch(lightColors[i].Color)))
+	}
+
+	rows = append(rows, "", columns(i18n.T("inspector.contrast")))
+	contrast := func(c theme.Contrast) string {
+		mark := ok.Render("✓")
+		if !c.OK() {
+			mark = bad.Render("✗")
+		}
+		return mark + fmt.Sprintf(" %5.1f:1  ΔE %.2f", c.Ratio, c.Distance)
+	}
+	lightChecks := theme.Contrasts(light)
+	for i, c := range theme.Contrasts(dark) {
+		rows = append(rows, label.Render("  "+c.Name)+cell.Render(contrast(c))+cell.Render(contrast(lightChecks[i])))
+	}
+
+	for _, base := range []struct {
+		name        string
+		dark, light color.Color
+	}{
+		{"Primary", dark.Primary, light.Primary},
+		{"Secondary", dark.Secondary, light.Secondary},
+	} {
+		rows = append(rows, "", columns(i18n.T("inspector.harmonies", base.name)))
+		lightVariants := theme.GenerateVariants(base.light)
+		for i, v := range theme.GenerateVariants(base.dark) {
+			rows = append(rows, label.Render("  "+v.Name)+cell.Render(swatch(v.Color))+cell.Render(swatch(lightVariants[i].Color)))
+		}
+	}
+	return rows
+}
+
+// hexString formats c as "#RRGGBB".
+func hexString(c color.Color) string {
+	cf, ok := colorful.MakeColor(c)
+	if !ok {
+		return "—"
+	}
+	return strings.ToUpper(cf.Hex())
+}
+
+// ShortHelp returns the bindings for the help bar.
+func (t *ThemeInspector) ShortHelp() []key.Binding {
+	return []key.Binding{t.keys.Prev, t.keys.Next, t.keys.Use}
+}
+
+// FullHelp returns the bindings for the full-help overlay.
+func (t *ThemeInspector) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{t.keys.Up, t.keys.Down, t.keys.Top, t.keys.Bottom}, t.ShortHelp()}
+}
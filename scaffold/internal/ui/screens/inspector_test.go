@@ -0,0 +1,65 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/theme"
+)
+
+func TestThemeInspector_CyclesAndUsesThemes(t *testing.T) {
+	names := theme.AvailableThemes()
+	require.GreaterOrEqual(t, len(names), 2)
+	ti := NewThemeInspector(names[1])
+	assert.Equal(t, names[1], ti.Inspected())
+
+	ti.Update(tea.KeyPressMsg{Code: tea.KeyRight})
+	assert.Equal(t, names[2], ti.Inspected())
+	ti.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	ti.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	assert.Equal(t, names[0], ti.Inspected())
+	ti.Update(tea.KeyPressMsg{Code: tea.KeyLeft})
+	assert.Equal(t, names[len(names)-1], ti.Inspected(), "the list wraps around")
+
+	_, cmd := ti.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, UseThemeMsg{Name: names[len(names)-1]}, cmd())
+}
+
+func TestThemeInspector_ShowsColorsContrastAndHarmonies(t *testing.T) {
+	ti := NewThemeInspector("ember")
+	body := ansi.Strip(ti.Body())
+	assert.Contains(t, body, "ember")
+	assert.Contains(t, body, "SurfaceRaised")
+	assert.Contains(t, body, "#8B1E3F", "ember's primary")
+	assert.Contains(t, body, "Foreground/Background")
+	assert.Contains(t, body, "complementary")
+}
+
+func TestThemeInspector_HintFollowsRebinding(t *testing.T) {
+	assert.Contains(t, ansi.Strip(NewThemeInspector("ember").Body()), "←/h/→/l theme • ↑/k/↓/j scroll • enter use")
+
+	require.NoError(t, keys.SetOverrides(keys.Overrides{"inspector": {"prev": {"["}, "next": {"]"}, "use": {"u"}}}))
+	t.Cleanup(func() { _ = keys.SetOverrides() })
+	assert.Contains(t, ansi.Strip(NewThemeInspector("ember").Body()), "[/] theme • ↑/k/↓/j scroll • u use")
+}
+
+func TestThemeInspector_ScrollsWithinHeight(t *testing.T) {
+	ti := NewThemeInspector("ember")
+	ti.SetHeight(12)
+	assert.Contains(t, ansi.Strip(ti.Body()), "Primary")
+	assert.NotContains(t, ansi.Strip(ti.Body()), "complementary")
+
+	for range 200 {
+		ti.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	}
+	body := ansi.Strip(ti.Body())
+	assert.Contains(t, body, "triadic2", "scrolled to the last harmony")
+	assert.Len(t, strings.Split(body, "\n"), 12)
+}
@@ -0,0 +1,274 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/theme"
+)
+
+// KeyBindingsChangedMsg carries the overrides edited on the key-bindings
+// screen. rootModel applies them, rebuilds every key map and saves them to
+// keys.json.
+type KeyBindingsChangedMsg struct {
+	Overrides keys.Overrides
+}
+
+func init() {
+	keys.Register(
+		keys.Def{ID: "keybindings.edit", Keys: []string{"enter"}, Desc: "keybindings.keys.edit"},
+		keys.Def{ID: "keybindings.reset", Keys: []string{"backspace", "delete"}, Help: "⌫", Desc: "keybindings.keys.reset"},
+		keys.Def{ID: "keybindings.unbind", Keys: []string{"x"}, Desc: "keybindings.keys.unbind"},
+	)
+}
+
+type keyBindingsKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Edit   key.Binding
+	Reset  key.Binding
+	Unbind key.Binding
+	Top    key.Binding
+	Bottom key.Binding
+}
+
+func defaultKeyBindingsKeyMap() keyBindingsKeyMap {
+	return keyBindingsKeyMap{
+		Up:     keys.Bind("menu.up"),
+		Down:   keys.Bind("menu.down"),
+		Edit:   keys.Bind("keybindings.edit"),
+		Reset:  keys.Bind("keybindings.reset"),
+		Unbind: keys.Bind("keybindings.unbind"),
+		Top:    keys.Bind("menu.top"),
+		Bottom: keys.Bind("menu.bottom"),
+	}
+}
+
+// KeyBindings lists every registered key binding by scope with its
+// effective keys, marks overrides and conflicts, and rebinds the selected
+// binding to the next key pressed.
+type KeyBindings struct {
+	theme.ThemeAware
+
+	defs      []keys.Def
+	cursor    int
+	list      scrollList
+	recording bool // the next key press becomes the selected binding
+	keys      keyBindingsKeyMap
+	width     int
+}
+
+// NewKeyBindings creates the key-bindings screen.
+func NewKeyBindings() *KeyBindings {
+	return &KeyBindings{
+		defs: keys.Defs(),
+		keys: defaultKeyBindingsKeyMap(),
+	}
+}
+
+// SetWidth sets the screen width.
+func (k *KeyBindings) SetWidth(w int) Screen {
+	k.width = w
+	return k
+}
+
+// SetHeight sets the available body height.
+func (k *KeyBindings) SetHeight(h int) Screen {
+	k.list.setHeight(h, k.rowOf(len(k.defs)-1)+1)
+	k.scrollToCursor()
+	return k
+}
+
+// ApplyTheme implements theme.Themeable.
+func (k *KeyBindings) ApplyTheme(state theme.State) {
+	k.ApplyThemeState(state)
+}
+
+// ApplyLanguage implements i18n.Localizable. It also picks up rebound
+// keys, since rootModel relocalizes every screen after a change.
+func (k *KeyBindings) ApplyLanguage() {
+	k.keys = defaultKeyBindingsKeyMap()
+}
+
+// CapturesKey implements KeyCapturer: while recording, the next key press,
+// whatever it is, is the new key for the selected binding.
+func (k *KeyBindings) CapturesKey(tea.KeyPressMsg) bool {
+	return k.recording
+}
+
+// Init is a no-op.
+func (k *KeyBindings) Init() tea.Cmd { return nil }
+
+// Update handles navigation, editing and key recording.
+func (k *KeyBindings) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if chord, ok := msg.(keys.ChordMsg); ok && key.Matches(chord, k.keys.Top) {
+		k.cursor = 0
+		k.scrollToCursor()
+		return k, nil
+	}
+	keyMsg, ok := msg.(tea.KeyPressMsg)
+	if !ok || len(k.defs) == 0 {
+		return k, nil
+	}
+	if k.recording {
+		k.recording = false
+		if keyMsg.String() == "esc" {
+			return k, nil
+		}
+		return k, k.override([]string{keyMsg.String()})
+	}
+	switch {
+	case key.Matches(keyMsg, k.keys.Up):
+		k.cursor = max(k.cursor-1, 0)
+	case key.Matches(keyMsg, k.keys.Down):
+		k.cursor = min(k.cursor+1, len(k.defs)-1)
+	case key.Matches(keyMsg, k.keys.Top):
+		k.cursor = 0
+	case key.Matches(keyMsg, k.keys.Bottom):
+		k.cursor = len(k.defs) - 1
+	case key.Matches(keyMsg, k.keys.Edit):
+		k.recording = true
+	case key.Matches(keyMsg, k.keys.Reset):
+		return k, k.override(nil)
+	case key.Matches(keyMsg, k.keys.Unbind):
+		return k, k.override([]string{})
+	}
+	k.scrollToCursor()
+	return k, nil
+}
+
+// override returns a command reporting the current overrides with the
+// selected binding set to keysList; nil removes its override.
+func (k *KeyBindings) override(keysList []string) tea.Cmd {
+	d := k.defs[k.cursor]
+	o := keys.CurrentOverrides()
+	if keysList == nil {
+		delete(o[d.Scope()], d.Name())
+	} else {
+		if o[d.Scope()] == nil {
+			o[d.Scope()] = map[string][]string{}
+		}
+		o[d.Scope()][d.Name()] = keysList
+	}
+	return func() tea.Msg { return KeyBindingsChangedMsg{Overrides: o} }
+}
+
+// scrollToCursor keeps the selected row within the visible rows.
+func (k *KeyBindings) scrollToCursor() {
+	k.list.show(k.rowOf(k.cursor))
+}
+
+// rowOf returns the list row of def i, counting a header row per scope.
+func (k *KeyBindings) rowOf(i int) int {
+	row := i
+	for j := range i + 1 {
+		if k.startsScope(j) {
+			row++
+		}
+	}
+	return row
+}
+
+// startsScope reports whether def i is the first of its scope.
+func (k *KeyBindings) startsScope(i int) bool {
+	return i == 0 || k.defs[i].Scope() != k.defs[i-1].Scope()
+}
+
+// View renders the screen.
+func (k *KeyBindings) View() tea.View {
+	return tea.NewView(k.Body())
+}
+
+// Body returns the body content for layout composition.
+func (k *KeyBindings) Body() string {
+	p := k.Palette()
+	header := lipgloss.NewStyle().Bold(true).Foreground(p.Primary)
+	name := lipgloss.NewStyle().Foreground(p.Foreground)
+	desc := lipgloss.NewStyle().Foreground(p.ForegroundMuted)
+	keyStyle := lipgloss.NewStyle().Foreground(p.Secondary)
+	changed := keyStyle.Bold(true)
+	conflict := lipgloss.NewStyle().Foreground(p.Error).Bold(true)
+	cursor := lipgloss.NewStyle().Foreground(p.Primary).Bold(true)
+
+	conflicting := map[string]bool{}
+	for _, c := range keys.Conflicts() {
+		for _, id := range c.IDs {
+			conflicting[id] = true
+		}
+	}
+	overrides := keys.CurrentOverrides()
+
+	nameW := 0
+	for _, d := range k.defs {
+		nameW = max(nameW, lipgloss.Width(d.Name()))
+	}
+
+	var rows []string
+	for i, d := range k.defs {
+		if k.startsScope(i) {
+			rows = append(rows, header.Render(d.Scope()))
+		}
+		bound := keys.Keys(d.ID)
+		label := keysLabel(bound)
+		if len(bound) == 0 {
+			label = i18n.T("keybindings.unbound")
+		}
+		style := keyStyle
+		if _, ok := overrides[d.Scope()][d.Name()]; ok {
+			style = changed
+			label += " *"
+		}
+		if conflicting[d.ID] {
+			style = conflict
+			label += " !"
+		}
+		if i == k.cursor && k.recording {
+			label = i18n.T("keybindings.pressKey")
+		}
+		help := d.ID
+		if d.Desc != "" {
+			help = i18n.T(d.Desc)
+		}
+		marker := "  "
+		if i == k.cursor {
+			marker = cursor.Render("▸ ")
+		}
+		rows = append(rows, fmt.Sprintf("%s%s  %s  %s", marker,
+			name.Render(d.Name()+strings.Repeat(" ", nameW-lipgloss.Width(d.Name()))),
+			style.Render(label), desc.Render(help)))
+	}
+
+	hint := i18n.T("keybindings.hint")
+	if n := len(keys.Conflicts()); n > 0 {
+		hint = conflict.Render(i18n.N("keybindings.conflicts", n)) + "  " + hint
+	}
+	return k.list.render("", rows, desc.Render(hint))
+}
+
+// keysLabel lists keys for display, naming the space bar.
+func keysLabel(bound []string) string {
+	names := make([]string, len(bound))
+	for i, k := range bound {
+		if k == " " {
+			k = "space"
+		}
+		names[i] = k
+	}
+	return strings.Join(names, " ")
+}
+
+// ShortHelp returns the bindings for the help bar.
+func (k *KeyBindings) ShortHelp() []key.Binding {
+	return []key.Binding{k.keys.Edit, k.keys.Reset, k.keys.Unbind}
+}
+
+// FullHelp returns the bindings for the full-help overlay.
+func (k *KeyBindings) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.keys.Up, k.keys.Down, k.keys.Top, k.keys.Bottom}, k.ShortHelp()}
+}
@@ -0,0 +1,92 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/internal/ui/keys"
+)
+
+// cursorAt returns a KeyBindings screen with the cursor on id.
+func cursorAt(t *testing.T, id string) *KeyBindings {
+	t.Helper()
+	require.NoError(t, keys.SetOverrides())
+	t.Cleanup(func() { _ = keys.SetOverrides() })
+	k := NewKeyBindings()
+	for i, d := range k.defs {
+		if d.ID == id {
+			k.cursor = i
+			return k
+		}
+	}
+	t.Fatalf("binding %s not registered", id)
+	return nil
+}
+
+func changedOverrides(t *testing.T, cmd tea.Cmd) keys.Overrides {
+	t.Helper()
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(KeyBindingsChangedMsg)
+	require.True(t, ok)
+	return msg.Overrides
+}
+
+func TestKeyBindings_RecordsNextKey(t *testing.T) {
+	k := cursorAt(t, "global.quit")
+
+	_, cmd := k.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	require.True(t, k.recording)
+
+	_, cmd = k.Update(tea.KeyPressMsg{Code: 'q', Mod: tea.ModCtrl})
+	assert.False(t, k.recording)
+	assert.Equal(t, keys.Overrides{"global": {"quit": {"ctrl+q"}}}, changedOverrides(t, cmd))
+}
+
+func TestKeyBindings_EscCancelsRecording(t *testing.T) {
+	k := cursorAt(t, "global.quit")
+	k.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	_, cmd := k.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	assert.Nil(t, cmd)
+	assert.False(t, k.recording)
+}
+
+func TestKeyBindings_ResetAndUnbind(t *testing.T) {
+	k := cursorAt(t, "menu.select")
+	require.NoError(t, keys.SetOverrides(keys.Overrides{"menu": {"select": {"o"}, "up": {"w"}}}))
+
+	_, cmd := k.Update(tea.KeyPressMsg{Code: tea.KeyBackspace})
+	assert.Equal(t, keys.Overrides{"menu": {"up": {"w"}}}, changedOverrides(t, cmd))
+
+	_, cmd = k.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	assert.Equal(t, keys.Overrides{"menu": {"select": {}, "up": {"w"}}}, changedOverrides(t, cmd))
+}
+
+func TestKeyBindings_MarksConflicts(t *testing.T) {
+	k := cursorAt(t, "menu.up")
+	assert.NotContains(t, k.Body(), "conflict ")
+
+	require.NoError(t, keys.SetOverrides(keys.Overrides{"menu": {"up": {"j"}}}))
+	assert.Contains(t, k.Body(), "j * !")
+	assert.Contains(t, k.Body(), "1 conflict")
+}
+
+func TestKeyBindings_ScrollsToCursor(t *testing.T) {
+	k := cursorAt(t, "global.quit")
+	k.SetHeight(8)
+	for range len(k.defs) {
+		k.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	}
+	body := ansi.Strip(k.Body())
+	assert.Len(t, strings.Split(body, "\n"), 8)
+	assert.Contains(t, body, "▸ "+k.defs[len(k.defs)-1].Name(), "the last binding is shown")
+
+	k.Update(keys.ChordMsg{Keys: "g g"})
+	assert.Contains(t, ansi.Strip(k.Body()), "▸ "+k.defs[0].Name())
+}
@@ -0,0 +1,124 @@
+package screens
+
+import (
+	"slices"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"scaffold/internal/i18n"
+	"scaffold/internal/timefmt"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/status"
+	"scaffold/internal/ui/theme"
+)
+
+// Messages is the status history: every status message of the session,
+// newest first, with the time it was shown. Times are rendered by
+// timefmt.Format, so they follow the date format, time zone and relative
+// times settings. Messages sent while the screen is open are added to it.
+type Messages struct {
+	theme.ThemeAware
+
+	entries []status.Entry // oldest first
+	list    scrollList
+	keys    scrollKeyMap
+}
+
+// NewMessages creates the screen on entries, oldest first.
+func NewMessages(entries []status.Entry) *Messages {
+	return &Messages{
+		entries: slices.Clone(entries),
+		list:    scrollList{titled: true},
+		keys:    defaultScrollKeyMap(),
+	}
+}
+
+// SetHeight sets the available body height.
+func (s *Messages) SetHeight(h int) Screen {
+	s.list.setHeight(h, len(s.entries))
+	return s
+}
+
+// ApplyTheme implements theme.Themeable.
+func (s *Messages) ApplyTheme(state theme.State) {
+	s.ApplyThemeState(state)
+}
+
+// ApplyLanguage implements i18n.Localizable.
+func (s *Messages) ApplyLanguage() {
+	s.keys = defaultScrollKeyMap()
+}
+
+// Init is a no-op.
+func (s *Messages) Init() tea.Cmd { return nil }
+
+// Update records new status messages and scrolls.
+func (s *Messages) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case status.Msg:
+		at := msg.At
+		if at.IsZero() {
+			at = time.Now()
+		}
+		s.entries = append(s.entries, status.Entry{Text: msg.Text, Kind: msg.Kind, At: at})
+		return s, nil
+	}
+	s.list.scroll(msg, s.keys, len(s.entries))
+	return s, nil
+}
+
+// View renders the screen.
+func (s *Messages) View() tea.View {
+	return tea.NewView(s.Body())
+}
+
+// Body returns the body content for layout composition.
+func (s *Messages) Body() string {
+	p := s.Palette()
+	title := lipgloss.NewStyle().Bold(true).Foreground(p.Primary)
+	muted := lipgloss.NewStyle().Foreground(p.ForegroundMuted)
+
+	head := title.Render(i18n.T("messages.title"))
+	if len(s.entries) == 0 {
+		return head + "\n\n" + muted.Render(i18n.T("messages.empty"))
+	}
+	hint := keys.Hint(keys.Group(i18n.T("messages.hint.scroll"), s.keys.Up, s.keys.Down))
+	return s.list.render(head, s.rows(), muted.Render(hint))
+}
+
+// rows renders one line per message, newest first: a mark in the color of
+// its kind, the time and the text.
+func (s *Messages) rows() []string {
+	p := s.Palette()
+	stamp := lipgloss.NewStyle().Foreground(p.ForegroundSubtle)
+	text := lipgloss.NewStyle().Foreground(p.Foreground)
+	marks := map[status.Kind]lipgloss.Style{
+		status.KindInfo:    lipgloss.NewStyle().Foreground(p.Info),
+		status.KindSuccess: lipgloss.NewStyle().Foreground(p.Success),
+		status.KindWarning: lipgloss.NewStyle().Foreground(p.Warning),
+		status.KindError:   lipgloss.NewStyle().Foreground(p.Error),
+	}
+
+	rows := make([]string, 0, len(s.entries))
+	for _, e := range slices.Backward(s.entries) {
+		mark, ok := marks[e.Kind]
+		if !ok {
+			mark = stamp
+		}
+		rows = append(rows, mark.Render("●")+" "+stamp.Render(timefmt.Format(e.At))+"  "+text.Render(e.Text))
+	}
+	return rows
+}
+
+// ShortHelp returns the bindings for the help bar.
+func (s *Messages) ShortHelp() []key.Binding {
+	return []key.Binding{s.keys.Up, s.keys.Down}
+}
+
+// FullHelp returns the bindings for the full-help overlay.
+func (s *Messages) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{s.keys.Up, s.keys.Down, s.keys.Top, s.keys.Bottom}}
+}
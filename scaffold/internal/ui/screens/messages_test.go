@@ -0,0 +1,82 @@
+package screens
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/internal/timefmt"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/status"
+)
+
+func TestMessages_NewestFirstWithFormattedTimes(t *testing.T) {
+	prev := timefmt.Current()
+	t.Cleanup(func() { timefmt.Set(prev) })
+	f, err := timefmt.New("15:04", "UTC", false)
+	require.NoError(t, err)
+	timefmt.Set(f)
+
+	at := time.Date(2024, time.July, 9, 22, 30, 0, 0, time.UTC)
+	s := NewMessages([]status.Entry{
+		{Text: "Settings saved", Kind: status.KindSuccess, At: at},
+		{Text: "Save failed", Kind: status.KindError, At: at.Add(5 * time.Minute)},
+	})
+	body := ansi.Strip(s.Body())
+	assert.Contains(t, body, "22:35  Save failed")
+	assert.Contains(t, body, "22:30  Settings saved")
+	assert.Less(t, strings.Index(body, "Save failed"), strings.Index(body, "Settings saved"), "newest first")
+}
+
+func TestMessages_RecordsNewMessages(t *testing.T) {
+	s := NewMessages(nil)
+	assert.Contains(t, ansi.Strip(s.Body()), "No messages yet")
+
+	s.Update(status.Msg{Text: "Theme: nord", Kind: status.KindInfo, At: time.Now()})
+	assert.Contains(t, ansi.Strip(s.Body()), "Theme: nord")
+}
+
+func TestMessages_ScrollsWithinHeight(t *testing.T) {
+	var entries []status.Entry
+	for i := range 20 {
+		entries = append(entries, status.Entry{Text: "msg " + string(rune('a'+i)), At: time.Now()})
+	}
+	s := NewMessages(entries)
+	s.SetHeight(10)
+	assert.Contains(t, ansi.Strip(s.Body()), "msg t")
+
+	for range 50 {
+		s.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	}
+	body := ansi.Strip(s.Body())
+	assert.Contains(t, body, "msg a", "scrolled to the oldest message")
+	assert.NotContains(t, body, "msg t")
+	assert.Len(t, strings.Split(body, "\n"), 10)
+}
+
+func TestMessages_JumpsToOldestAndNewest(t *testing.T) {
+	var entries []status.Entry
+	for i := range 20 {
+		entries = append(entries, status.Entry{Text: "msg " + string(rune('a'+i)), At: time.Now()})
+	}
+	s := NewMessages(entries)
+	s.SetHeight(10)
+
+	s.Update(tea.KeyPressMsg{Code: 'G', Text: "G"})
+	assert.Contains(t, ansi.Strip(s.Body()), "msg a", "G shows the oldest message")
+
+	s.Update(keys.ChordMsg{Keys: "g g"})
+	assert.Contains(t, ansi.Strip(s.Body()), "msg t", "g g shows the newest message")
+}
+
+func TestMessages_HintFollowsRebinding(t *testing.T) {
+	require.NoError(t, keys.SetOverrides(keys.Overrides{"menu": {"up": {"ctrl+u"}, "down": {"ctrl+d"}}}))
+	t.Cleanup(func() { _ = keys.SetOverrides() })
+	s := NewMessages([]status.Entry{{Text: "hi", At: time.Now()}})
+	assert.Contains(t, ansi.Strip(s.Body()), "ctrl+u/ctrl+d scroll")
+}
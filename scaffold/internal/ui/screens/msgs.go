@@ -14,6 +14,24 @@ type SettingsSavedMsg struct {
 	Cfg config.Config
 }
 
+// SettingsPreviewMsg carries an unsaved config whose UI chrome (theme,
+// banner, help bar, density, mouse mode, language) should be previewed while
+// settings are edited.
+// A BackMsg from the settings screen ends the preview.
+type SettingsPreviewMsg struct {
+	Cfg config.Config
+}
+
+// UseThemeMsg asks rootModel to switch to the named theme, as chosen in the
+// theme inspector or the command palette.
+type UseThemeMsg struct {
+	Name string
+}
+
+// EditConfigMsg asks rootModel to open the config file in the external
+// editor. The file is reloaded and validated when the editor exits.
+type EditConfigMsg struct{}
+
 // detailTickMsg is sent every second while the detail screen is loading,
 // demonstrating the canonical tea.Tick periodic-task pattern (§7C).
 type detailTickMsg time.Time
@@ -0,0 +1,125 @@
+package screens
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/menu"
+	"scaffold/internal/ui/theme"
+)
+
+// ProfileItemPrefix prefixes the menu.Item screen IDs emitted by the profile
+// picker. rootModel strips it to recover the selected profile name; an empty
+// remainder selects the base config without a profile.
+const ProfileItemPrefix = "profile:"
+
+// Profiles is the profile picker screen. Selecting an entry emits a
+// menu.SelectionMsg whose ScreenID is ProfileItemPrefix + name.
+type Profiles struct {
+	theme.ThemeAware
+
+	menu   menu.Model
+	names  []string
+	active string
+	width  int
+}
+
+// NewProfiles creates the picker for the given profile names. active is the
+// currently applied profile ("" for none) and is pre-selected.
+func NewProfiles(names []string, active string) *Profiles {
+	selected := 0
+	for i, name := range names {
+		if name == active {
+			selected = i + 1
+		}
+	}
+
+	m := menu.New()
+	m = m.SetItems(profileItems(names, active)).Select(selected)
+	return &Profiles{
+		menu:   m,
+		names:  names,
+		active: active,
+	}
+}
+
+// profileItems returns the picker entries in the current language: the base
+// config followed by one entry per profile.
+func profileItems(names []string, active string) []menu.Item {
+	items := make([]menu.Item, 0, len(names)+1)
+	items = append(items, menu.NewItem(i18n.T("profiles.base.title"), i18n.T("profiles.base.desc"), ProfileItemPrefix))
+	for _, name := range names {
+		desc := "profiles/" + name + ".json"
+		if name == active {
+			desc = i18n.T("profiles.active", desc)
+		}
+		items = append(items, menu.NewItem(name, desc, ProfileItemPrefix+name))
+	}
+	return items
+}
+
+// SetWidth sets the screen width.
+func (p *Profiles) SetWidth(w int) Screen {
+	p.width = w
+	height := p.menu.RequiredHeight()
+	if height == 0 {
+		height = 10 // fallback
+	}
+	p.menu = p.menu.SetSize(w-6, height)
+	return p
+}
+
+// ApplyTheme implements theme.Themeable.
+func (p *Profiles) ApplyTheme(state theme.State) {
+	p.ApplyThemeState(state)
+	p.menu.ApplyTheme(state)
+	// Density changes the item height, so the menu is resized to fit.
+	if p.width > 0 {
+		p.SetWidth(p.width)
+	}
+}
+
+// ApplyLanguage implements i18n.Localizable.
+func (p *Profiles) ApplyLanguage() {
+	p.menu = p.menu.SetItems(profileItems(p.names, p.active))
+	p.menu.ApplyLanguage()
+}
+
+// Init initializes the profile picker.
+func (p *Profiles) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the profile picker.
+func (p *Profiles) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	p.menu, cmd = p.menu.Update(msg)
+	return p, cmd
+}
+
+// View renders the profile picker.
+func (p *Profiles) View() tea.View {
+	return tea.NewView(p.Body())
+}
+
+// Body returns the body content for layout composition.
+func (p *Profiles) Body() string {
+	return p.menu.View().Content
+}
+
+// CapturesKey implements KeyCapturer: keys typed into the menu's filter
+// are the query.
+func (p *Profiles) CapturesKey(tea.KeyPressMsg) bool {
+	return p.menu.Filtering()
+}
+
+// ShortHelp returns short help key bindings for the profile picker.
+func (p *Profiles) ShortHelp() []key.Binding {
+	return p.menu.Keys().ShortHelp()
+}
+
+// FullHelp returns full help key bindings for the profile picker.
+func (p *Profiles) FullHelp() [][]key.Binding {
+	return p.menu.Keys().FullHelp()
+}
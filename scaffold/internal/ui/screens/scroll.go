@@ -0,0 +1,116 @@
+package screens
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"scaffold/internal/ui/keys"
+)
+
+// scrollKeyMap scrolls a scrollList.
+type scrollKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Top    key.Binding
+	Bottom key.Binding
+}
+
+func defaultScrollKeyMap() scrollKeyMap {
+	return scrollKeyMap{
+		Up:     keys.Bind("menu.up"),
+		Down:   keys.Bind("menu.down"),
+		Top:    keys.Bind("menu.top"),
+		Bottom: keys.Bind("menu.bottom"),
+	}
+}
+
+// scrollList lays out rows between an optional title and a hint line that
+// stay put, and scrolls the rows when the body is too short for all of
+// them. The screens using it keep the rows; a scrollList only tracks the
+// window onto them.
+type scrollList struct {
+	offset int  // first visible row
+	height int  // body height; 0 until set, which shows every row
+	titled bool // a title and a blank line precede the rows
+}
+
+// chrome is the number of body lines that are not rows: the blank line and
+// the hint below them and, when titled, the title and blank line above.
+func (l *scrollList) chrome() int {
+	if l.titled {
+		return 4
+	}
+	return 2
+}
+
+// visibleRows is the number of rows that fit, or 0 before the height is
+// known.
+func (l *scrollList) visibleRows() int {
+	if l.height == 0 {
+		return 0
+	}
+	return max(l.height-l.chrome(), 1)
+}
+
+// setHeight sets the body height for a list of total rows.
+func (l *scrollList) setHeight(h, total int) {
+	l.height = h
+	l.offset = min(l.offset, l.maxOffset(total))
+}
+
+// maxOffset is the offset that shows the last of total rows at the bottom.
+func (l *scrollList) maxOffset(total int) int {
+	if l.visibleRows() == 0 {
+		return 0
+	}
+	return max(total-l.visibleRows(), 0)
+}
+
+// scroll moves the window on the keys of k over a list of total rows and
+// reports whether msg was one of them.
+func (l *scrollList) scroll(msg tea.Msg, k scrollKeyMap, total int) bool {
+	switch {
+	case keys.Matches(msg, k.Up):
+		l.offset = max(l.offset-1, 0)
+	case keys.Matches(msg, k.Down):
+		l.offset = min(l.offset+1, l.maxOffset(total))
+	case keys.Matches(msg, k.Top):
+		l.offset = 0
+	case keys.Matches(msg, k.Bottom):
+		l.offset = l.maxOffset(total)
+	default:
+		return false
+	}
+	return true
+}
+
+// show moves the window just enough to show row.
+func (l *scrollList) show(row int) {
+	n := l.visibleRows()
+	if n == 0 {
+		return
+	}
+	if row < l.offset {
+		l.offset = row
+	}
+	if row >= l.offset+n {
+		l.offset = row - n + 1
+	}
+}
+
+// render lays out the visible rows under title, when titled, and above
+// hint.
+func (l *scrollList) render(title string, rows []string, hint string) string {
+	if n := l.visibleRows(); n > 0 && len(rows) > n {
+		offset := min(l.offset, len(rows)-n)
+		rows = rows[offset : offset+n]
+	}
+	var lines []string
+	if l.titled {
+		lines = append(lines, title, "")
+	}
+	lines = append(append(lines, rows...), "", hint)
+	return strings.Join(lines, "\n")
+}
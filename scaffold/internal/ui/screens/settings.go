@@ -2,6 +2,8 @@ package screens
 
 import (
 	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/keys"
 	"scaffold/internal/ui/modal"
 	"scaffold/internal/ui/theme"
 
@@ -18,34 +20,35 @@ type settingsKeyMap struct {
 	Reset   key.Binding
 	NextTab key.Binding
 	PrevTab key.Binding
+	Back    key.Binding
+	Search  key.Binding
+	Edit    key.Binding
+}
+
+func init() {
+	keys.Register(
+		keys.Def{ID: "settings.prev", Keys: []string{"up", "shift+tab"}, Help: "↑/shift+tab", Desc: "settings.keys.prev"},
+		keys.Def{ID: "settings.next", Keys: []string{"down", "tab"}, Help: "↓/tab", Desc: "settings.keys.next"},
+		keys.Def{ID: "settings.submit", Keys: []string{"enter"}, Desc: "settings.keys.submit"},
+		keys.Def{ID: "settings.reset", Keys: []string{"ctrl+r"}, Desc: "settings.keys.reset"},
+		keys.Def{ID: "settings.nextTab", Keys: []string{"}"}, Desc: "settings.keys.nextGroup"},
+		keys.Def{ID: "settings.prevTab", Keys: []string{"{"}, Desc: "settings.keys.prevGroup"},
+		keys.Def{ID: "settings.search", Keys: []string{"/", "ctrl+f"}, Help: "/", Desc: "settings.keys.search"},
+		keys.Def{ID: "settings.editFile", Keys: []string{"ctrl+o"}, Desc: "settings.keys.editFile"},
+	)
 }
 
 func defaultSettingsKeyMap() settingsKeyMap {
 	return settingsKeyMap{
-		Up: key.NewBinding(
-			key.WithKeys("up", "shift+tab"),
-			key.WithHelp("↑/shift+tab", "prev"),
-		),
-		Down: key.NewBinding(
-			key.WithKeys("down", "tab"),
-			key.WithHelp("↓/tab", "next"),
-		),
-		Submit: key.NewBinding(
-			key.WithKeys("enter"),
-			key.WithHelp("enter", "submit"),
-		),
-		Reset: key.NewBinding(
-			key.WithKeys("r"),
-			key.WithHelp("r", "reset defaults"),
-		),
-		NextTab: key.NewBinding(
-			key.WithKeys("}"),
-			key.WithHelp("}", "next group"),
-		),
-		PrevTab: key.NewBinding(
-			key.WithKeys("{"),
-			key.WithHelp("{", "prev group"),
-		),
+		Up:      keys.Bind("settings.prev"),
+		Down:    keys.Bind("settings.next"),
+		Submit:  keys.Bind("settings.submit"),
+		Reset:   keys.Bind("settings.reset"),
+		NextTab: keys.Bind("settings.nextTab"),
+		PrevTab: keys.Bind("settings.prevTab"),
+		Back:    keys.Bind("global.back"),
+		Search:  keys.Bind("settings.search"),
+		Edit:    keys.Bind("settings.editFile"),
 	}
 }
 
@@ -54,6 +57,8 @@ type Settings struct {
 	theme.ThemeAware
 
 	cfg          *config.Config
+	orig         map[string]any // field values at construction, keyed by FieldMeta.Key
+	hooked       map[string]any // last values seen by fireHooks
 	form         *huh.Form
 	groups       []config.GroupMeta
 	keys         settingsKeyMap
@@ -62,10 +67,12 @@ type Settings struct {
 	height       int
 	currentGroup int
 	tabStyles    tabStyles
+	search       settingsSearch
 }
 
 // NewSettings creates a Settings screen from a config snapshot.
-// The config is value-copied so the form edits a working copy.
+// The config is value-copied so the form edits a working copy; the
+// snapshot's values are kept to detect which fields were modified.
 func NewSettings(cfg config.Config) *Settings {
 	cfgCopy := cfg
 	s := &Settings{
@@ -73,10 +80,14 @@ func NewSettings(cfg config.Config) *Settings {
 		keys:         defaultSettingsKeyMap(),
 		currentGroup: 0,
 	}
-	s.groups = config.Schema(s.cfg)
+	s.groups = localizedSchema(s.cfg)
+	s.orig = snapshotValues(s.groups)
+	s.hooked = snapshotValues(s.groups)
+	s.search = newSettingsSearch(s.groups)
 
+	// Esc is handled by the screen (unsaved-changes guard), not by huh.
 	km := huh.NewDefaultKeyMap()
-	km.Quit = key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back"))
+	km.Quit.SetEnabled(false)
 	s.huhKeys = km
 
 	// Note: initTabStyles() is called by ApplyTheme which is invoked by handleNavigate
@@ -102,16 +113,43 @@ func (s *Settings) SetHeight(h int) Screen {
 func (s *Settings) ApplyTheme(state theme.State) {
 	s.ApplyThemeState(state)
 	s.initTabStyles()
+	s.search.applyTheme(state.Palette)
 	// Rebuild the form so huh re-applies styles from the new theme.
 	// WithTheme alone does not re-style already-initialized fields.
-	// Accessor objects write directly to s.cfg, so current edits are preserved.
-	s.form = s.buildForm(state.Name)
+	// Accessor objects write directly to s.cfg, so current edits are preserved,
+	// and focus is kept so a live theme preview doesn't move the cursor.
+	if s.form == nil {
+		s.form = s.buildForm(state.Name)
+		return
+	}
+	s.refreshForm()
+}
+
+// ApplyLanguage implements i18n.Localizable. Labels, key help and the search
+// index are rebuilt in the current language; edits live in s.cfg and survive.
+func (s *Settings) ApplyLanguage() {
+	s.groups = localizedSchema(s.cfg)
+	s.keys = defaultSettingsKeyMap()
+	s.search = newSettingsSearch(s.groups)
+	s.search.applyTheme(s.Palette())
+	s.refreshForm()
+}
+
+// refreshForm rebuilds the form on the current group, keeping the focused
+// field.
+func (s *Settings) refreshForm() {
+	s.syncCurrentGroup()
+	focused := ""
+	if f := s.form.GetFocusedField(); f != nil {
+		focused = f.GetKey()
+	}
+	s.rebuildAt(s.currentGroup, focused)
 }
 
 // buildForm constructs the settings form with the given theme applied.
 func (s *Settings) buildForm(themeName string) *huh.Form {
-	return buildFormForAllGroups(s.groups).
-		WithTheme(theme.HuhTheme(themeName)).
+	return buildFormForAllGroups(s.groups, s.fieldMarker).
+		WithTheme(theme.HuhTheme(themeName, s.ThemeState().Profile)).
 		WithKeyMap(s.huhKeys).
 		WithShowHelp(false)
 }
@@ -121,22 +159,51 @@ func (s *Settings) Init() tea.Cmd {
 	return s.form.Init()
 }
 
-// Update handles messages for the settings screen.
+// Update handles messages for the settings screen. Field hooks fire after
+// every update in which a hooked field's value changed.
 func (s *Settings) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m, cmd := s.update(msg)
+	return m, tea.Batch(cmd, s.fireHooks())
+}
+
+func (s *Settings) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
-	// Handle modal response: confirmed reset → dispatch SettingsSavedMsg with defaults.
+	// Handle modal responses for the save preview and discard guard.
 	if confirmed, ok := msg.(modal.ConfirmedMsg); ok {
-		if confirmed.ID == "reset-settings" {
-			defaults := config.DefaultConfig()
-			return s, func() tea.Msg { return SettingsSavedMsg{Cfg: *defaults} }
+		switch confirmed.ID {
+		case "save-settings":
+			saved := *s.cfg
+			return s, func() tea.Msg { return SettingsSavedMsg{Cfg: saved} }
+		case "discard-settings":
+			return s, func() tea.Msg { return BackMsg{} }
 		}
 	}
 
-	// Handle reset and submit keys
-	if s.form.State == huh.StateNormal {
+	if mouseMsg, ok := msg.(tea.MouseMsg); ok {
+		if s.search.active || s.form.State != huh.StateNormal {
+			return s, nil
+		}
+		return s, s.handleMouse(mouseMsg)
+	}
+
+	// While searching, keys drive the search box instead of the form.
+	if s.search.active {
 		if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+			target, cmd := s.search.update(keyMsg)
+			if target != "" {
+				return s, s.focusField(target)
+			}
+			return s, cmd
+		}
+	}
+
+	// Handle search, reset and submit keys, except text typed into a field
+	if s.form.State == huh.StateNormal {
+		if keyMsg, ok := msg.(tea.KeyPressMsg); ok && !s.typingInFocusedField(keyMsg) {
 			switch {
+			case key.Matches(keyMsg, s.keys.Search):
+				return s, s.search.open()
 			case key.Matches(keyMsg, s.keys.NextTab):
 				// Cycle to next group
 				if s.currentGroup < len(s.groups)-1 {
@@ -150,21 +217,21 @@ func (s *Settings) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return s, s.form.PrevGroup()
 				}
 			case key.Matches(keyMsg, s.keys.Reset):
-				return s, modal.ShowConfirm(
-					"reset-settings",
-					"Reset Settings",
-					"Restore all defaults and save? This cannot be undone.",
-				)
-			case keyMsg.String() == "enter":
-				// Submit the form with Enter from any field
+				return s, s.resetFocusedField()
+			case key.Matches(keyMsg, s.keys.Edit):
+				return s, func() tea.Msg { return EditConfigMsg{} }
+			case key.Matches(keyMsg, s.keys.Back):
+				return s, s.confirmDiscard()
+			case key.Matches(keyMsg, s.keys.Submit):
+				// Review and save the form from any field
 				form, formCmd := s.form.Update(msg)
 				if f, ok := form.(*huh.Form); ok {
 					s.form = f
 				}
-				saved := *s.cfg
-				return s, tea.Sequence(formCmd, func() tea.Msg {
-					return SettingsSavedMsg{Cfg: saved}
-				})
+				if s.form.State == huh.StateCompleted {
+					formCmd = s.rebuildForm()
+				}
+				return s, tea.Sequence(formCmd, s.confirmSave())
 			}
 		}
 	}
@@ -177,8 +244,9 @@ func (s *Settings) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch s.form.State {
 	case huh.StateCompleted:
-		saved := *s.cfg
-		return s, func() tea.Msg { return SettingsSavedMsg{Cfg: saved} }
+		// Tabbing past the last field completes the form; keep editing
+		// behind the save preview so "No" returns to the same group.
+		return s, tea.Batch(s.rebuildForm(), s.confirmSave())
 	case huh.StateAborted:
 		return s, func() tea.Msg { return BackMsg{} }
 	}
@@ -186,6 +254,15 @@ func (s *Settings) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return s, tea.Batch(cmds...)
 }
 
+// CapturesKey implements KeyCapturer: text typed into the search box or a
+// focused input field stays there.
+func (s *Settings) CapturesKey(msg tea.KeyPressMsg) bool {
+	if s.search.active {
+		return msg.Text != ""
+	}
+	return s.form.State == huh.StateNormal && s.typingInFocusedField(msg)
+}
+
 // View renders the settings screen.
 func (s *Settings) View() tea.View {
 	return tea.NewView(s.Body())
@@ -194,10 +271,13 @@ func (s *Settings) View() tea.View {
 // Body returns the body content for layout composition.
 func (s *Settings) Body() string {
 	if s.form.State != huh.StateNormal {
-		return "Applying settings..."
+		return i18n.T("settings.applying")
 	}
 	tabBar := s.renderTabBar()
 	formView := s.form.View()
+	if s.search.active {
+		formView = s.search.view()
+	}
 	if tabBar == "" {
 		return formView
 	}
@@ -206,19 +286,26 @@ func (s *Settings) Body() string {
 
 // ShortHelp returns short help key bindings for the global help bar.
 func (s *Settings) ShortHelp() []key.Binding {
+	if s.search.active {
+		return s.search.keys.ShortHelp()
+	}
 	if len(s.groups) > 1 {
-		return []key.Binding{s.keys.Submit, s.keys.Reset, s.keys.NextTab}
+		return []key.Binding{s.keys.Submit, s.keys.Search, s.keys.Reset, s.keys.NextTab}
 	}
-	return []key.Binding{s.keys.Submit, s.keys.Reset}
+	return []key.Binding{s.keys.Submit, s.keys.Search, s.keys.Reset}
 }
 
 // FullHelp returns full help key bindings for the global help bar.
 func (s *Settings) FullHelp() [][]key.Binding {
+	if s.search.active {
+		return [][]key.Binding{s.search.keys.ShortHelp()}
+	}
 	if len(s.groups) > 1 {
 		return [][]key.Binding{
-			{s.keys.Submit, s.keys.Reset},
-			{s.keys.NextTab, s.keys.PrevTab},
+			{s.keys.Submit, s.keys.Back, s.keys.Reset},
+			{s.keys.Search, s.keys.NextTab, s.keys.PrevTab},
+			{s.keys.Edit},
 		}
 	}
-	return [][]key.Binding{{s.keys.Submit, s.keys.Reset}}
+	return [][]key.Binding{{s.keys.Submit, s.keys.Back, s.keys.Reset}, {s.keys.Search}, {s.keys.Edit}}
 }
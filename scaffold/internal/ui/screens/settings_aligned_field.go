@@ -13,19 +13,32 @@ import (
 // Both alignedField and inlineSelect embed this to render title, description,
 // and control in fixed-width columns.
 type fieldAlignment struct {
-	label  string // display label (without ":")
-	desc   string // description text
-	titleW int    // column width for label text
-	descW  int    // column width for description
+	label  string        // display label (without ":")
+	desc   string        // description text
+	titleW int           // column width for label text
+	descW  int           // column width for description
+	marker func() string // state marker appended to the label; nil for read-only rows
 }
 
 // columnGap is the number of space characters between alignment columns.
 const columnGap = 4
 
+// Label markers. Both are markerWidth cells wide, and
+// computeAlignmentWidths reserves room for them in the title column.
+const (
+	modifiedMarker = " *" // unsaved edit
+	customMarker   = " •" // saved value that differs from cfg_default
+	markerWidth    = 2
+)
+
 // renderAligned joins title, description, and control content into a
 // horizontally aligned row with fixed-width columns and spacing gaps.
 func (a *fieldAlignment) renderAligned(styles *huh.FieldStyles, content string) string {
-	title := styles.Title.Width(a.titleW).MarginRight(columnGap).Render(a.label)
+	label := a.label
+	if a.marker != nil {
+		label += a.marker()
+	}
+	title := styles.Title.Width(a.titleW).MarginRight(columnGap).Render(label)
 	desc := styles.Description.Width(a.descW).MarginRight(columnGap).Render(a.desc)
 	return lipgloss.JoinHorizontal(lipgloss.Left, title, desc, content)
 }
@@ -49,7 +62,8 @@ type alignedField struct {
 }
 
 // newAlignedField creates an aligned wrapper around an inner huh.Field.
-func newAlignedField(label, desc string, titleW, descW int, inner huh.Field) *alignedField {
+// marker may be nil for rows that cannot be edited.
+func newAlignedField(label, desc string, titleW, descW int, marker func() string, inner huh.Field) *alignedField {
 	return &alignedField{
 		inner: inner,
 		alignment: fieldAlignment{
@@ -57,6 +71,7 @@ func newAlignedField(label, desc string, titleW, descW int, inner huh.Field) *al
 			desc:   desc,
 			titleW: titleW,
 			descW:  descW,
+			marker: marker,
 		},
 	}
 }
@@ -0,0 +1,221 @@
+package screens
+
+import (
+	"fmt"
+	"strings"
+
+	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/modal"
+	"scaffold/internal/ui/status"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// maxDiffLines caps the number of changes listed in the save preview so the
+// dialog stays within a small terminal.
+const maxDiffLines = 8
+
+// fieldChange describes one edited field for the save preview.
+type fieldChange struct {
+	label string
+	from  string
+	to    string
+}
+
+// snapshotValues records the current value of every editable field in
+// groups, keyed by FieldMeta.Key.
+func snapshotValues(groups []config.GroupMeta) map[string]any {
+	vals := make(map[string]any)
+	for _, g := range groups {
+		for _, f := range g.Fields {
+			if f.Kind != config.FieldReadOnly {
+				vals[f.Key] = f.Value.Interface()
+			}
+		}
+	}
+	return vals
+}
+
+// isModified reports whether the field with the given key differs from the
+// value it had when the screen was opened.
+func (s *Settings) isModified(key string) bool {
+	f, ok := s.field(key)
+	if !ok {
+		return false
+	}
+	orig, ok := s.orig[key]
+	return ok && f.Value.Interface() != orig
+}
+
+// fieldMarker returns the label marker for the field with the given key:
+// modifiedMarker for unsaved edits, customMarker for values that differ from
+// cfg_default, and "" otherwise.
+func (s *Settings) fieldMarker(key string) string {
+	f, ok := s.field(key)
+	switch {
+	case !ok:
+		return ""
+	case s.isModified(key):
+		return modifiedMarker
+	case !f.IsDefault():
+		return customMarker
+	}
+	return ""
+}
+
+// field returns the FieldMeta with the given key.
+func (s *Settings) field(key string) (config.FieldMeta, bool) {
+	for _, g := range s.groups {
+		for _, f := range g.Fields {
+			if f.Key == key {
+				return f, true
+			}
+		}
+	}
+	return config.FieldMeta{}, false
+}
+
+// changes returns every modified field in schema order.
+func (s *Settings) changes() []fieldChange {
+	var out []fieldChange
+	for _, g := range s.groups {
+		for _, f := range g.Fields {
+			orig, ok := s.orig[f.Key]
+			if !ok || f.Value.Interface() == orig {
+				continue
+			}
+			out = append(out, fieldChange{
+				label: f.Label,
+				from:  formatFieldValue(f, orig),
+				to:    formatFieldValue(f, f.Value.Interface()),
+			})
+		}
+	}
+	return out
+}
+
+// Dirty reports whether any field has unsaved changes.
+func (s *Settings) Dirty() bool {
+	return len(s.changes()) > 0
+}
+
+// formatFieldValue renders a field value for the diff dialog. Secret values
+// are masked so they never appear on screen.
+func formatFieldValue(f config.FieldMeta, v any) string {
+	switch val := v.(type) {
+	case string:
+		if val == "" {
+			return i18n.T("settings.empty")
+		}
+		if f.Secret {
+			return "••••••"
+		}
+		return val
+	case bool:
+		if val {
+			return i18n.T("settings.yes")
+		}
+		return i18n.T("settings.no")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// diffBody renders changes as "Label: old → new" lines.
+func diffBody(changes []fieldChange) string {
+	lines := make([]string, 0, min(len(changes), maxDiffLines)+1)
+	for i, c := range changes {
+		if i == maxDiffLines {
+			lines = append(lines, i18n.T("settings.diffMore", len(changes)-maxDiffLines))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s → %s", c.label, c.from, c.to))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// confirmSave shows the old → new preview for the pending changes. With
+// nothing changed it leaves the screen without saving.
+func (s *Settings) confirmSave() tea.Cmd {
+	changes := s.changes()
+	if len(changes) == 0 {
+		return tea.Batch(
+			func() tea.Msg { return BackMsg{} },
+			status.SetInfo(i18n.T("settings.save.none"), 0),
+		)
+	}
+	title := i18n.N("settings.save.title", len(changes))
+	return modal.ShowConfirm("save-settings", title, diffBody(changes))
+}
+
+// confirmDiscard asks before throwing away unsaved changes, and goes back
+// straight away when there are none.
+func (s *Settings) confirmDiscard() tea.Cmd {
+	n := len(s.changes())
+	if n == 0 {
+		return func() tea.Msg { return BackMsg{} }
+	}
+	body := i18n.N("settings.discard.body", n)
+	return modal.ShowConfirm("discard-settings", i18n.T("settings.discard.title"), body)
+}
+
+// rebuildForm replaces the form with a fresh one on the current group.
+// Accessors write straight into s.cfg, so edits survive the rebuild.
+func (s *Settings) rebuildForm() tea.Cmd {
+	return s.rebuildAt(s.currentGroup, "")
+}
+
+// rebuildAt replaces the form with a fresh one focused on group, and on the
+// field with the given key when key is not empty. Rebuilding is also how
+// out-of-band writes to s.cfg (such as a field reset) reach huh's inputs,
+// which otherwise keep their own copy of the value.
+func (s *Settings) rebuildAt(group int, key string) tea.Cmd {
+	s.form = s.buildForm(s.ThemeName())
+	cmd := s.form.Init()
+	for range group {
+		s.form.NextGroup()
+	}
+	s.currentGroup = group
+	if key != "" {
+		for range s.groups[group].Fields {
+			if f := s.form.GetFocusedField(); f == nil || f.GetKey() == key {
+				break
+			}
+			s.form.NextField()
+		}
+	}
+	return cmd
+}
+
+// focusField rebuilds the form focused on the field with the given key.
+func (s *Settings) focusField(key string) tea.Cmd {
+	for gi, g := range s.groups {
+		for _, f := range g.Fields {
+			if f.Key == key {
+				return s.rebuildAt(gi, key)
+			}
+		}
+	}
+	return nil
+}
+
+// resetFocusedField restores the focused field to its cfg_default value.
+func (s *Settings) resetFocusedField() tea.Cmd {
+	focused := s.form.GetFocusedField()
+	if focused == nil {
+		return nil
+	}
+	f, ok := s.field(focused.GetKey())
+	if !ok || f.ReadOnly {
+		return nil
+	}
+	if f.IsDefault() {
+		return status.SetInfo(i18n.T("settings.reset.already", f.Label), 0)
+	}
+	f.ResetToDefault()
+	return tea.Batch(
+		s.focusField(f.Key),
+		status.SetInfo(i18n.T("settings.reset.done", f.Label), 0),
+	)
+}
@@ -3,9 +3,11 @@ package screens
 import (
 	"fmt"
 	"reflect"
-	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"scaffold/config"
+	"scaffold/internal/i18n"
 	"scaffold/internal/ui/theme"
 
 	"charm.land/huh/v2"
@@ -41,8 +43,48 @@ func (a *intAccessor) Set(val string) {
 	a.v.SetInt(int64(intVal))
 }
 
+// localizedSchema returns config.Schema(cfg) with group and field labels in
+// the current language. Catalog keys are "group.<key>" and
+// "field.<key>.label" / "field.<key>.desc"; the cfg_label and cfg_desc tags
+// are the English source and the fallback for untranslated fields.
+func localizedSchema(cfg *config.Config) []config.GroupMeta {
+	groups := config.Schema(cfg)
+	for gi := range groups {
+		g := &groups[gi]
+		if s, ok := i18n.Lookup("group." + g.Key); ok {
+			g.Label = s
+		}
+		for fi := range g.Fields {
+			f := &g.Fields[fi]
+			if s, ok := i18n.Lookup("field." + f.Key + ".label"); ok {
+				f.Label = s
+			}
+			if s, ok := i18n.Lookup("field." + f.Key + ".desc"); ok {
+				f.Desc = s
+			}
+		}
+	}
+	return groups
+}
+
+// optionLabel returns the display text for a select option: the native
+// language name for ui.language, a "field.<key>.options.<value>"
+// translation when one exists, and otherwise the value with its first
+// letter upper-cased.
+func optionLabel(fieldKey, value string) string {
+	if fieldKey == "ui.language" {
+		return i18n.DisplayName(value)
+	}
+	if s, ok := i18n.Lookup("field." + fieldKey + ".options." + value); ok {
+		return s
+	}
+	r, size := utf8.DecodeRuneInString(value)
+	return string(unicode.ToUpper(r)) + value[size:]
+}
+
 // computeAlignmentWidths returns the maximum title and description column
-// widths for a group. Title width includes the ":" suffix.
+// widths for a group. Title width includes room for a label marker.
+// Widths are measured in terminal cells, so CJK labels take two per rune.
 func computeAlignmentWidths(group config.GroupMeta) (titleW, descW int) {
 	for _, f := range group.Fields {
 		if tw := lipgloss.Width(f.Label); tw > titleW {
@@ -52,7 +94,7 @@ func computeAlignmentWidths(group config.GroupMeta) (titleW, descW int) {
 			descW = dw
 		}
 	}
-	return titleW, descW
+	return titleW + markerWidth, descW
 }
 
 // minControlWidth is the minimum width reserved for the interactive control column.
@@ -61,7 +103,8 @@ const minControlWidth = 20
 // buildFormForAllGroups constructs a huh.Form from all config groups.
 // Uses LayoutDefault for pagination (one group per page) to handle many fields.
 // The form width is set dynamically based on the widest group's alignment needs.
-func buildFormForAllGroups(groups []config.GroupMeta) *huh.Form {
+// marker is consulted on render for the state marker shown after each label.
+func buildFormForAllGroups(groups []config.GroupMeta, marker func(key string) string) *huh.Form {
 	huhGroups := make([]*huh.Group, 0, len(groups))
 	var maxOverhead int
 	for _, g := range groups {
@@ -72,7 +115,7 @@ func buildFormForAllGroups(groups []config.GroupMeta) *huh.Form {
 		}
 		fields := make([]huh.Field, 0, len(g.Fields))
 		for _, fm := range g.Fields {
-			if f := buildField(fm, titleW, descW); f != nil {
+			if f := buildField(fm, titleW, descW, marker); f != nil {
 				fields = append(fields, f)
 			}
 		}
@@ -92,7 +135,11 @@ func buildFormForAllGroups(groups []config.GroupMeta) *huh.Form {
 // buildField maps a single FieldMeta to a huh.Field wrapped in an aligned
 // container so that title, description, and control columns align vertically
 // across all fields in a group.
-func buildField(m config.FieldMeta, titleW, descW int) huh.Field {
+func buildField(m config.FieldMeta, titleW, descW int, marker func(key string) string) huh.Field {
+	var fieldMarker func() string
+	if marker != nil {
+		fieldMarker = func() string { return marker(m.Key) }
+	}
 	switch m.Kind {
 	case config.FieldSelect:
 		options := m.Options
@@ -101,41 +148,44 @@ func buildField(m config.FieldMeta, titleW, descW int) huh.Field {
 		}
 		opts := make([]huh.Option[string], len(options))
 		for i, o := range options {
-			opts[i] = huh.NewOption(strings.ToUpper(o[:1])+o[1:], o)
+			opts[i] = huh.NewOption(optionLabel(m.Key, o), o)
 		}
 		sel := huh.NewSelect[string]().
 			Key(m.Key).
 			Options(opts...).Inline(true).
 			Accessor(&reflectAccessor[string]{v: m.Value})
-		return newInlineSelect(m.Label, m.Desc, titleW, descW, sel)
+		return newInlineSelect(m.Label, m.Desc, titleW, descW, fieldMarker, sel)
 	case config.FieldConfirm:
 		confirm := huh.NewConfirm().
 			Key(m.Key).
-			Affirmative("Yes").Negative("No").Inline(true).
+			Affirmative(i18n.T("settings.yes")).Negative(i18n.T("settings.no")).Inline(true).
 			Accessor(&reflectAccessor[bool]{v: m.Value})
-		return newAlignedField(m.Label, m.Desc, titleW, descW, confirm)
+		return newAlignedField(m.Label, m.Desc, titleW, descW, fieldMarker, confirm)
 	case config.FieldReadOnly:
 		note := huh.NewNote().
 			Title(fmt.Sprint(m.Value.Interface()))
-		return newAlignedField(m.Label, m.Desc, titleW, descW, note)
+		return newAlignedField(m.Label, m.Desc, titleW, descW, nil, note)
 	default: // FieldInput
 		switch m.Value.Kind() {
 		case reflect.Int:
 			input := huh.NewInput().
 				Key(m.Key).Inline(true).
 				Accessor(&intAccessor{v: m.Value})
-			return newAlignedField(m.Label, m.Desc, titleW, descW, input)
+			return newAlignedField(m.Label, m.Desc, titleW, descW, fieldMarker, input)
 		case reflect.Bool:
 			confirm := huh.NewConfirm().
 				Key(m.Key).Inline(true).
-				Affirmative("Yes").Negative("No").
+				Affirmative(i18n.T("settings.yes")).Negative(i18n.T("settings.no")).
 				Accessor(&reflectAccessor[bool]{v: m.Value})
-			return newAlignedField(m.Label, m.Desc, titleW, descW, confirm)
+			return newAlignedField(m.Label, m.Desc, titleW, descW, fieldMarker, confirm)
 		default: // string and others
 			input := huh.NewInput().
 				Key(m.Key).Inline(true).
 				Accessor(&reflectAccessor[string]{v: m.Value})
-			return newAlignedField(m.Label, m.Desc, titleW, descW, input)
+			if m.Secret {
+				input = input.EchoMode(huh.EchoModePassword)
+			}
+			return newAlignedField(m.Label, m.Desc, titleW, descW, fieldMarker, input)
 		}
 	}
 }
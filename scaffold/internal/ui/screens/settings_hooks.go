@@ -0,0 +1,58 @@
+package screens
+
+import (
+	"scaffold/config"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// FieldHook runs when the value of a settings field changes in the form.
+// It receives a snapshot of the working config and returns a command for
+// the root model, typically a SettingsPreviewMsg.
+type FieldHook func(cfg config.Config) tea.Cmd
+
+// fieldHooks maps FieldMeta.Key to the hook fired when that field changes.
+// Built-in hooks preview UI chrome live; rootModel reverts the preview when
+// the settings screen is left without saving.
+var fieldHooks = map[string]FieldHook{
+	"ui.themeName":    PreviewSettings,
+	"ui.showBanner":   PreviewSettings,
+	"ui.showHelpBar":  PreviewSettings,
+	"ui.compactMode":  PreviewSettings,
+	"ui.mouseEnabled": PreviewSettings,
+	"ui.language":     PreviewSettings,
+}
+
+// RegisterFieldHook sets the hook fired when the field with the given key
+// changes. It replaces any existing hook for key.
+// Must be called from init() — no synchronization.
+func RegisterFieldHook(key string, hook FieldHook) {
+	fieldHooks[key] = hook
+}
+
+// PreviewSettings is a FieldHook that asks rootModel to render the chrome
+// from cfg without saving it.
+func PreviewSettings(cfg config.Config) tea.Cmd {
+	return func() tea.Msg { return SettingsPreviewMsg{Cfg: cfg} }
+}
+
+// fireHooks runs the hook of every hooked field whose value changed since
+// the last call.
+func (s *Settings) fireHooks() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, g := range s.groups {
+		for _, f := range g.Fields {
+			hook, ok := fieldHooks[f.Key]
+			if !ok {
+				continue
+			}
+			val := f.Value.Interface()
+			if last, seen := s.hooked[f.Key]; seen && last == val {
+				continue
+			}
+			s.hooked[f.Key] = val
+			cmds = append(cmds, hook(*s.cfg))
+		}
+	}
+	return tea.Batch(cmds...)
+}
@@ -21,7 +21,7 @@ type inlineSelect struct {
 }
 
 // newInlineSelect creates an inline select field with alignment support.
-func newInlineSelect(label, desc string, titleW, descW int, sel *huh.Select[string]) *inlineSelect {
+func newInlineSelect(label, desc string, titleW, descW int, marker func() string, sel *huh.Select[string]) *inlineSelect {
 	return &inlineSelect{
 		Select: sel,
 		alignment: fieldAlignment{
@@ -29,6 +29,7 @@ func newInlineSelect(label, desc string, titleW, descW int, sel *huh.Select[stri
 			desc:   desc,
 			titleW: titleW,
 			descW:  descW,
+			marker: marker,
 		},
 	}
 }
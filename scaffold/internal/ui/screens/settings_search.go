@@ -0,0 +1,219 @@
+package screens
+
+import (
+	"sort"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/huh/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/sahilm/fuzzy"
+
+	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/theme"
+)
+
+// maxSearchResults caps the number of matches listed below the search box.
+const maxSearchResults = 8
+
+// searchKeyMap defines the keybindings active while the search box is open.
+type searchKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Cancel key.Binding
+}
+
+// ShortHelp implements help.KeyMap.
+func (k searchKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Select, k.Up, k.Down, k.Cancel}
+}
+
+func init() {
+	keys.Register(
+		keys.Def{ID: "search.prev", Keys: []string{"up", "shift+tab"}, Help: "↑", Desc: "search.keys.prev"},
+		keys.Def{ID: "search.next", Keys: []string{"down", "ctrl+n", "tab"}, Help: "↓", Desc: "search.keys.next"},
+		keys.Def{ID: "search.select", Keys: []string{"enter"}, Desc: "search.keys.select"},
+		keys.Def{ID: "search.cancel", Keys: []string{"esc"}, Desc: "search.keys.cancel"},
+	)
+}
+
+func defaultSearchKeyMap() searchKeyMap {
+	return searchKeyMap{
+		Up:     keys.Bind("search.prev"),
+		Down:   keys.Bind("search.next"),
+		Select: keys.Bind("search.select"),
+		Cancel: keys.Bind("search.cancel"),
+	}
+}
+
+// searchEntry is one searchable field together with its group label.
+type searchEntry struct {
+	group string
+	field config.FieldMeta
+}
+
+// settingsSearch is the "/" fuzzy finder over field labels, descriptions
+// and keys. It lists matches across all groups; selecting one hands the
+// field key back to Settings, which focuses it.
+type settingsSearch struct {
+	active  bool
+	input   textinput.Model
+	entries []searchEntry
+	matches []searchEntry
+	cursor  int
+	keys    searchKeyMap
+
+	selected lipgloss.Style
+	normal   lipgloss.Style
+	muted    lipgloss.Style
+}
+
+// newSettingsSearch indexes every editable field in groups.
+func newSettingsSearch(groups []config.GroupMeta) settingsSearch {
+	var entries []searchEntry
+	for _, g := range groups {
+		for _, f := range g.Fields {
+			if f.Kind != config.FieldReadOnly {
+				entries = append(entries, searchEntry{group: g.Label, field: f})
+			}
+		}
+	}
+	ti := textinput.New()
+	ti.Prompt = "/ "
+	ti.Placeholder = i18n.T("search.placeholder")
+	return settingsSearch{
+		input:   ti,
+		entries: entries,
+		keys:    defaultSearchKeyMap(),
+	}
+}
+
+// applyTheme styles the result list from the palette.
+func (s *settingsSearch) applyTheme(p theme.Palette) {
+	s.selected = lipgloss.NewStyle().Foreground(p.Primary).Bold(true)
+	s.normal = lipgloss.NewStyle().Foreground(p.Foreground)
+	s.muted = lipgloss.NewStyle().Foreground(p.ForegroundMuted)
+}
+
+// open shows an empty search box listing every field.
+func (s *settingsSearch) open() tea.Cmd {
+	s.active = true
+	s.input.Reset()
+	s.filter()
+	return s.input.Focus()
+}
+
+// close hides the search box.
+func (s *settingsSearch) close() {
+	s.active = false
+	s.input.Blur()
+}
+
+// update handles a key press while the search box is open. It returns the
+// key of the chosen field once the user selects a match.
+func (s *settingsSearch) update(msg tea.KeyPressMsg) (string, tea.Cmd) {
+	switch {
+	case key.Matches(msg, s.keys.Cancel):
+		s.close()
+		return "", nil
+	case key.Matches(msg, s.keys.Select):
+		if len(s.matches) == 0 {
+			return "", nil
+		}
+		target := s.matches[s.cursor].field.Key
+		s.close()
+		return target, nil
+	case key.Matches(msg, s.keys.Up):
+		if s.cursor > 0 {
+			s.cursor--
+		}
+		return "", nil
+	case key.Matches(msg, s.keys.Down):
+		if s.cursor < min(len(s.matches), maxSearchResults)-1 {
+			s.cursor++
+		}
+		return "", nil
+	}
+
+	prev := s.input.Value()
+	var cmd tea.Cmd
+	s.input, cmd = s.input.Update(msg)
+	if s.input.Value() != prev {
+		s.filter()
+	}
+	return "", cmd
+}
+
+// filter ranks entries against the current query. Each entry scores as the
+// best fuzzy match among its label, key and description; an empty query lists
+// every field in schema order.
+func (s *settingsSearch) filter() {
+	s.cursor = 0
+	query := strings.TrimSpace(s.input.Value())
+	if query == "" {
+		s.matches = s.entries
+		return
+	}
+
+	type scored struct {
+		entry searchEntry
+		score int
+	}
+	var hits []scored
+	for _, e := range s.entries {
+		found := fuzzy.Find(query, []string{e.field.Label, e.field.Key, e.field.Desc})
+		if len(found) == 0 {
+			continue
+		}
+		// fuzzy.Find sorts by score, so the first match is the best one.
+		hits = append(hits, scored{entry: e, score: found[0].Score})
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+
+	s.matches = make([]searchEntry, len(hits))
+	for i, h := range hits {
+		s.matches[i] = h.entry
+	}
+}
+
+// view renders the search box and the top matches.
+func (s *settingsSearch) view() string {
+	rows := []string{s.input.View(), ""}
+	if len(s.matches) == 0 {
+		rows = append(rows, s.muted.Render("  "+i18n.T("search.none")))
+		return strings.Join(rows, "\n")
+	}
+	for i, e := range s.matches {
+		if i == maxSearchResults {
+			rows = append(rows, s.muted.Render("  "+i18n.T("search.more", len(s.matches)-maxSearchResults)))
+			break
+		}
+		label := e.group + " › " + e.field.Label
+		if i == s.cursor {
+			rows = append(rows, s.selected.Render("> "+label)+"  "+s.muted.Render(e.field.Desc))
+		} else {
+			rows = append(rows, s.normal.Render("  "+label)+"  "+s.muted.Render(e.field.Desc))
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+// typingInFocusedField reports whether msg would insert text into the
+// focused field, so printable bindings such as "/" don't steal characters
+// from text inputs (URLs, paths).
+func (s *Settings) typingInFocusedField(msg tea.KeyPressMsg) bool {
+	if msg.Text == "" {
+		return false
+	}
+	af, ok := s.form.GetFocusedField().(*alignedField)
+	if !ok {
+		return false
+	}
+	_, isInput := af.inner.(*huh.Input)
+	return isInput
+}
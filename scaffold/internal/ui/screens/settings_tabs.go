@@ -1,9 +1,13 @@
 package screens
 
 import (
+	"strconv"
 	"strings"
 
+	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
+
+	"scaffold/internal/ui/mouse"
 )
 
 // tabStyles holds lipgloss styles for the group tab bar.
@@ -13,13 +17,16 @@ type tabStyles struct {
 	tabBar   lipgloss.Style
 }
 
-// initTabStyles creates tab styles from the current theme palette.
+// initTabStyles creates tab styles from the current theme palette. Compact
+// mode drops the blank line between the tab bar and the form.
 func (s *Settings) initTabStyles() {
 	p := s.Palette()
+	gap := 1
+	if s.ThemeState().Compact {
+		gap = 0
+	}
 	s.tabStyles = tabStyles{
-		active: lipgloss.NewStyle().
-			Foreground(p.OnPrimary).
-			Background(p.Primary).
+		active: p.Fill(lipgloss.NewStyle(), p.Primary, p.OnPrimary).
 			Bold(true).
 			Padding(0, 1),
 		inactive: lipgloss.NewStyle().
@@ -27,7 +34,7 @@ func (s *Settings) initTabStyles() {
 			Padding(0, 1),
 		tabBar: lipgloss.NewStyle().
 			Padding(0, 1).
-			MarginBottom(1),
+			MarginBottom(gap),
 	}
 }
 
@@ -77,3 +84,70 @@ func (s *Settings) syncCurrentGroup() {
 	}
 }
 
+// tabZones returns the hit regions of the tab bar, one per group, in body
+// coordinates. Zone IDs are group indices. The layout mirrors renderTabBar.
+func (s *Settings) tabZones() mouse.Zones {
+	var zones mouse.Zones
+	if len(s.groups) <= 1 {
+		return zones
+	}
+	x := s.tabStyles.tabBar.GetPaddingLeft()
+	for i, g := range s.groups {
+		style := s.tabStyles.inactive
+		if i == s.currentGroup {
+			style = s.tabStyles.active
+		}
+		w := lipgloss.Width(style.Render(g.Label))
+		zones.Add(strconv.Itoa(i), mouse.Rect{X: x, Y: 0, W: w, H: 1})
+		x += w + 1 // tabs are joined with a single space
+	}
+	return zones
+}
+
+// switchGroup moves the form to group i, one page at a time, exactly as the
+// NextTab/PrevTab keys do.
+func (s *Settings) switchGroup(i int) tea.Cmd {
+	s.syncCurrentGroup()
+	var cmds []tea.Cmd
+	for s.currentGroup < i {
+		s.currentGroup++
+		cmds = append(cmds, s.form.NextGroup())
+	}
+	for s.currentGroup > i {
+		s.currentGroup--
+		cmds = append(cmds, s.form.PrevGroup())
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleMouse switches tabs on click and moves between the fields of the
+// current group on wheel. The wheel stops at the group's first and last
+// field so it never pages or submits the form. Coordinates are relative to
+// the settings body.
+func (s *Settings) handleMouse(msg tea.MouseMsg) tea.Cmd {
+	if x, y, ok := mouse.LeftClick(msg); ok {
+		zones := s.tabZones()
+		if id, hit := zones.Hit(x, y); hit {
+			i, _ := strconv.Atoi(id)
+			return s.switchGroup(i)
+		}
+		return nil
+	}
+	delta := mouse.WheelDelta(msg)
+	if delta == 0 {
+		return nil
+	}
+	s.syncCurrentGroup()
+	fields := s.groups[s.currentGroup].Fields
+	focused := s.form.GetFocusedField()
+	if focused == nil || len(fields) == 0 {
+		return nil
+	}
+	switch {
+	case delta < 0 && focused.GetKey() != fields[0].Key:
+		return s.form.PrevField()
+	case delta > 0 && focused.GetKey() != fields[len(fields)-1].Key:
+		return s.form.NextField()
+	}
+	return nil
+}
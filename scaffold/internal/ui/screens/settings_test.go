@@ -0,0 +1,372 @@
+package screens
+
+import (
+	"reflect"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/config"
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/modal"
+	"scaffold/internal/ui/mouse"
+)
+
+func newTestSettings(t *testing.T) *Settings {
+	t.Helper()
+	return NewSettings(*config.DefaultConfig())
+}
+
+func escKey() tea.KeyPressMsg {
+	return tea.KeyPressMsg{Code: tea.KeyEscape}
+}
+
+// --- dirty tracking ---
+
+func TestSettings_CleanOnOpen(t *testing.T) {
+	s := newTestSettings(t)
+	assert.False(t, s.Dirty())
+	assert.Empty(t, s.changes())
+}
+
+func TestSettings_TracksModifiedFields(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.LogLevel = "debug"
+	s.cfg.UI.ShowBanner = false
+
+	assert.True(t, s.Dirty())
+	assert.True(t, s.isModified("logLevel"))
+	assert.True(t, s.isModified("ui.showBanner"))
+	assert.False(t, s.isModified("ui.themeName"))
+
+	changes := s.changes()
+	require.Len(t, changes, 2)
+	assert.Equal(t, "info", changes[0].from)
+	assert.Equal(t, "debug", changes[0].to)
+	assert.Equal(t, "Yes", changes[1].from)
+	assert.Equal(t, "No", changes[1].to)
+}
+
+func TestSettings_RevertedEditIsClean(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.LogLevel = "debug"
+	s.cfg.LogLevel = "info"
+	assert.False(t, s.Dirty())
+}
+
+func TestSettings_SecretValuesMasked(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.Network.APIToken = "tok-123"
+
+	body := diffBody(s.changes())
+	assert.NotContains(t, body, "tok-123")
+	assert.Contains(t, body, "(empty) → ••••••")
+}
+
+func TestDiffBody_TruncatesLongLists(t *testing.T) {
+	changes := make([]fieldChange, maxDiffLines+3)
+	body := diffBody(changes)
+	assert.Contains(t, body, "… and 3 more")
+}
+
+// --- esc guard ---
+
+func TestSettings_EscWithoutChangesGoesBack(t *testing.T) {
+	s := newTestSettings(t)
+
+	_, cmd := s.Update(escKey())
+	require.NotNil(t, cmd)
+	assert.IsType(t, BackMsg{}, cmd())
+}
+
+func TestSettings_EscWithChangesAsksToDiscard(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.LogLevel = "warn"
+
+	_, cmd := s.Update(escKey())
+	require.NotNil(t, cmd)
+	show, ok := cmd().(modal.ShowMsg)
+	require.True(t, ok, "expected a confirm modal")
+	assert.Equal(t, "discard-settings", show.ID)
+	assert.Equal(t, modal.KindConfirm, show.Kind)
+}
+
+func TestSettings_DiscardConfirmedGoesBack(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.LogLevel = "warn"
+
+	_, cmd := s.Update(modal.ConfirmedMsg{ID: "discard-settings"})
+	require.NotNil(t, cmd)
+	assert.IsType(t, BackMsg{}, cmd())
+}
+
+// --- save preview ---
+
+func TestSettings_ConfirmSaveShowsDiff(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.LogLevel = "error"
+
+	show, ok := s.confirmSave()().(modal.ShowMsg)
+	require.True(t, ok, "expected a confirm modal")
+	assert.Equal(t, "save-settings", show.ID)
+	assert.Equal(t, "Save 1 change?", show.Title)
+	assert.Contains(t, show.Body, "info → error")
+}
+
+func TestSettings_SaveConfirmedEmitsEditedConfig(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.LogLevel = "error"
+
+	_, cmd := s.Update(modal.ConfirmedMsg{ID: "save-settings"})
+	require.NotNil(t, cmd)
+	saved, ok := cmd().(SettingsSavedMsg)
+	require.True(t, ok)
+	assert.Equal(t, "error", saved.Cfg.LogLevel)
+}
+
+func TestSettings_SaveCancelledKeepsEditing(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.LogLevel = "error"
+
+	s.Update(modal.CancelledMsg{ID: "save-settings"})
+	assert.True(t, s.Dirty(), "edits must survive a cancelled save")
+}
+
+func TestSettings_SubmitFollowsRebinding(t *testing.T) {
+	require.NoError(t, keys.SetOverrides(keys.Overrides{"settings": {"submit": {"ctrl+s"}}}))
+	t.Cleanup(func() { _ = keys.SetOverrides() })
+	s := newTestSettings(t)
+
+	_, cmd := s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, showsSaveModal(cmd), "enter no longer submits")
+
+	s.cfg.LogLevel = "error"
+	_, cmd = s.Update(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	assert.True(t, showsSaveModal(cmd), "the rebound key submits")
+}
+
+// showsSaveModal reports whether cmd, or a command it batches or
+// sequences, opens the save preview.
+func showsSaveModal(cmd tea.Cmd) bool {
+	if cmd == nil {
+		return false
+	}
+	msg := cmd()
+	if show, ok := msg.(modal.ShowMsg); ok {
+		return show.ID == "save-settings"
+	}
+	// tea.Sequence yields an unexported []tea.Cmd.
+	if v := reflect.ValueOf(msg); v.Kind() == reflect.Slice && v.Type().Elem() == reflect.TypeFor[tea.Cmd]() {
+		for i := range v.Len() {
+			if showsSaveModal(v.Index(i).Interface().(tea.Cmd)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// --- search ---
+
+func typeKeys(s *Settings, text string) {
+	for _, r := range text {
+		s.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+func focusedKey(s *Settings) string {
+	if f := s.form.GetFocusedField(); f != nil {
+		return f.GetKey()
+	}
+	return ""
+}
+
+func TestSettings_SearchJumpsAcrossGroups(t *testing.T) {
+	s := newTestSettings(t)
+	s.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	require.True(t, s.search.active, "/ must open the search box")
+
+	typeKeys(s, "timeout")
+	require.NotEmpty(t, s.search.matches)
+	assert.Equal(t, "network.timeout", s.search.matches[0].field.Key)
+
+	s.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.False(t, s.search.active)
+	assert.Equal(t, "network.timeout", focusedKey(s))
+	assert.Equal(t, "Network", s.groups[s.currentGroup].Label)
+}
+
+func TestSettings_SearchMatchesDescriptionAndKey(t *testing.T) {
+	s := newTestSettings(t)
+	s.search.open()
+
+	typeKeys(s, "proxyUsername")
+	require.NotEmpty(t, s.search.matches)
+	assert.Equal(t, "network.proxyUsername", s.search.matches[0].field.Key)
+}
+
+func TestSettings_SearchEscClosesWithoutLeaving(t *testing.T) {
+	s := newTestSettings(t)
+	s.search.open()
+
+	_, cmd := s.Update(escKey())
+	assert.False(t, s.search.active)
+	assert.Nil(t, cmd, "esc in search must not leave the screen")
+}
+
+func TestSettings_SlashTypedIntoTextInput(t *testing.T) {
+	s := newTestSettings(t)
+	s.focusField("network.apiEndpoint")
+
+	s.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	assert.False(t, s.search.active, "/ must be typed into text inputs")
+}
+
+// --- per-field reset ---
+
+func TestSettings_ResetFocusedFieldRestoresDefault(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Network.Timeout = 5
+	cfg.LogLevel = "warn"
+	s := NewSettings(*cfg)
+	s.focusField("network.timeout")
+
+	s.Update(tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl})
+	assert.Equal(t, 30, s.cfg.Network.Timeout)
+	assert.Equal(t, "warn", s.cfg.LogLevel, "other fields must be untouched")
+	assert.Equal(t, "network.timeout", focusedKey(s), "focus must stay on the reset field")
+	assert.True(t, s.isModified("network.timeout"), "reset is an unsaved edit")
+}
+
+func TestSettings_FieldMarkers(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.LogLevel = "warn"
+	s := NewSettings(*cfg)
+
+	assert.Equal(t, customMarker, s.fieldMarker("logLevel"), "saved non-default value")
+	assert.Empty(t, s.fieldMarker("debug"), "default value")
+
+	s.cfg.Debug = true
+	assert.Equal(t, modifiedMarker, s.fieldMarker("debug"), "unsaved edit")
+}
+
+// --- field hooks ---
+
+// previewMsgs runs cmd and collects any SettingsPreviewMsg it produces,
+// descending into batches.
+func previewMsgs(cmd tea.Cmd) []SettingsPreviewMsg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case SettingsPreviewMsg:
+		return []SettingsPreviewMsg{msg}
+	case tea.BatchMsg:
+		var out []SettingsPreviewMsg
+		for _, c := range msg {
+			out = append(out, previewMsgs(c)...)
+		}
+		return out
+	}
+	return nil
+}
+
+func TestSettings_HookedFieldChangeFiresPreview(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.UI.ShowBanner = false
+
+	_, cmd := s.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	msgs := previewMsgs(cmd)
+	require.Len(t, msgs, 1)
+	assert.False(t, msgs[0].Cfg.UI.ShowBanner)
+
+	_, cmd = s.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	assert.Empty(t, previewMsgs(cmd), "unchanged values must not fire again")
+}
+
+func TestSettings_UnhookedFieldChangeDoesNotPreview(t *testing.T) {
+	s := newTestSettings(t)
+	s.cfg.Network.Timeout = 5
+
+	_, cmd := s.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	assert.Empty(t, previewMsgs(cmd))
+}
+
+// --- mouse ---
+
+func TestSettings_ClickTabSwitchesGroup(t *testing.T) {
+	s := newTestSettings(t)
+	s.initTabStyles()
+	require.Greater(t, len(s.groups), 2)
+
+	zones := s.tabZones()
+	var target mouse.Rect
+	for x := 0; x < 200; x++ {
+		if id, ok := zones.Hit(x, 0); ok && id == "2" {
+			target = mouse.Rect{X: x, Y: 0}
+			break
+		}
+	}
+	s.Update(tea.MouseClickMsg{X: target.X, Y: 0, Button: tea.MouseLeft})
+	assert.Equal(t, 2, s.currentGroup)
+	assert.Equal(t, s.groups[2].Fields[0].Key, focusedKey(s))
+}
+
+func TestSettings_WheelStaysWithinGroup(t *testing.T) {
+	s := newTestSettings(t)
+	s.form.Init()
+	first := focusedKey(s)
+
+	s.Update(tea.MouseWheelMsg{Button: tea.MouseWheelUp})
+	assert.Equal(t, first, focusedKey(s), "wheel up on the first field must not leave the group")
+
+	s.Update(tea.MouseWheelMsg{Button: tea.MouseWheelDown})
+	assert.Equal(t, s.groups[0].Fields[1].Key, focusedKey(s))
+}
+
+// --- i18n ---
+
+func TestSettings_ApplyLanguageTranslatesLabels(t *testing.T) {
+	t.Cleanup(func() { i18n.SetLanguage(i18n.Fallback) })
+	s := newTestSettings(t)
+	s.form.Init()
+	s.form.NextField()
+	focused := focusedKey(s)
+
+	i18n.SetLanguage("ja")
+	s.ApplyLanguage()
+
+	f, ok := s.field("ui.language")
+	require.True(t, ok)
+	assert.Equal(t, "言語", f.Label)
+	assert.Equal(t, "一般", s.groups[0].Label)
+	assert.Equal(t, focused, focusedKey(s), "relocalizing keeps focus")
+
+	i18n.SetLanguage(i18n.Fallback)
+	s.ApplyLanguage()
+	f, _ = s.field("ui.language")
+	assert.Equal(t, "Language", f.Label, "English labels come back from cfg_label")
+}
+
+func TestComputeAlignmentWidths_CountsCells(t *testing.T) {
+	group := config.GroupMeta{Fields: []config.FieldMeta{
+		{Label: "言語", Desc: "表示言語"},
+		{Label: "abc", Desc: "x"},
+	}}
+	titleW, descW := computeAlignmentWidths(group)
+	assert.Equal(t, 4+markerWidth, titleW, "CJK runes are two cells wide")
+	assert.Equal(t, 8, descW)
+}
+
+func TestOptionLabel(t *testing.T) {
+	t.Cleanup(func() { i18n.SetLanguage(i18n.Fallback) })
+	assert.Equal(t, "日本語", optionLabel("ui.language", "ja"))
+	assert.Equal(t, "Normal", optionLabel("ui.animationSpeed", "normal"))
+
+	i18n.SetLanguage("de")
+	assert.Equal(t, "Schnell", optionLabel("ui.animationSpeed", "fast"))
+	assert.Equal(t, "Json", optionLabel("ui.outputFormat", "json"))
+}
@@ -5,6 +5,8 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/keys"
 	"scaffold/internal/ui/theme"
 )
 
@@ -26,14 +28,15 @@ type Welcome struct {
 
 // NewWelcome creates the first-run welcome screen.
 func NewWelcome() *Welcome {
-	return &Welcome{
-		keys: welcomeKeyMap{
-			Continue: key.NewBinding(
-				key.WithKeys("enter", " "),
-				key.WithHelp("enter", "get started"),
-			),
-		},
-	}
+	return &Welcome{keys: defaultWelcomeKeyMap()}
+}
+
+func init() {
+	keys.Register(keys.Def{ID: "welcome.continue", Keys: []string{"enter", " "}, Help: "enter", Desc: "welcome.keys.continue"})
+}
+
+func defaultWelcomeKeyMap() welcomeKeyMap {
+	return welcomeKeyMap{Continue: keys.Bind("welcome.continue")}
 }
 
 // SetWidth sets the available render width.
@@ -47,6 +50,11 @@ func (w *Welcome) ApplyTheme(state theme.State) {
 	w.ApplyThemeState(state)
 }
 
+// ApplyLanguage implements i18n.Localizable.
+func (w *Welcome) ApplyLanguage() {
+	w.keys = defaultWelcomeKeyMap()
+}
+
 // Init is a no-op; no commands needed on enter.
 func (w *Welcome) Init() tea.Cmd { return nil }
 
@@ -84,25 +92,25 @@ func (w *Welcome) Body() string {
 		Italic(true)
 
 	features := []string{
-		"  • Context-aware async task runner",
-		"  • Modal dialogs (confirm, alert, prompt)",
-		"  • Theme system with 8 built-in palettes",
-		"  • Persistent settings via config file",
+		i18n.T("welcome.feature.tasks"),
+		i18n.T("welcome.feature.modals"),
+		i18n.T("welcome.feature.themes"),
+		i18n.T("welcome.feature.settings"),
 	}
 
 	featureLines := make([]string, len(features))
 	for i, f := range features {
-		featureLines[i] = textStyle.Render(f)
+		featureLines[i] = textStyle.Render("  • " + f)
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left,
-		headingStyle.Render("Welcome to Scaffold"),
-		textStyle.Render("A production-ready BubbleTea v2 application template."),
+		headingStyle.Render(i18n.T("welcome.heading")),
+		textStyle.Render(i18n.T("welcome.tagline")),
 		"",
-		subStyle.Render("What's included:"),
+		subStyle.Render(i18n.T("welcome.included")),
 		lipgloss.JoinVertical(lipgloss.Left, featureLines...),
 		"",
-		mutedStyle.Render("Press enter to get started →"),
+		mutedStyle.Render(i18n.T("welcome.continue")),
 	)
 }
 
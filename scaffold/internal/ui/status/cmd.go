@@ -18,7 +18,7 @@ var (
 // Duration of 0 means the message persists until cleared.
 func Set(text string, kind Kind, duration time.Duration) tea.Cmd {
 	return func() tea.Msg {
-		return Msg{Text: text, Kind: kind, Duration: duration}
+		return Msg{Text: text, Kind: kind, Duration: duration, At: time.Now()}
 	}
 }
 
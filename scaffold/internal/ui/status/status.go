@@ -24,6 +24,7 @@ type Msg struct {
 	Text     string
 	Kind     Kind
 	Duration time.Duration // 0 = persistent until cleared
+	At       time.Time     // when the status was set; zero = when received
 }
 
 // ClearMsg is sent to reset the footer to default state.
@@ -34,3 +35,10 @@ type State struct {
 	Text string
 	Kind Kind
 }
+
+// Entry is a status message as recorded in the history.
+type Entry struct {
+	Text string
+	Kind Kind
+	At   time.Time
+}
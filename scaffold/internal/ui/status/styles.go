@@ -15,16 +15,18 @@ type Styles struct {
 }
 
 // NewStyles creates status styles from a theme palette.
-// Uses background colors for clear visual distinction of status types.
+// Uses background colors for clear visual distinction of status types;
+// a monochrome palette reverses them all and underlines warnings and errors.
 func NewStyles(p theme.Palette) Styles {
 	base := lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	alert := base.Underline(p.Monochrome())
 
 	return Styles{
-		Base:    base.Background(p.Primary).Foreground(p.OnPrimary),
-		Info:    base.Background(p.Info).Foreground(p.OnInfo),
-		Success: base.Background(p.Success).Foreground(p.OnSuccess),
-		Warning: base.Background(p.Warning).Foreground(p.OnWarning),
-		Error:   base.Background(p.Error).Foreground(p.OnError),
+		Base:    p.Fill(base, p.Primary, p.OnPrimary),
+		Info:    p.Fill(base, p.Info, p.OnInfo),
+		Success: p.Fill(base, p.Success, p.OnSuccess),
+		Warning: p.Fill(alert, p.Warning, p.OnWarning),
+		Error:   p.Fill(alert, p.Error, p.OnError),
 	}
 }
 
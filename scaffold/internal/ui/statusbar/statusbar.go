@@ -4,21 +4,30 @@
 package statusbar
 
 import (
+	"slices"
+	"time"
+
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
 	"scaffold/config"
+	"scaffold/internal/i18n"
 	"scaffold/internal/ui/status"
 	"scaffold/internal/ui/theme"
 )
 
+// historyLimit is the number of status messages kept in the history.
+const historyLimit = 100
+
 // Model is the statusbar component.
 type Model struct {
 	state     status.State
+	history   []status.Entry // oldest first, at most historyLimit
 	statusSty status.Styles
 	footerSty lipgloss.Style
 	rightSty  lipgloss.Style
 	cfg       config.Config
+	dirty     bool // an editor has unsaved changes
 	maxW      int
 }
 
@@ -27,8 +36,31 @@ type Model struct {
 func New(cfg config.Config) Model {
 	return Model{
 		cfg:   cfg,
-		state: status.State{Text: "Ready", Kind: status.KindNone},
+		state: status.State{Text: i18n.T("status.ready"), Kind: status.KindNone},
+	}
+}
+
+// WithCfg returns a new Model with an updated config, so the right-hand
+// indicators (version, active profile, debug) reflect the latest settings.
+func (m Model) WithCfg(cfg config.Config) Model {
+	m.cfg = cfg
+	return m
+}
+
+// WithDirty returns a new Model that shows or hides the unsaved-changes
+// indicator.
+func (m Model) WithDirty(dirty bool) Model {
+	m.dirty = dirty
+	return m
+}
+
+// WithLanguage returns a new Model whose idle "Ready" text is in the current
+// language. Active status messages are left as they were sent.
+func (m Model) WithLanguage() Model {
+	if m.state.Kind == status.KindNone {
+		m.state.Text = i18n.T("status.ready")
 	}
+	return m
 }
 
 // Update handles messages relevant to the statusbar.
@@ -36,31 +68,31 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case status.Msg:
 		m.state = status.State{Text: msg.Text, Kind: msg.Kind}
+		at := msg.At
+		if at.IsZero() {
+			at = time.Now()
+		}
+		// Copy on append: earlier Models share the backing array.
+		m.history = append(slices.Clip(m.history), status.Entry{Text: msg.Text, Kind: msg.Kind, At: at})
+		if len(m.history) > historyLimit {
+			m.history = m.history[len(m.history)-historyLimit:]
+		}
 
 	case status.ClearMsg:
-		m.state = status.State{Text: "Ready", Kind: status.KindNone}
+		m.state = status.State{Text: i18n.T("status.ready"), Kind: status.KindNone}
 
 	case theme.ThemeChangedMsg:
 		p := msg.State.Palette
 
 		m.statusSty = status.NewStyles(p)
 
-		m.footerSty = lipgloss.NewStyle().
-			MarginTop(1).
-			Border(lipgloss.RoundedBorder(), true).
-			BorderForeground(p.Border).
-			PaddingLeft(1)
+		m.footerSty = theme.FooterStyle(p, msg.State.Compact)
 
 		m.rightSty = lipgloss.NewStyle().Foreground(p.ForegroundSubtle)
 
-		// Mirror the MaxWidth calculation from theme.newStylesFromPalette so that
-		// the gap arithmetic matches the rest of the layout.
-		w := msg.State.Width
-		maxWidth := w * 90 / 100
-		if maxWidth < 40 {
-			maxWidth = w - 4
-		}
-		m.maxW = maxWidth
+		// Same MaxWidth as theme.Styles so that the gap arithmetic matches
+		// the rest of the layout.
+		m.maxW = theme.MaxWidth(msg.State.Width)
 	}
 
 	return m, nil
@@ -71,21 +103,39 @@ func (m Model) State() status.State {
 	return m.state
 }
 
-// View renders the full footer: left status badge + spacer + right version text.
+// History returns the status messages received so far, oldest first.
+func (m Model) History() []status.Entry {
+	return slices.Clone(m.history)
+}
+
+// View renders the full footer: left status badge + spacer + right version
+// text, followed by the unsaved-changes, active profile and debug
+// indicators when set.
 func (m Model) View() tea.View {
 	left := m.statusSty.Render(m.state.Text, m.state.Kind)
 
 	rightContent := " v" + m.cfg.App.Version
+	if m.dirty {
+		rightContent += " [" + i18n.T("status.modified") + "]"
+	}
+	if m.cfg.Profile != "" {
+		rightContent += " [" + m.cfg.Profile + "]"
+	}
 	if m.cfg.Debug {
 		rightContent += " [DEBUG]"
 	}
 	right := m.rightSty.Render(rightContent + " ")
 
-	// Account for footer border (2) and padding (1).
-	innerWidth := m.maxW - 3
+	// Account for the footer border and padding (none of the border when compact).
+	innerWidth := m.maxW - m.footerSty.GetHorizontalFrameSize()
 	gapW := max(0, innerWidth-lipgloss.Width(left)-lipgloss.Width(right))
 	gap := lipgloss.NewStyle().Width(gapW).Render("")
 
 	footerContent := lipgloss.JoinHorizontal(lipgloss.Top, left, gap, right)
 	return tea.NewView(m.footerSty.Render(footerContent))
 }
+
+// Height returns the number of terminal lines the footer occupies.
+func (m Model) Height() int {
+	return lipgloss.Height(m.View().Content)
+}
@@ -0,0 +1,146 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"strings"
+	"unicode"
+
+	"charm.land/lipgloss/v2"
+)
+
+// -----------------------------------------------------------------------------
+// Palette Export
+// -----------------------------------------------------------------------------
+
+// ExportFormat names a format [Export] writes.
+type ExportFormat string
+
+// Supported export formats.
+const (
+	ExportJSON   ExportFormat = "json"   // both variants, every Palette field
+	ExportCSS    ExportFormat = "css"    // custom properties, light variant under prefers-color-scheme
+	ExportBase16 ExportFormat = "base16" // tinted-theming base16 YAML of one variant
+)
+
+// ExportFormats lists the supported formats.
+var ExportFormats = []ExportFormat{ExportJSON, ExportCSS, ExportBase16}
+
+// Export writes the derived palette of the named theme. JSON and CSS carry
+// the dark and light variants; base16 has room for one, chosen by isDark.
+func Export(name string, format ExportFormat, isDark bool) ([]byte, error) {
+	if _, ok := lookupTheme(name); !ok {
+		return nil, fmt.Errorf("theme: unknown theme %q", name)
+	}
+	dark, light := NewPalette(name, true), NewPalette(name, false)
+	switch format {
+	case ExportJSON:
+		return exportJSON(name, dark, light)
+	case ExportCSS:
+		return exportCSS(name, dark, light), nil
+	case ExportBase16:
+		p := light
+		if isDark {
+			p = dark
+		}
+		return exportBase16(name, p, isDark), nil
+	}
+	return nil, fmt.Errorf("theme: unknown export format %q", format)
+}
+
+// exportJSON writes {"name", "dark", "light"} with the variants keyed like
+// theme file overrides, so an export can be edited into a theme file.
+func exportJSON(name string, dark, light Palette) ([]byte, error) {
+	variant := func(p Palette) map[string]string {
+		m := map[string]string{}
+		for _, c := range p.Colors() {
+			m[lowerCamel(c.Name)] = hexColor(c.Color)
+		}
+		return m
+	}
+	data, err := json.MarshalIndent(struct {
+		Name  string            `json:"name"`
+		Dark  map[string]string `json:"dark"`
+		Light map[string]string `json:"light"`
+	}{name, variant(dark), variant(light)}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("theme: encoding %s: %w", name, err)
+	}
+	return append(data, '\n'), nil
+}
+
+// exportCSS writes the dark variant as --color-* custom properties on
+// :root, and the light variant for prefers-color-scheme: light.
+func exportCSS(name string, dark, light Palette) []byte {
+	var b bytes.Buffer
+	block := func(indent string, p Palette) {
+		fmt.Fprintf(&b, "%s:root {\n", indent)
+		for _, c := range p.Colors() {
+			fmt.Fprintf(&b, "%s  --color-%s: %s;\n", indent, kebab(c.Name), strings.ToLower(hexColor(c.Color)))
+		}
+		fmt.Fprintf(&b, "%s}\n", indent)
+	}
+	fmt.Fprintf(&b, "/* %s */\n", name)
+	block("", dark)
+	b.WriteString("\n@media (prefers-color-scheme: light) {\n")
+	block("  ", light)
+	b.WriteString("}\n")
+	return b.Bytes()
+}
+
+// exportBase16 maps p onto the sixteen base16 slots: the background and
+// text ramp in base00-base07, the status colors and accents in
+// base08-base0F.
+func exportBase16(name string, p Palette, isDark bool) []byte {
+	extreme, variant := lipgloss.Color("#000000"), "light"
+	if isDark {
+		extreme, variant = lipgloss.Color("#FFFFFF"), "dark"
+	}
+	slots := []color.Color{
+		p.Background,                             // base00: default background
+		p.Surface,                                // base01: lighter background
+		p.SurfaceRaised,                          // base02: selection background
+		p.ForegroundSubtle,                       // base03: comments
+		p.ForegroundMuted,                        // base04: dark foreground
+		p.Foreground,                             // base05: default foreground
+		blendColors(p.Foreground, extreme, 0.3),  // base06: light foreground
+		blendColors(p.Foreground, extreme, 0.6),  // base07: lightest foreground
+		p.Error,                                  // base08: red
+		blendColors(p.Error, p.Warning, 0.5),     // base09: orange
+		p.Warning,                                // base0A: yellow
+		p.Success,                                // base0B: green
+		p.Info,                                   // base0C: cyan
+		p.Primary,                                // base0D: blue
+		p.Secondary,                              // base0E: magenta
+		blendColors(p.Error, p.Background, 0.35), // base0F: brown
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "system: \"base16\"\nname: %q\nvariant: %q\npalette:\n", name, variant)
+	for i, c := range slots {
+		fmt.Fprintf(&b, "  base%02X: %q\n", i, hexColor(c))
+	}
+	return b.Bytes()
+}
+
+// lowerCamel lowercases the first letter of a field name.
+func lowerCamel(s string) string {
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+// kebab turns a field name into a hyphenated CSS name: "OnPrimary" becomes
+// "on-primary".
+func kebab(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('-')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,70 @@
+package theme
+
+import (
+	"encoding/json"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport_JSONHasEveryFieldOfBothVariants(t *testing.T) {
+	data, err := Export("ember", ExportJSON, true)
+	require.NoError(t, err)
+	var got struct {
+		Name  string
+		Dark  map[string]string
+		Light map[string]string
+	}
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, "ember", got.Name)
+	assert.Len(t, got.Dark, len(Palette{}.Colors()))
+	assert.Equal(t, "#8B1E3F", got.Dark["primary"])
+	assert.Equal(t, "#F1EFEF", got.Dark["onPrimary"], "ember's Modify hook is applied")
+	assert.NotEqual(t, got.Dark["surfaceRaised"], got.Light["surfaceRaised"])
+}
+
+func TestExport_CSS(t *testing.T) {
+	data, err := Export("ember", ExportCSS, true)
+	require.NoError(t, err)
+	css := string(data)
+	assert.Contains(t, css, "  --color-primary: #8b1e3f;\n")
+	assert.Contains(t, css, "--color-foreground-muted: ")
+	assert.Contains(t, css, "@media (prefers-color-scheme: light) {\n  :root {\n")
+}
+
+func TestExport_Base16RoundTrips(t *testing.T) {
+	data, err := Export("ember", ExportBase16, false)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `variant: "light"`)
+
+	s, err := ParseScheme(data, FormatBase16)
+	require.NoError(t, err)
+	light := NewPalette("ember", false)
+	assert.Equal(t, "ember", s.Name)
+	assert.Equal(t, lipgloss.Color(hexColor(light.Background)), s.Background)
+	assert.Equal(t, lipgloss.Color(hexColor(light.Primary)), s.Primary)
+	assert.Equal(t, lipgloss.Color(hexColor(light.Error)), s.ANSI[1])
+}
+
+func TestExport_Rejects(t *testing.T) {
+	_, err := Export("nope", ExportJSON, true)
+	assert.ErrorContains(t, err, `unknown theme "nope"`)
+	_, err = Export("ember", "svg", true)
+	assert.ErrorContains(t, err, `unknown export format "svg"`)
+}
+
+func TestContrasts_MatchValidatePalette(t *testing.T) {
+	p := NewPalette("ember", false)
+	var failing int
+	for _, c := range Contrasts(p) {
+		assert.GreaterOrEqual(t, c.Ratio, 1.0)
+		if !c.OK() {
+			failing++
+		}
+	}
+	assert.Len(t, ValidatePalette(p), failing)
+	white, black := lipgloss.Color("#FFFFFF"), lipgloss.Color("#000000")
+	assert.InDelta(t, 21, contrastRatio(white, black), 0.01)
+}
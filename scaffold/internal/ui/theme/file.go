@@ -0,0 +1,250 @@
+package theme
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	koanfjson "github.com/knadh/koanf/parsers/json"
+	koanftoml "github.com/knadh/koanf/parsers/toml/v2"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/v2"
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// -----------------------------------------------------------------------------
+// Theme Files
+// -----------------------------------------------------------------------------
+
+// File is the on-disk form of a user theme, read from a .json or .toml file
+// by [LoadThemes]. Colors are "#RGB" or "#RRGGBB" hex strings.
+//
+// The five core colors are required. Dark and Light optionally override any
+// [Palette] field in that variant, keyed by field name in lowerCamel case
+// ("onPrimary", "foregroundMuted", ...). Overriding a core color re-derives
+// the colors computed from it before the other overrides apply.
+type File struct {
+	Name       string            `json:"name,omitempty" koanf:"name"` // defaults to the file name without extension
+	Primary    string            `json:"primary" koanf:"primary"`
+	Secondary  string            `json:"secondary" koanf:"secondary"`
+	Background string            `json:"background" koanf:"background"`
+	Surface    string            `json:"surface" koanf:"surface"`
+	Foreground string            `json:"foreground" koanf:"foreground"`
+	Dark       map[string]string `json:"dark,omitempty" koanf:"dark"`
+	Light      map[string]string `json:"light,omitempty" koanf:"light"`
+}
+
+// coreFields are the Palette fields a ThemeSpec sets directly.
+var coreFields = []string{"Primary", "Secondary", "Background", "Surface", "Foreground"}
+
+// paletteFields maps lowercased Palette field names to the field names.
+var paletteFields = func() map[string]string {
+	fields := map[string]string{}
+	t := reflect.TypeFor[Palette]()
+	for i := range t.NumField() {
+		fields[strings.ToLower(t.Field(i).Name)] = t.Field(i).Name
+	}
+	return fields
+}()
+
+// parseColor parses a "#RGB" or "#RRGGBB" hex color.
+func parseColor(s string) (color.Color, error) {
+	hex := strings.TrimSpace(s)
+	if len(hex) == 4 && hex[0] == '#' {
+		hex = "#" + strings.Repeat(hex[1:2], 2) + strings.Repeat(hex[2:3], 2) + strings.Repeat(hex[3:4], 2)
+	}
+	if _, err := colorful.Hex(hex); err != nil {
+		return nil, fmt.Errorf("invalid color %q", s)
+	}
+	return lipgloss.Color(hex), nil
+}
+
+// parseOverrides resolves a variant's overrides to Palette field names.
+func parseOverrides(variant string, o map[string]string) (map[string]color.Color, error) {
+	colors := make(map[string]color.Color, len(o))
+	for k, v := range o {
+		field, ok := paletteFields[strings.ToLower(k)]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown palette color %q", variant, k)
+		}
+		c, err := parseColor(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", variant, k, err)
+		}
+		colors[field] = c
+	}
+	return colors, nil
+}
+
+// Spec converts f to a ThemeSpec, validating its colors.
+func (f File) Spec() (ThemeSpec, error) {
+	if f.Name == "" {
+		return ThemeSpec{}, errors.New("missing name")
+	}
+	spec := ThemeSpec{Name: f.Name}
+	core := []string{f.Primary, f.Secondary, f.Background, f.Surface, f.Foreground}
+	sv := reflect.ValueOf(&spec).Elem()
+	for i, field := range coreFields {
+		if core[i] == "" {
+			return ThemeSpec{}, fmt.Errorf("missing %s color", strings.ToLower(field))
+		}
+		c, err := parseColor(core[i])
+		if err != nil {
+			return ThemeSpec{}, fmt.Errorf("%s: %w", strings.ToLower(field), err)
+		}
+		sv.FieldByName(field).Set(reflect.ValueOf(&c).Elem())
+	}
+
+	dark, err := parseOverrides("dark", f.Dark)
+	if err != nil {
+		return ThemeSpec{}, err
+	}
+	light, err := parseOverrides("light", f.Light)
+	if err != nil {
+		return ThemeSpec{}, err
+	}
+	if len(dark) == 0 && len(light) == 0 {
+		return spec, nil
+	}
+
+	base := spec
+	spec.Modify = func(p Palette, isDark bool) Palette {
+		overrides := light
+		if isDark {
+			overrides = dark
+		}
+		variant, rederive := base, false
+		vv := reflect.ValueOf(&variant).Elem()
+		for _, field := range coreFields {
+			if c, ok := overrides[field]; ok {
+				vv.FieldByName(field).Set(reflect.ValueOf(&c).Elem())
+				rederive = true
+			}
+		}
+		if rederive {
+			p = buildPalette(variant, isDark)
+		}
+		pv := reflect.ValueOf(&p).Elem()
+		for field, c := range overrides {
+			pv.FieldByName(field).Set(reflect.ValueOf(&c).Elem())
+		}
+		return p
+	}
+	return spec, nil
+}
+
+// ReadFile reads a theme file. The format follows the extension: .json or
+// .toml. A theme without a name is named after the file.
+func ReadFile(path string) (File, error) {
+	var parser koanf.Parser
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		parser = koanfjson.Parser()
+	case ".toml":
+		parser = koanftoml.Parser()
+	default:
+		return File{}, fmt.Errorf("theme: %s: unsupported format", path)
+	}
+	k := koanf.New(".")
+	if err := k.Load(file.Provider(path), parser); err != nil {
+		return File{}, fmt.Errorf("theme: reading %s: %w", path, err)
+	}
+	var f File
+	if err := k.Unmarshal("", &f); err != nil {
+		return File{}, fmt.Errorf("theme: parsing %s: %w", path, err)
+	}
+	if f.Name == "" {
+		f.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return f, nil
+}
+
+// SaveFile writes f to a .json theme file.
+func SaveFile(path string, f File) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("theme: encoding %s: %w", f.Name, err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("theme: writing %s: %w", path, err)
+	}
+	return nil
+}
+
+// fileThemes names the registered themes that [LoadThemes] read from files.
+var fileThemes = map[string]bool{}
+
+// LoadThemes registers the themes in dir's *.json and *.toml files in place
+// of those loaded by the previous call, and returns their names. A missing
+// dir loads none.
+//
+// A file that cannot be read, or that names a built-in theme or one already
+// loaded from another file, is skipped and reported in warnings. So are the
+// [ValidatePalette] findings for each loaded theme's dark and light
+// variants, although those themes are still registered.
+func LoadThemes(dir string) (names, warnings []string) {
+	var paths []string
+	for _, pattern := range []string{"*.json", "*.toml"} {
+		matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+
+	var specs []ThemeSpec
+	seen := map[string]bool{}
+	for _, path := range paths {
+		f, err := ReadFile(path)
+		if err == nil && seen[f.Name] {
+			err = fmt.Errorf("theme %q is defined more than once", f.Name)
+		}
+		var spec ThemeSpec
+		if err == nil {
+			spec, err = f.Spec()
+		}
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %v", filepath.Base(path), err))
+			continue
+		}
+		seen[spec.Name] = true
+		specs = append(specs, spec)
+	}
+	if _, err := os.Stat(dir); err != nil && !errors.Is(err, os.ErrNotExist) {
+		warnings = append(warnings, err.Error())
+	}
+
+	registryMu.Lock()
+	for name := range fileThemes {
+		delete(themeRegistry, name)
+	}
+	fileThemes = map[string]bool{}
+	for _, spec := range specs {
+		if _, builtin := themeRegistry[spec.Name]; builtin {
+			warnings = append(warnings, fmt.Sprintf("theme %q would replace a built-in theme", spec.Name))
+			continue
+		}
+		themeRegistry[spec.Name] = spec
+		fileThemes[spec.Name] = true
+		names = append(names, spec.Name)
+	}
+	registryMu.Unlock()
+
+	for _, name := range names {
+		for _, isDark := range []bool{true, false} {
+			variant := "light"
+			if isDark {
+				variant = "dark"
+			}
+			for _, w := range ValidatePalette(NewPalette(name, isDark)) {
+				warnings = append(warnings, fmt.Sprintf("%s (%s): %s", name, variant, w))
+			}
+		}
+	}
+	return names, warnings
+}
@@ -0,0 +1,106 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTheme(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+}
+
+func TestLoadThemes_JSONAndTOML(t *testing.T) {
+	dir := t.TempDir()
+	t.Cleanup(func() { LoadThemes(t.TempDir()) })
+	writeTheme(t, dir, "dusk.json", `{
+		"primary": "#E0A526", "secondary": "#5B8DEF",
+		"background": "#1B1A22", "surface": "#24232D", "foreground": "#F2F0F7",
+		"dark": {"onPrimary": "#101010"},
+		"light": {"background": "#FAF8F2", "foreground": "#202024"}
+	}`)
+	writeTheme(t, dir, "moss.toml", `
+name = "moss garden"
+primary = "#5E9E6E"
+secondary = "#C6A15B"
+background = "#111A14"
+surface = "#18241C"
+foreground = "#EEF5EA"
+`)
+
+	names, warnings := LoadThemes(dir)
+	for _, w := range warnings {
+		assert.Regexp(t, `^(dusk|moss garden) \((dark|light)\): `, w, "only validation findings")
+	}
+	assert.Equal(t, []string{"dusk", "moss garden"}, names, "unnamed themes are named after their file")
+	assert.Contains(t, AvailableThemes(), "moss garden")
+
+	dark := NewPalette("dusk", true)
+	assert.Equal(t, lipgloss.Color("#E0A526"), dark.Primary)
+	assert.Equal(t, lipgloss.Color("#101010"), dark.OnPrimary)
+	assert.Equal(t, lipgloss.Color("#1B1A22"), dark.Background)
+
+	light := NewPalette("dusk", false)
+	assert.Equal(t, lipgloss.Color("#FAF8F2"), light.Background)
+	assert.Equal(t, withAlpha(lipgloss.Color("#202024"), 0.6), light.ForegroundMuted,
+		"colors derived from an overridden core color follow it")
+	assert.NotEqual(t, dark.OnPrimary, light.OnPrimary)
+}
+
+func TestLoadThemes_ReportsProblems(t *testing.T) {
+	dir := t.TempDir()
+	t.Cleanup(func() { LoadThemes(t.TempDir()) })
+	core := `"primary": "#E0A526", "secondary": "#5B8DEF", "background": "#1B1A22", "surface": "#24232D"`
+	writeTheme(t, dir, "a-broken.json", `{`)
+	writeTheme(t, dir, "b-missing.json", `{`+core+`}`)
+	writeTheme(t, dir, "c-badcolor.json", `{`+core+`, "foreground": "white"}`)
+	writeTheme(t, dir, "d-unknown.json", `{`+core+`, "foreground": "#F2F0F7", "dark": {"sparkle": "#FFFFFF"}}`)
+	writeTheme(t, dir, "e-builtin.json", `{"name": "ember", `+core+`, "foreground": "#F2F0F7"}`)
+	writeTheme(t, dir, "f-lowcontrast.json", `{`+core+`, "foreground": "#1C1B23"}`)
+
+	names, warnings := LoadThemes(dir)
+	assert.Equal(t, []string{"f-lowcontrast"}, names, "themes that only fail validation still load")
+	require.Greater(t, len(warnings), 5)
+	assert.Contains(t, warnings[0], "a-broken.json")
+	assert.Equal(t, "b-missing.json: missing foreground color", warnings[1])
+	assert.Equal(t, `c-badcolor.json: foreground: invalid color "white"`, warnings[2])
+	assert.Equal(t, `d-unknown.json: dark: unknown palette color "sparkle"`, warnings[3])
+	assert.Equal(t, `theme "ember" would replace a built-in theme`, warnings[4])
+	assert.Contains(t, warnings, "f-lowcontrast (dark): Foreground may have insufficient contrast with Background")
+	assert.Contains(t, warnings, "f-lowcontrast (light): Foreground may have insufficient contrast with Background")
+}
+
+func TestLoadThemes_ReplacesPreviousLoad(t *testing.T) {
+	dir := t.TempDir()
+	t.Cleanup(func() { LoadThemes(t.TempDir()) })
+	writeTheme(t, dir, "dusk.json", `{"primary": "#E0A526", "secondary": "#5B8DEF", "background": "#1B1A22", "surface": "#24232D", "foreground": "#F2F0F7"}`)
+	LoadThemes(dir)
+	require.Contains(t, AvailableThemes(), "dusk")
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "dusk.json")))
+	names, _ := LoadThemes(dir)
+	assert.Empty(t, names)
+	assert.NotContains(t, AvailableThemes(), "dusk")
+	assert.Contains(t, AvailableThemes(), "ember", "built-in themes stay")
+}
+
+func TestRegistry_ConcurrentUse(t *testing.T) {
+	t.Cleanup(func() { LoadThemes(t.TempDir()) })
+	dir := t.TempDir()
+	writeTheme(t, dir, "dusk.json", `{"primary": "#E0A526", "secondary": "#5B8DEF", "background": "#1B1A22", "surface": "#24232D", "foreground": "#F2F0F7"}`)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() { LoadThemes(dir) })
+		wg.Go(func() { _ = AvailableThemes() })
+		wg.Go(func() { _ = NewPalette("dusk", true) })
+	}
+	wg.Wait()
+	assert.Contains(t, AvailableThemes(), "dusk")
+}
@@ -3,15 +3,21 @@ package theme
 import (
 	"charm.land/huh/v2"
 	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
 )
 
 // HuhTheme returns a huh.Theme that matches the application palette for the given theme name.
 // Uses huh.ThemeFunc so huh drives isDark on every View() call.
 // Focused elements use Primary, unfocused use Secondary, descriptions use ForegroundMuted.
-// No background colors are applied.
-func HuhTheme(name string) huh.Theme {
+// No background colors are applied. Both variants are degraded to profile
+// once, rather than on every View() call.
+func HuhTheme(name string, profile colorprofile.Profile) huh.Theme {
+	palettes := map[bool]Palette{
+		true:  Degrade(NewPalette(name, true), profile),
+		false: Degrade(NewPalette(name, false), profile),
+	}
 	return huh.ThemeFunc(func(isDark bool) *huh.Styles {
-		p := NewPalette(name, isDark)
+		p := palettes[isDark]
 		t := huh.ThemeCharm(isDark)
 
 		// Focused state - use Primary for interactive elements
@@ -0,0 +1,567 @@
+package theme
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+	koanftoml "github.com/knadh/koanf/parsers/toml/v2"
+	colorful "github.com/lucasb-eyer/go-colorful"
+	"gopkg.in/yaml.v3"
+)
+
+// -----------------------------------------------------------------------------
+// Scheme Import
+// -----------------------------------------------------------------------------
+
+// SchemeFormat names a terminal or editor color scheme format that
+// [ParseScheme] reads.
+type SchemeFormat string
+
+// Supported scheme formats.
+const (
+	FormatBase16    SchemeFormat = "base16"    // base16/base24 YAML, classic or tinted-theming layout
+	FormatITerm2    SchemeFormat = "iterm2"    // .itermcolors plist
+	FormatAlacritty SchemeFormat = "alacritty" // alacritty.toml or the older alacritty.yml
+	FormatKitty     SchemeFormat = "kitty"     // kitty.conf color settings
+	FormatWezTerm   SchemeFormat = "wezterm"   // WezTerm TOML color scheme
+	FormatVSCode    SchemeFormat = "vscode"    // VS Code color theme JSON
+)
+
+// SchemeFormats lists the supported formats.
+var SchemeFormats = []SchemeFormat{FormatBase16, FormatITerm2, FormatAlacritty, FormatKitty, FormatWezTerm, FormatVSCode}
+
+// ansiNames are the names terminal configs give the eight normal colors.
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// Scheme is a color scheme read from another program's theme file. Colors
+// the file does not define are nil; Background and Foreground are always
+// set.
+type Scheme struct {
+	Name       string
+	Background color.Color
+	Foreground color.Color
+	Surface    color.Color // panels and sidebars, where the format has them
+	Primary    color.Color // accent colors, where the format has them
+	Secondary  color.Color
+	ANSI       [16]color.Color // normal colors 0-7, bright colors 8-15
+}
+
+// File maps s onto a theme file. The core colors come from the scheme's
+// own accents where it has them and otherwise from its ANSI blue and
+// magenta; Surface is lifted slightly from Background towards Foreground
+// when the scheme has none. ANSI red, green, yellow and cyan become the
+// Error, Success, Warning and Info colors of both variants.
+func (s Scheme) File() File {
+	surface := s.Surface
+	if surface == nil {
+		surface = blendColors(s.Background, s.Foreground, 0.06)
+	}
+	primary := firstColor(s.Primary, s.ANSI[4], s.ANSI[12], s.Foreground)
+	secondary := firstColor(s.Secondary, s.ANSI[5], s.ANSI[13], primary)
+
+	status := map[string]string{}
+	for _, st := range []struct {
+		field string
+		ansi  int
+	}{{"error", 1}, {"success", 2}, {"warning", 3}, {"info", 6}} {
+		c := firstColor(s.ANSI[st.ansi], s.ANSI[st.ansi+8])
+		if c == nil {
+			continue
+		}
+		status[st.field] = hexColor(c)
+		status["on"+strings.ToUpper(st.field[:1])+st.field[1:]] = hexColor(contrastingForeground(c))
+	}
+	var dark, light map[string]string
+	if len(status) > 0 {
+		dark, light = status, make(map[string]string, len(status))
+		for k, v := range status {
+			light[k] = v
+		}
+	}
+
+	return File{
+		Name:       s.Name,
+		Primary:    hexColor(primary),
+		Secondary:  hexColor(secondary),
+		Background: hexColor(s.Background),
+		Surface:    hexColor(surface),
+		Foreground: hexColor(s.Foreground),
+		Dark:       dark,
+		Light:      light,
+	}
+}
+
+// Spec converts s to a ThemeSpec via [Scheme.File].
+func (s Scheme) Spec() (ThemeSpec, error) {
+	return s.File().Spec()
+}
+
+// ReadScheme reads a scheme file in format, or in the format
+// [DetectSchemeFormat] finds if format is empty. A scheme without a name is
+// named after the file.
+func ReadScheme(path string, format SchemeFormat) (Scheme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Scheme{}, fmt.Errorf("theme: reading %s: %w", path, err)
+	}
+	if format == "" {
+		if format, err = DetectSchemeFormat(path, data); err != nil {
+			return Scheme{}, err
+		}
+	}
+	s, err := ParseScheme(data, format)
+	if err != nil {
+		return Scheme{}, fmt.Errorf("theme: parsing %s: %w", path, err)
+	}
+	if s.Name == "" {
+		s.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return s, nil
+}
+
+// DetectSchemeFormat guesses the format of a scheme file from its extension
+// and, where several formats share one, its content.
+func DetectSchemeFormat(path string, data []byte) (SchemeFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".itermcolors":
+		return FormatITerm2, nil
+	case ".yaml", ".yml":
+		if m, err := parseYAML(data); err == nil && lookupPath(m, "colors") != nil {
+			return FormatAlacritty, nil
+		}
+		return FormatBase16, nil
+	case ".toml":
+		if m, err := koanftoml.Parser().Unmarshal(data); err == nil && lookupPath(m, "colors", "ansi") != nil {
+			return FormatWezTerm, nil
+		}
+		return FormatAlacritty, nil
+	case ".json", ".jsonc":
+		return FormatVSCode, nil
+	case ".conf", "":
+		return FormatKitty, nil
+	}
+	if bytes.Contains(data, []byte("<plist")) {
+		return FormatITerm2, nil
+	}
+	return "", fmt.Errorf("theme: %s: unknown scheme format", path)
+}
+
+// ParseScheme parses a scheme in the given format.
+func ParseScheme(data []byte, format SchemeFormat) (Scheme, error) {
+	var (
+		s   Scheme
+		err error
+	)
+	switch format {
+	case FormatBase16:
+		s, err = parseBase16(data)
+	case FormatITerm2:
+		s, err = parseITerm2(data)
+	case FormatAlacritty:
+		s, err = parseAlacritty(data)
+	case FormatKitty:
+		s, err = parseKitty(data)
+	case FormatWezTerm:
+		s, err = parseWezTerm(data)
+	case FormatVSCode:
+		s, err = parseVSCode(data)
+	default:
+		return Scheme{}, fmt.Errorf("unknown scheme format %q", format)
+	}
+	if err != nil {
+		return Scheme{}, err
+	}
+	if s.Background == nil || s.Foreground == nil {
+		return Scheme{}, fmt.Errorf("%s scheme has no background or foreground color", format)
+	}
+	return s, nil
+}
+
+// parseBase16 reads a base16 or base24 scheme. The classic layout has the
+// baseXX colors at the top level next to "scheme"; the tinted-theming
+// layout nests them under "palette" next to "name".
+func parseBase16(data []byte) (Scheme, error) {
+	m, err := parseYAML(data)
+	if err != nil {
+		return Scheme{}, err
+	}
+	pal := m
+	if p, ok := m["palette"].(map[string]any); ok {
+		pal = p
+	}
+	base := func(n int) color.Color { return schemeColor(pal[fmt.Sprintf("base%02X", n)]) }
+
+	s := Scheme{
+		Name:       firstString(m["name"], m["scheme"]),
+		Background: base(0x00),
+		Surface:    base(0x01),
+		Foreground: base(0x05),
+		Primary:    base(0x0D),
+		Secondary:  base(0x0E),
+	}
+	// Standard base16 terminal mapping; base24 adds its own bright colors.
+	normal := []int{0x00, 0x08, 0x0B, 0x0A, 0x0D, 0x0E, 0x0C, 0x05}
+	bright := []int{0x03, 0x12, 0x14, 0x13, 0x16, 0x17, 0x15, 0x07}
+	for i := range 8 {
+		s.ANSI[i] = base(normal[i])
+		s.ANSI[i+8] = firstColor(base(bright[i]), s.ANSI[i])
+	}
+	return s, nil
+}
+
+// parseITerm2 reads an .itermcolors property list.
+func parseITerm2(data []byte) (Scheme, error) {
+	v, err := parsePlist(data)
+	if err != nil {
+		return Scheme{}, err
+	}
+	m, ok := v.(map[string]any)
+	if !ok {
+		return Scheme{}, errors.New("plist root is not a dictionary")
+	}
+	entry := func(key string) color.Color {
+		c, ok := m[key].(map[string]any)
+		if !ok {
+			return nil
+		}
+		r, _ := c["Red Component"].(float64)
+		g, _ := c["Green Component"].(float64)
+		b, _ := c["Blue Component"].(float64)
+		return lipgloss.Color(colorful.Color{R: r, G: g, B: b}.Clamped().Hex())
+	}
+	s := Scheme{
+		Background: entry("Background Color"),
+		Foreground: entry("Foreground Color"),
+	}
+	for i := range 16 {
+		s.ANSI[i] = entry(fmt.Sprintf("Ansi %d Color", i))
+	}
+	return s, nil
+}
+
+// parseAlacritty reads Alacritty's [colors] tables, from TOML or from the
+// YAML config older releases used.
+func parseAlacritty(data []byte) (Scheme, error) {
+	m, err := koanftoml.Parser().Unmarshal(data)
+	if err != nil {
+		if m, err = parseYAML(data); err != nil {
+			return Scheme{}, err
+		}
+	}
+	s := Scheme{
+		Background: schemeColor(lookupPath(m, "colors", "primary", "background")),
+		Foreground: schemeColor(lookupPath(m, "colors", "primary", "foreground")),
+	}
+	for i, name := range ansiNames {
+		s.ANSI[i] = schemeColor(lookupPath(m, "colors", "normal", name))
+		s.ANSI[i+8] = schemeColor(lookupPath(m, "colors", "bright", name))
+	}
+	return s, nil
+}
+
+// parseKitty reads the color settings of a kitty.conf or kitty theme. The
+// theme's name comes from its "## name:" header comment.
+func parseKitty(data []byte) (Scheme, error) {
+	var s Scheme
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if name, ok := strings.CutPrefix(line, "## name:"); ok {
+			s.Name = strings.TrimSpace(name)
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, c := fields[0], schemeColor(fields[1])
+		switch key {
+		case "background":
+			s.Background = c
+		case "foreground":
+			s.Foreground = c
+		default:
+			if n, err := strconv.Atoi(strings.TrimPrefix(key, "color")); err == nil && strings.HasPrefix(key, "color") && n >= 0 && n < 16 {
+				s.ANSI[n] = c
+			}
+		}
+	}
+	return s, nil
+}
+
+// parseWezTerm reads a WezTerm color scheme file: a [colors] table with
+// ansi and brights arrays, and the name under [metadata].
+func parseWezTerm(data []byte) (Scheme, error) {
+	m, err := koanftoml.Parser().Unmarshal(data)
+	if err != nil {
+		return Scheme{}, err
+	}
+	s := Scheme{
+		Name:       firstString(lookupPath(m, "metadata", "name")),
+		Background: schemeColor(lookupPath(m, "colors", "background")),
+		Foreground: schemeColor(lookupPath(m, "colors", "foreground")),
+	}
+	for off, key := range map[int]string{0: "ansi", 8: "brights"} {
+		list, _ := lookupPath(m, "colors", key).([]any)
+		for i := 0; i < len(list) && i < 8; i++ {
+			s.ANSI[off+i] = schemeColor(list[i])
+		}
+	}
+	return s, nil
+}
+
+// parseVSCode reads a VS Code color theme. Its workbench colors give the
+// editor background and foreground, the sidebar as Surface and the button
+// and link colors as accents; its terminal colors give the ANSI colors.
+func parseVSCode(data []byte) (Scheme, error) {
+	var theme struct {
+		Name   string            `json:"name"`
+		Colors map[string]string `json:"colors"`
+	}
+	if err := json.Unmarshal(stripJSONC(data), &theme); err != nil {
+		return Scheme{}, err
+	}
+	c := func(keys ...string) color.Color {
+		for _, k := range keys {
+			if v := schemeColor(theme.Colors[k]); v != nil {
+				return v
+			}
+		}
+		return nil
+	}
+	s := Scheme{
+		Name:       theme.Name,
+		Background: c("editor.background", "terminal.background"),
+		Foreground: c("editor.foreground", "foreground", "terminal.foreground"),
+		Surface:    c("sideBar.background", "editorWidget.background", "panel.background"),
+		Primary:    c("button.background", "focusBorder", "activityBarBadge.background"),
+		Secondary:  c("textLink.foreground", "badge.background"),
+	}
+	for i, name := range ansiNames {
+		title := strings.ToUpper(name[:1]) + name[1:]
+		s.ANSI[i] = c("terminal.ansi" + title)
+		s.ANSI[i+8] = c("terminal.ansiBright" + title)
+	}
+	return s, nil
+}
+
+// -----------------------------------------------------------------------------
+// Scheme Helpers
+// -----------------------------------------------------------------------------
+
+// schemeColor parses the hex notations found in scheme files: "#RGB",
+// "#RRGGBB" and "#RRGGBBAA" (alpha dropped), also without "#" or with a
+// "0x" prefix. Anything else yields nil.
+func schemeColor(v any) color.Color {
+	s, ok := v.(string)
+	if !ok {
+		return nil
+	}
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(s, "#"), "0x"), "0X")
+	switch len(s) {
+	case 3:
+		s = strings.Repeat(s[0:1], 2) + strings.Repeat(s[1:2], 2) + strings.Repeat(s[2:3], 2)
+	case 8:
+		s = s[:6]
+	}
+	c, err := colorful.Hex("#" + s)
+	if err != nil || len(s) != 6 {
+		return nil
+	}
+	return lipgloss.Color(strings.ToUpper(c.Hex()))
+}
+
+// hexColor formats c as "#RRGGBB".
+func hexColor(c color.Color) string {
+	cf, _ := colorful.MakeColor(c)
+	return strings.ToUpper(cf.Hex())
+}
+
+// blendColors mixes t of b into a in CIE-L*a*b* space.
+func blendColors(a, b color.Color, t float64) color.Color {
+	ca, _ := colorful.MakeColor(a)
+	cb, _ := colorful.MakeColor(b)
+	return lipgloss.Color(ca.BlendLab(cb, t).Clamped().Hex())
+}
+
+// firstColor returns the first non-nil color.
+func firstColor(cs ...color.Color) color.Color {
+	for _, c := range cs {
+		if c != nil {
+			return c
+		}
+	}
+	return nil
+}
+
+// firstString returns the first non-empty string among vs.
+func firstString(vs ...any) string {
+	for _, v := range vs {
+		if s, ok := v.(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// lookupPath walks nested maps along path, returning nil when it leaves
+// them.
+func lookupPath(m map[string]any, path ...string) any {
+	var v any = m
+	for _, k := range path {
+		mm, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = mm[k]
+	}
+	return v
+}
+
+// parseYAML decodes a YAML mapping.
+func parseYAML(data []byte) (map[string]any, error) {
+	var m map[string]any
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, errors.New("empty document")
+	}
+	return m, nil
+}
+
+// parsePlist decodes an XML property list into maps, slices, strings,
+// float64s and bools.
+func parsePlist(data []byte) (any, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	inPlist := false
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return nil, fmt.Errorf("plist: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Local == "plist" && !inPlist {
+			inPlist = true
+			continue
+		}
+		return decodePlistValue(d, start)
+	}
+}
+
+// decodePlistValue decodes the value element opened by start.
+func decodePlistValue(d *xml.Decoder, start xml.StartElement) (any, error) {
+	switch start.Name.Local {
+	case "dict", "array":
+		m := map[string]any{}
+		var list []any
+		var key string
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				return nil, fmt.Errorf("plist: %w", err)
+			}
+			switch t := tok.(type) {
+			case xml.StartElement:
+				if t.Name.Local == "key" {
+					if err := d.DecodeElement(&key, &t); err != nil {
+						return nil, fmt.Errorf("plist: %w", err)
+					}
+					continue
+				}
+				v, err := decodePlistValue(d, t)
+				if err != nil {
+					return nil, err
+				}
+				if start.Name.Local == "dict" {
+					m[key] = v
+				} else {
+					list = append(list, v)
+				}
+			case xml.EndElement:
+				if start.Name.Local == "dict" {
+					return m, nil
+				}
+				return list, nil
+			}
+		}
+	case "true", "false":
+		if err := d.Skip(); err != nil {
+			return nil, fmt.Errorf("plist: %w", err)
+		}
+		return start.Name.Local == "true", nil
+	}
+	var text string
+	if err := d.DecodeElement(&text, &start); err != nil {
+		return nil, fmt.Errorf("plist: %w", err)
+	}
+	if start.Name.Local == "real" || start.Name.Local == "integer" {
+		f, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+		if err != nil {
+			return nil, fmt.Errorf("plist: %w", err)
+		}
+		return f, nil
+	}
+	return text, nil
+}
+
+// stripJSONC removes the comments and trailing commas VS Code allows in
+// its JSON files: comments first, so a comment cannot hide a trailing comma.
+func stripJSONC(data []byte) []byte {
+	return scanJSON(scanJSON(data, true), false)
+}
+
+// scanJSON copies data, leaving strings intact and dropping either the
+// comments or the commas that directly precede a closing bracket.
+func scanJSON(data []byte, comments bool) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+		case comments && c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i+1 < len(data) && data[i+1] != '\n' {
+				i++
+			}
+			continue
+		case comments && c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return out
+			}
+			i += end + 3
+			continue
+		case !comments && c == ',':
+			rest := bytes.TrimLeft(data[i+1:], " \t\r\n")
+			if len(rest) > 0 && (rest[0] == '}' || rest[0] == ']') {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,167 @@
+package theme
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// importCases holds one small scheme per format, each with background
+// #101418, foreground #D8DEE9, blue #5E81AC and red #BF616A.
+var importCases = []struct {
+	file   string
+	format SchemeFormat
+	name   string
+	data   string
+}{
+	{"ocean.yaml", FormatBase16, "Ocean", `
+scheme: "Ocean"
+base00: "101418"
+base01: "1C2128"
+base05: "D8DEE9"
+base08: "BF616A"
+base0D: "5E81AC"
+base0E: "B48EAD"
+`},
+	{"tinted.yaml", FormatBase16, "Tinted", `
+system: "base24"
+name: "Tinted"
+palette:
+  base00: "#101418"
+  base05: "#D8DEE9"
+  base08: "#BF616A"
+  base0D: "#5E81AC"
+  base12: "#D08770"
+`},
+	{"night.itermcolors", FormatITerm2, "night", `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Ansi 1 Color</key>
+	<dict>
+		<key>Blue Component</key><real>0.41568627450980394</real>
+		<key>Green Component</key><real>0.38039215686274508</real>
+		<key>Red Component</key><real>0.74901960784313726</real>
+	</dict>
+	<key>Ansi 4 Color</key>
+	<dict>
+		<key>Blue Component</key><real>0.67450980392156867</real>
+		<key>Green Component</key><real>0.50588235294117645</real>
+		<key>Red Component</key><real>0.36862745098039218</real>
+	</dict>
+	<key>Background Color</key>
+	<dict>
+		<key>Blue Component</key><real>0.094117647058823528</real>
+		<key>Green Component</key><real>0.078431372549019607</real>
+		<key>Red Component</key><real>0.062745098039215685</real>
+	</dict>
+	<key>Foreground Color</key>
+	<dict>
+		<key>Blue Component</key><real>0.9137254901960784</real>
+		<key>Green Component</key><real>0.87058823529411766</real>
+		<key>Red Component</key><real>0.84705882352941175</real>
+		<key>Color Space</key><string>sRGB</string>
+	</dict>
+</dict>
+</plist>
+`},
+	{"alacritty.toml", FormatAlacritty, "alacritty", `
+[colors.primary]
+background = "#101418"
+foreground = "#d8dee9"
+
+[colors.normal]
+red = "#bf616a"
+blue = "#5e81ac"
+`},
+	{"alacritty.yml", FormatAlacritty, "alacritty", `
+colors:
+  primary:
+    background: '0x101418'
+    foreground: '0xd8dee9'
+  normal:
+    red: '0xbf616a'
+    blue: '0x5e81ac'
+`},
+	{"frost.conf", FormatKitty, "Frost", `
+## name: Frost
+# comment
+background #101418
+foreground #d8dee9
+color1     #bf616a
+color4     #5e81ac
+`},
+	{"wez.toml", FormatWezTerm, "Wez", `
+[colors]
+background = "#101418"
+foreground = "#d8dee9"
+ansi = ["#101418", "#bf616a", "#a3be8c", "#ebcb8b", "#5e81ac", "#b48ead", "#88c0d0", "#e5e9f0"]
+brights = ["#4c566a", "#bf616a", "#a3be8c", "#ebcb8b", "#81a1c1", "#b48ead", "#8fbcbb", "#eceff4"]
+
+[metadata]
+name = "Wez"
+`},
+	{"code.json", FormatVSCode, "Code", `{
+	// VS Code allows comments
+	"name": "Code",
+	"type": "dark",
+	"colors": {
+		"editor.background": "#101418",
+		"editor.foreground": "#D8DEE9ff", /* alpha is dropped */
+		"terminal.ansiRed": "#BF616A",
+		"terminal.ansiBlue": "#5E81AC",
+	},
+}`},
+}
+
+func TestReadScheme_Formats(t *testing.T) {
+	dir := t.TempDir()
+	for _, tc := range importCases {
+		t.Run(tc.file, func(t *testing.T) {
+			path := filepath.Join(dir, tc.file)
+			require.NoError(t, os.WriteFile(path, []byte(tc.data), 0o644))
+			format, err := DetectSchemeFormat(path, []byte(tc.data))
+			require.NoError(t, err)
+			assert.Equal(t, tc.format, format)
+
+			s, err := ReadScheme(path, "")
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, s.Name)
+			assert.Equal(t, lipgloss.Color("#101418"), s.Background)
+			assert.Equal(t, lipgloss.Color("#D8DEE9"), s.Foreground)
+			assert.Equal(t, lipgloss.Color("#BF616A"), s.ANSI[1])
+
+			f := s.File()
+			assert.Equal(t, "#5E81AC", f.Primary, "accent falls back to ANSI blue")
+			assert.Equal(t, "#BF616A", f.Dark["error"])
+			assert.Equal(t, f.Dark, f.Light)
+			_, err = f.Spec()
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestScheme_FileUsesSchemeAccents(t *testing.T) {
+	s, err := ParseScheme([]byte(importCases[0].data), FormatBase16)
+	require.NoError(t, err)
+	f := s.File()
+	assert.Equal(t, "#1C2128", f.Surface, "base01 is the surface")
+	assert.Equal(t, "#B48EAD", f.Secondary, "base0E is the secondary accent")
+	assert.Equal(t, lipgloss.Color("#BF616A"), s.ANSI[9], "base16 brights repeat the normal colors")
+
+	s, err = ParseScheme([]byte(importCases[1].data), FormatBase16)
+	require.NoError(t, err)
+	assert.Equal(t, lipgloss.Color("#D08770"), s.ANSI[9], "base24 has its own brights")
+	assert.NotEmpty(t, s.File().Surface, "a missing surface is derived")
+}
+
+func TestParseScheme_RequiresBackgroundAndForeground(t *testing.T) {
+	_, err := ParseScheme([]byte("background #101418\n"), FormatKitty)
+	assert.ErrorContains(t, err, "no background or foreground")
+	_, err = ParseScheme(nil, "emacs")
+	assert.ErrorContains(t, err, "unknown scheme format")
+}
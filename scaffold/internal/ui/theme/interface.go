@@ -1,11 +1,17 @@
 package theme
 
+import "github.com/charmbracelet/colorprofile"
+
 // State represents the complete theme state.
 type State struct {
 	Name    string  // theme name (e.g., "ocean", "forest")
 	IsDark  bool    // dark/light mode
 	Palette Palette // cached palette (computed once)
 	Width   int     // for width-dependent styles
+	Compact bool    // reduced vertical spacing (UIConfig.CompactMode)
+
+	// Profile is the terminal's color profile; Palette is degraded to it.
+	Profile colorprofile.Profile
 }
 
 // Themeable is implemented by components that need theme updates.
@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/colorprofile"
 )
 
 var (
@@ -25,21 +26,23 @@ func GetManager() *Manager {
 type Manager struct {
 	mu           sync.RWMutex
 	state        State
-	paletteCache map[string]map[bool]Palette // name -> isDark -> Palette
+	paletteCache map[string]map[bool]Palette // name -> isDark -> degraded Palette
 }
 
 // Init initializes the manager and returns initial theme command.
 // If width is 0, no command is returned (will be triggered by first WindowSizeMsg).
-func (m *Manager) Init(name string, isDark bool, width int) tea.Cmd {
+func (m *Manager) Init(name string, isDark, compact bool, width int) tea.Cmd {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	m.state = State{
 		Name:    name,
 		IsDark:  isDark,
-		Palette: m.getCachedPalette(name, isDark),
 		Width:   width,
+		Compact: compact,
+		Profile: m.state.Profile,
 	}
+	m.state.Palette = m.getCachedPalette(name, isDark)
 
 	// Don't fire theme update until we have a valid width
 	if width > 0 {
@@ -48,7 +51,8 @@ func (m *Manager) Init(name string, isDark bool, width int) tea.Cmd {
 	return nil
 }
 
-// getCachedPalette returns cached palette or creates and caches one.
+// getCachedPalette returns cached palette or creates and caches one,
+// degraded to the current color profile.
 func (m *Manager) getCachedPalette(name string, isDark bool) Palette {
 	if m.paletteCache[name] == nil {
 		m.paletteCache[name] = make(map[bool]Palette)
@@ -56,7 +60,7 @@ func (m *Manager) getCachedPalette(name string, isDark bool) Palette {
 	if p, ok := m.paletteCache[name][isDark]; ok {
 		return p
 	}
-	p := NewPalette(name, isDark)
+	p := Degrade(NewPalette(name, isDark), m.state.Profile)
 	m.paletteCache[name][isDark] = p
 	return p
 }
@@ -99,6 +103,45 @@ func (m *Manager) SetWidth(width int) tea.Cmd {
 	return RequestThemeUpdate(m.state)
 }
 
+// SetCompact updates layout density and returns command if changed.
+func (m *Manager) SetCompact(compact bool) tea.Cmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state.Compact == compact {
+		return nil
+	}
+	m.state.Compact = compact
+	return RequestThemeUpdate(m.state)
+}
+
+// SetProfile updates the terminal color profile palettes are degraded to
+// and returns command if changed.
+func (m *Manager) SetProfile(profile colorprofile.Profile) tea.Cmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state.Profile == profile {
+		return nil
+	}
+	m.state.Profile = profile
+	m.paletteCache = make(map[string]map[bool]Palette)
+	m.state.Palette = m.getCachedPalette(m.state.Name, m.state.IsDark)
+	return RequestThemeUpdate(m.state)
+}
+
+// Reload drops the cached palettes, so themes registered or replaced since
+// they were built take effect, and returns a command re-sending the current
+// state. The current theme falls back to "default" if it no longer exists.
+func (m *Manager) Reload() tea.Cmd {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.paletteCache = make(map[string]map[bool]Palette)
+	m.state.Palette = m.getCachedPalette(m.state.Name, m.state.IsDark)
+	return RequestThemeUpdate(m.state)
+}
+
 // State returns current theme state (read-only).
 func (m *Manager) State() State {
 	m.mu.RLock()
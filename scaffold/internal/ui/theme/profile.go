@@ -0,0 +1,195 @@
+package theme
+
+import (
+	"cmp"
+	"image/color"
+	"reflect"
+	"slices"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+	colorful "github.com/lucasb-eyer/go-colorful"
+)
+
+// -----------------------------------------------------------------------------
+// Color Profile Degradation
+// -----------------------------------------------------------------------------
+
+// distinctPairs lists the Palette fields that must not collapse onto the
+// same terminal color: text and the color it is drawn on, and colors that
+// are told apart by hue alone.
+var distinctPairs = [][2]string{
+	{"Foreground", "Background"},
+	{"Foreground", "Surface"},
+	{"Foreground", "SurfaceRaised"},
+	{"Foreground", "ForegroundMuted"},
+	{"ForegroundMuted", "Background"},
+	{"ForegroundSubtle", "Background"},
+	{"Primary", "Background"},
+	{"Primary", "OnPrimary"},
+	{"PrimaryMuted", "OnPrimary"},
+	{"Secondary", "Background"},
+	{"Secondary", "OnSecondary"},
+	{"Primary", "Secondary"},
+	{"Success", "OnSuccess"},
+	{"Error", "OnError"},
+	{"Warning", "OnWarning"},
+	{"Info", "OnInfo"},
+	{"Success", "Background"},
+	{"Error", "Background"},
+	{"Warning", "Background"},
+	{"Info", "Background"},
+	{"Success", "Error"},
+	{"Success", "Warning"},
+	{"Success", "Info"},
+	{"Error", "Warning"},
+	{"Error", "Info"},
+	{"Warning", "Info"},
+}
+
+// minHueChroma is the HCL chroma from which a color is told by its hue.
+// Such a color is mapped to a terminal color with a hue, even where a gray
+// is nearer: in sixteen colors, light blues are otherwise nearest to gray.
+const minHueChroma = 0.15
+
+// chroma returns the HCL chroma of c.
+func chroma(c color.Color) float64 {
+	cf, ok := colorful.MakeColor(c)
+	if !ok {
+		return 0
+	}
+	_, ch, _ := cf.Hcl()
+	return ch
+}
+
+// Degrade maps p onto the colors a terminal with the given profile can show.
+//
+// TrueColor and Unknown return p unchanged. ANSI256 and ANSI replace every
+// color, in field order, with the perceptually nearest (CIEDE2000) color of
+// the xterm 256-color cube and gray ramp, or of the sixteen ANSI colors.
+// Colors with a hue (see [minHueChroma]) fall back to grays only when no
+// colored candidate fits. Where the nearest would bring a color within
+// [minStatusColorDistance] of one it must stay apart from (less, if the two
+// were already closer in p), the next nearest is taken instead. ASCII and
+// NoTTY, which is what NO_COLOR selects, return the [Monochrome] palette.
+func Degrade(p Palette, profile colorprofile.Profile) Palette {
+	switch profile {
+	case colorprofile.ANSI256:
+		candidates := make([]color.Color, 0, 240)
+		for i := 16; i < 256; i++ { // 0-15 are the terminal's own palette
+			candidates = append(candidates, lipgloss.ANSIColor(i))
+		}
+		return degradeTo(p, candidates)
+	case colorprofile.ANSI:
+		candidates := make([]color.Color, 16)
+		for i := range candidates {
+			candidates[i] = ansi.BasicColor(i)
+		}
+		return degradeTo(p, candidates)
+	case colorprofile.ASCII, colorprofile.NoTTY:
+		return Monochrome()
+	}
+	return p
+}
+
+// degradeTo replaces every color of p with one of candidates; see [Degrade].
+func degradeTo(p Palette, candidates []color.Color) Palette {
+	original := map[string]color.Color{}
+	for _, c := range p.Colors() {
+		original[c.Name] = c.Color
+	}
+	mapped := map[string]color.Color{}
+
+	// keepsApart reports whether c, as the color of field name, stays far
+	// enough from the fields already mapped that it must differ from.
+	keepsApart := func(name string, c color.Color) bool {
+		for _, pair := range distinctPairs {
+			other := pair[1]
+			if pair[1] == name {
+				other = pair[0]
+			} else if pair[0] != name {
+				continue
+			}
+			m, ok := mapped[other]
+			if !ok {
+				continue
+			}
+			want := min(colorDistance(original[name], original[other]), minStatusColorDistance)
+			if colorDistance(c, m) < want {
+				return false
+			}
+		}
+		return true
+	}
+
+	type ranked struct {
+		color    color.Color
+		hueLost  bool
+		distance float64
+	}
+	gray := make([]bool, len(candidates))
+	for i, c := range candidates {
+		gray[i] = chroma(c) < minHueChroma
+	}
+	v := reflect.ValueOf(&p).Elem()
+	for _, nc := range p.Colors() {
+		if nc.Color == nil {
+			continue
+		}
+		hued := chroma(nc.Color) >= minHueChroma
+		ranking := make([]ranked, len(candidates))
+		for i, c := range candidates {
+			ranking[i] = ranked{c, hued && gray[i], colorDistance(nc.Color, c)}
+		}
+		slices.SortStableFunc(ranking, func(a, b ranked) int {
+			switch {
+			case a.hueLost && !b.hueLost:
+				return 1
+			case !a.hueLost && b.hueLost:
+				return -1
+			}
+			return cmp.Compare(a.distance, b.distance)
+		})
+
+		best := ranking[0].color
+		for _, r := range ranking {
+			if keepsApart(nc.Name, r.color) {
+				best = r.color
+				break
+			}
+		}
+		mapped[nc.Name] = best
+		v.FieldByName(nc.Name).Set(reflect.ValueOf(&best).Elem())
+	}
+	return p
+}
+
+// Monochrome returns the palette for terminals without color. Every color
+// is lipgloss.NoColor, so text keeps the terminal's own colors, and the
+// style builders mark emphasis with bold, underline and reverse instead;
+// see [Palette.Monochrome] and [Palette.Fill].
+func Monochrome() Palette {
+	var p Palette
+	v := reflect.ValueOf(&p).Elem()
+	none := reflect.ValueOf(lipgloss.NoColor{})
+	for i := range v.NumField() {
+		v.Field(i).Set(none)
+	}
+	return p
+}
+
+// Monochrome reports whether p is the [Monochrome] palette.
+func (p Palette) Monochrome() bool {
+	_, ok := p.Foreground.(lipgloss.NoColor)
+	return ok
+}
+
+// Fill returns s drawn in fg on a bg fill, or in reverse video when p is
+// monochrome.
+func (p Palette) Fill(s lipgloss.Style, bg, fg color.Color) lipgloss.Style {
+	if p.Monochrome() {
+		return s.Reverse(true)
+	}
+	return s.Background(bg).Foreground(fg)
+}
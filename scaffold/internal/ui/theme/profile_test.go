@@ -0,0 +1,78 @@
+package theme
+
+import (
+	"testing"
+
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/colorprofile"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDegrade_TrueColorKeepsPalette(t *testing.T) {
+	p := NewPalette("ember", true)
+	assert.Equal(t, p, Degrade(p, colorprofile.TrueColor))
+	assert.Equal(t, p, Degrade(p, colorprofile.Unknown))
+}
+
+func TestDegrade_ANSI256UsesCubeAndGrayRamp(t *testing.T) {
+	p := Degrade(NewPalette("default", true), colorprofile.ANSI256)
+	for _, c := range p.Colors() {
+		idx, ok := c.Color.(lipgloss.ANSIColor)
+		require.True(t, ok, "%s is %T", c.Name, c.Color)
+		assert.GreaterOrEqual(t, int(idx), 16, c.Name)
+	}
+	assert.Equal(t, lipgloss.ANSIColor(37), p.Primary, "#10B1AE is nearest to cube color 37")
+}
+
+func TestDegrade_ANSIKeepsPairsApart(t *testing.T) {
+	for _, name := range AvailableThemes() {
+		for _, isDark := range []bool{true, false} {
+			p := Degrade(NewPalette(name, isDark), colorprofile.ANSI)
+			colors := map[string]any{}
+			for _, c := range p.Colors() {
+				_, ok := c.Color.(ansi.BasicColor)
+				require.True(t, ok, "%s %s is %T", name, c.Name, c.Color)
+				colors[c.Name] = c.Color
+			}
+			for _, pair := range distinctPairs {
+				assert.NotEqual(t, colors[pair[0]], colors[pair[1]], "%s (dark=%v): %s/%s", name, isDark, pair[0], pair[1])
+			}
+		}
+	}
+}
+
+func TestDegrade_NoColorIsMonochrome(t *testing.T) {
+	p := NewPalette("ember", true)
+	assert.False(t, p.Monochrome())
+	for _, profile := range []colorprofile.Profile{colorprofile.ASCII, colorprofile.NoTTY} {
+		mono := Degrade(p, profile)
+		assert.True(t, mono.Monochrome())
+		assert.Equal(t, Monochrome(), mono)
+	}
+
+	fill := Monochrome().Fill(lipgloss.NewStyle(), p.Primary, p.OnPrimary)
+	assert.True(t, fill.GetReverse())
+	assert.Equal(t, lipgloss.NoColor{}, fill.GetBackground())
+	fill = p.Fill(lipgloss.NewStyle(), p.Primary, p.OnPrimary)
+	assert.False(t, fill.GetReverse())
+	assert.Equal(t, p.Primary, fill.GetBackground())
+}
+
+func TestManager_SetProfile(t *testing.T) {
+	m := &Manager{paletteCache: make(map[string]map[bool]Palette)}
+	m.Init("nord", true, false, 80)
+	require.Nil(t, m.SetProfile(colorprofile.Unknown))
+
+	cmd := m.SetProfile(colorprofile.ANSI256)
+	require.NotNil(t, cmd)
+	state := cmd().(ThemeChangedMsg).State
+	assert.Equal(t, colorprofile.ANSI256, state.Profile)
+	assert.IsType(t, lipgloss.ANSIColor(0), state.Palette.Primary)
+
+	m.SetDarkMode(false)
+	assert.IsType(t, lipgloss.ANSIColor(0), m.State().Palette.Primary, "variants are degraded too")
+	m.Init("nord", true, false, 80)
+	assert.Equal(t, colorprofile.ANSI256, m.State().Profile, "Init keeps the detected profile")
+}
@@ -1,15 +1,20 @@
 // Package theme manages application color themes, including palette generation,
 // perceptually uniform color manipulation, and lipgloss/huh style construction.
-// Themes are registered via [RegisterTheme] and queried through [NewPalette].
+// Themes are registered via [RegisterTheme], or loaded from theme files by
+// [LoadThemes], and queried through [NewPalette].
 package theme
 
 import (
 	"fmt"
 	"image/color"
 	"math"
+	"reflect"
 	"sort"
+	"sync"
 
 	"charm.land/bubbles/v2/list"
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 	colorful "github.com/lucasb-eyer/go-colorful"
 )
@@ -142,32 +147,71 @@ func GenerateVariants(base color.Color) []ColorVariant {
 // Palette Validation
 // -----------------------------------------------------------------------------
 
-// ValidatePalette checks that palette colors meet perceptual distance requirements.
-// Returns warnings for colors that are too similar (confusion risk).
-func ValidatePalette(p Palette) []string {
-	const (
-		minTextContrastDistance = 0.5
-		minStatusColorDistance  = 0.15
-	)
+// Contrast is one pair of colors [ValidatePalette] checks: text against
+// the color it is drawn on, or two status colors that must stay apart.
+type Contrast struct {
+	Name     string // "Foreground/Background", "Success/Error", ...
+	A, B     color.Color
+	Distance float64 // perceptual distance (CIEDE2000)
+	Min      float64 // smallest acceptable Distance
+	Ratio    float64 // WCAG 2 contrast ratio, 1 to 21
 
-	var warnings []string
+	warning string
+}
+
+// OK reports whether the pair is far enough apart.
+func (c Contrast) OK() bool { return c.Distance >= c.Min }
 
-	// Check text contrast with background
-	if dist := colorDistance(p.Foreground, p.Background); dist < minTextContrastDistance {
-		warnings = append(warnings, "Foreground may have insufficient contrast with Background")
+// contrastRatio returns the WCAG 2 contrast ratio of two colors.
+func contrastRatio(c1, c2 color.Color) float64 {
+	luminance := func(c color.Color) float64 {
+		cf, ok := colorful.MakeColor(c)
+		if !ok {
+			return 0
+		}
+		r, g, b := cf.LinearRgb()
+		return 0.2126*r + 0.7152*g + 0.0722*b
+	}
+	l1, l2 := luminance(c1), luminance(c2)
+	if l1 < l2 {
+		l1, l2 = l2, l1
 	}
+	return (l1 + 0.05) / (l2 + 0.05)
+}
 
-	// Check primary/on-primary contrast
-	if dist := colorDistance(p.Primary, p.OnPrimary); dist < minTextContrastDistance {
-		warnings = append(warnings, "Primary and OnPrimary may have insufficient contrast")
+// Smallest perceptual distances (CIEDE2000) [ValidatePalette] accepts
+// between text and the color it is drawn on, and between status colors.
+const (
+	minTextContrastDistance = 0.5
+	minStatusColorDistance  = 0.15
+)
+
+// Contrasts returns the pairs of p that [ValidatePalette] checks, in the
+// order it reports them.
+func Contrasts(p Palette) []Contrast {
+	pair := func(name string, a, b color.Color, minDist float64, warning string) Contrast {
+		return Contrast{
+			Name:     name,
+			A:        a,
+			B:        b,
+			Distance: colorDistance(a, b),
+			Min:      minDist,
+			Ratio:    contrastRatio(a, b),
+			warning:  warning,
+		}
 	}
 
-	// Check secondary/on-secondary contrast
-	if dist := colorDistance(p.Secondary, p.OnSecondary); dist < minTextContrastDistance {
-		warnings = append(warnings, "Secondary and OnSecondary may have insufficient contrast")
+	// Text contrast with background, primary and secondary fills
+	checks := []Contrast{
+		pair("Foreground/Background", p.Foreground, p.Background, minTextContrastDistance,
+			"Foreground may have insufficient contrast with Background"),
+		pair("Primary/OnPrimary", p.Primary, p.OnPrimary, minTextContrastDistance,
+			"Primary and OnPrimary may have insufficient contrast"),
+		pair("Secondary/OnSecondary", p.Secondary, p.OnSecondary, minTextContrastDistance,
+			"Secondary and OnSecondary may have insufficient contrast"),
 	}
 
-	// Check status/on-status contrast
+	// Status/on-status contrast
 	statusChecks := []struct {
 		name string
 		col  color.Color
@@ -180,33 +224,32 @@ func ValidatePalette(p Palette) []string {
 	}
 
 	for _, check := range statusChecks {
-		if dist := colorDistance(check.col, check.on); dist < minTextContrastDistance {
-			warnings = append(warnings, fmt.Sprintf("%s and On%s may have insufficient contrast", check.name, check.name))
-		}
+		checks = append(checks, pair(check.name+"/On"+check.name, check.col, check.on, minTextContrastDistance,
+			fmt.Sprintf("%s and On%s may have insufficient contrast", check.name, check.name)))
 	}
 
-	// Check status color distinctness
-	statusColors := []struct {
-		name string
-		col  color.Color
-	}{
-		{"Success", p.Success},
-		{"Error", p.Error},
-		{"Warning", p.Warning},
-		{"Info", p.Info},
-	}
-
-	for i := 0; i < len(statusColors); i++ {
-		for j := i + 1; j < len(statusColors); j++ {
-			dist := colorDistance(statusColors[i].col, statusColors[j].col)
-			if dist < minStatusColorDistance {
-				warnings = append(warnings, fmt.Sprintf(
-					"%s and %s are too similar (distance: %.2f)",
-					statusColors[i].name, statusColors[j].name, dist))
-			}
+	// Status color distinctness
+	for i := 0; i < len(statusChecks); i++ {
+		for j := i + 1; j < len(statusChecks); j++ {
+			a, b := statusChecks[i], statusChecks[j]
+			c := pair(a.name+"/"+b.name, a.col, b.col, minStatusColorDistance, "")
+			c.warning = fmt.Sprintf("%s and %s are too similar (distance: %.2f)", a.name, b.name, c.Distance)
+			checks = append(checks, c)
 		}
 	}
 
+	return checks
+}
+
+// ValidatePalette checks that palette colors meet perceptual distance requirements.
+// Returns warnings for colors that are too similar (confusion risk).
+func ValidatePalette(p Palette) []string {
+	var warnings []string
+	for _, c := range Contrasts(p) {
+		if !c.OK() {
+			warnings = append(warnings, c.warning)
+		}
+	}
 	return warnings
 }
 
@@ -250,6 +293,23 @@ type Palette struct {
 	OnInfo    color.Color // high contrast text on Info
 }
 
+// NamedColor is one color of a [Palette], named after its field.
+type NamedColor struct {
+	Name  string
+	Color color.Color
+}
+
+// Colors returns every color of p in field order.
+func (p Palette) Colors() []NamedColor {
+	v := reflect.ValueOf(p)
+	colors := make([]NamedColor, v.NumField())
+	for i := range colors {
+		c, _ := v.Field(i).Interface().(color.Color)
+		colors[i] = NamedColor{Name: v.Type().Field(i).Name, Color: c}
+	}
+	return colors
+}
+
 // -----------------------------------------------------------------------------
 // Available Themes
 // -----------------------------------------------------------------------------
@@ -269,21 +329,36 @@ type ThemeSpec struct {
 	Modify func(p Palette, isDark bool) Palette
 }
 
-var themeRegistry = map[string]ThemeSpec{}
+var (
+	registryMu    sync.RWMutex
+	themeRegistry = map[string]ThemeSpec{}
+)
 
 // -----------------------------------------------------------------------------
 // Registration
 // -----------------------------------------------------------------------------
 
-// RegisterTheme adds spec to the global theme registry.
-// Call this in an init function before the TUI starts.
-// RegisterTheme is not concurrency-safe; call only from init().
+// RegisterTheme adds spec to the global theme registry, replacing any
+// theme of the same name. It is safe for concurrent use; palettes already
+// cached by the [Manager] are rebuilt by [Manager.Reload].
 func RegisterTheme(spec ThemeSpec) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
 	themeRegistry[spec.Name] = spec
 }
 
+// lookupTheme returns the registered theme called name.
+func lookupTheme(name string) (ThemeSpec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	spec, ok := themeRegistry[name]
+	return spec, ok
+}
+
 // AvailableThemes returns the sorted names of all registered themes.
 func AvailableThemes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
 	names := make([]string, 0, len(themeRegistry))
 	for name := range themeRegistry {
 		names = append(names, name)
@@ -386,9 +461,9 @@ func buildPalette(spec ThemeSpec, isDark bool) Palette {
 // If "default" is also not registered, it uses hardcoded sentinel colors.
 // isDark selects the dark or light variant.
 func NewPalette(name string, isDark bool) Palette {
-	spec, ok := themeRegistry[name]
+	spec, ok := lookupTheme(name)
 	if !ok {
-		spec, ok = themeRegistry["default"]
+		spec, ok = lookupTheme("default")
 		if !ok {
 			// Fallback sentinel colors
 			spec = ThemeSpec{
@@ -556,46 +631,77 @@ type Styles struct {
 	MaxWidth    int
 }
 
-// newStylesFromPalette creates Styles from a Palette.
-func newStylesFromPalette(p Palette, width int) Styles {
+// MaxWidth returns the content width used for a terminal of the given width.
+func MaxWidth(width int) int {
 	maxWidth := width * 90 / 100
 	if maxWidth < 40 {
 		maxWidth = width - 4
 	}
+	return maxWidth
+}
 
-	return Styles{
+// newStylesFromPalette creates Styles from a Palette. Compact styles drop
+// the vertical padding, margins and borders around the header and footer.
+func newStylesFromPalette(p Palette, width int, compact bool) Styles {
+	maxWidth := MaxWidth(width)
+
+	s := Styles{
 		MaxWidth: maxWidth,
 		App:      lipgloss.NewStyle().Width(maxWidth).Padding(0, 0),
-		Header:   lipgloss.NewStyle().Padding(2).MarginBottom(0).PaddingBottom(0),
+		Header:   HeaderStyle(compact),
 		PlainTitle: lipgloss.NewStyle().
 			Bold(true).
 			Foreground(p.Primary).
 			Border(lipgloss.NormalBorder(), false, false, true, false).
 			BorderForeground(p.Secondary).
 			PaddingBottom(1),
-		Body: lipgloss.NewStyle().Padding(0, 3).Foreground(p.Foreground),
-		Help: lipgloss.NewStyle().MarginTop(0).Padding(0, 3),
-		Footer: lipgloss.NewStyle().
-			MarginTop(1).
-			Border(lipgloss.RoundedBorder(), true).
-			BorderForeground(p.Border).
-			PaddingLeft(1),
-		StatusLeft: lipgloss.NewStyle().
-			Background(p.PrimaryMuted).
-			Foreground(p.OnPrimary).
+		Body:   lipgloss.NewStyle().Padding(0, 3).Foreground(p.Foreground),
+		Help:   lipgloss.NewStyle().MarginTop(0).Padding(0, 3),
+		Footer: FooterStyle(p, compact),
+		StatusLeft: p.Fill(lipgloss.NewStyle(), p.PrimaryMuted, p.OnPrimary).
 			Bold(true),
 		StatusRight: lipgloss.NewStyle().Foreground(p.ForegroundSubtle),
 	}
+	if compact {
+		s.PlainTitle = s.PlainTitle.PaddingBottom(0)
+	}
+	return s
+}
+
+// HeaderStyle returns the frame around the header banner or title.
+func HeaderStyle(compact bool) lipgloss.Style {
+	if compact {
+		return lipgloss.NewStyle().Padding(0, 2)
+	}
+	return lipgloss.NewStyle().Padding(2).MarginBottom(0).PaddingBottom(3)
+}
+
+// FooterStyle returns the frame around the status bar. The compact footer
+// is a single line without the top margin and border.
+func FooterStyle(p Palette, compact bool) lipgloss.Style {
+	if compact {
+		return lipgloss.NewStyle().PaddingLeft(1)
+	}
+	return lipgloss.NewStyle().
+		MarginTop(1).
+		Border(lipgloss.RoundedBorder(), true).
+		BorderForeground(p.Border).
+		PaddingLeft(1)
 }
 
 // New creates Styles with adaptive colors for the given theme name.
 func New(name string, isDark bool, width int) Styles {
-	return newStylesFromPalette(NewPalette(name, isDark), width)
+	return newStylesFromPalette(NewPalette(name, isDark), width, false)
 }
 
 // NewFromPalette creates Styles from an existing Palette (avoids recalculation).
 func NewFromPalette(p Palette, width int) Styles {
-	return newStylesFromPalette(p, width)
+	return newStylesFromPalette(p, width, false)
+}
+
+// NewFromState creates Styles for a theme state, honoring its density.
+func NewFromState(s State) Styles {
+	return newStylesFromPalette(s.Palette, s.Width, s.Compact)
 }
 
 // DetailStyles holds styles for the detail screen.
@@ -677,9 +783,7 @@ func ListStyles(p Palette) list.Styles {
 	s := list.DefaultStyles(false)
 
 	s.TitleBar = lipgloss.NewStyle().Padding(0, 0, 1, 2)
-	s.Title = lipgloss.NewStyle().
-		Background(p.Primary).
-		Foreground(p.OnPrimary).
+	s.Title = p.Fill(lipgloss.NewStyle(), p.Primary, p.OnPrimary).
 		Padding(0, 1)
 	s.Spinner = lipgloss.NewStyle().Foreground(p.Primary)
 	s.PaginationStyle = lipgloss.NewStyle().Foreground(p.ForegroundSubtle).PaddingLeft(2)
@@ -694,6 +798,29 @@ func ListStyles(p Palette) list.Styles {
 	return s
 }
 
+// TextAreaStyles creates textarea.Styles from a Palette. The cursor line is
+// highlighted only while focused.
+func TextAreaStyles(p Palette) textarea.Styles {
+	var s textarea.Styles
+	s.Focused = textarea.StyleState{
+		Base:             lipgloss.NewStyle(),
+		Text:             lipgloss.NewStyle().Foreground(p.Foreground),
+		LineNumber:       lipgloss.NewStyle().Foreground(p.ForegroundSubtle),
+		CursorLineNumber: lipgloss.NewStyle().Foreground(p.Primary),
+		CursorLine:       lipgloss.NewStyle().Background(p.SurfaceRaised),
+		EndOfBuffer:      lipgloss.NewStyle().Foreground(p.ForegroundSubtle),
+		Placeholder:      lipgloss.NewStyle().Foreground(p.ForegroundSubtle),
+		Prompt:           lipgloss.NewStyle().Foreground(p.Primary),
+	}
+	s.Blurred = s.Focused
+	s.Blurred.Text = lipgloss.NewStyle().Foreground(p.ForegroundMuted)
+	s.Blurred.CursorLineNumber = s.Blurred.LineNumber
+	s.Blurred.CursorLine = lipgloss.NewStyle().Foreground(p.ForegroundMuted)
+	s.Blurred.Prompt = lipgloss.NewStyle().Foreground(p.Secondary)
+	s.Cursor = textarea.CursorStyle{Color: p.Primary, Shape: tea.CursorBlock, Blink: true}
+	return s
+}
+
 // ListItemStyles creates list.DefaultItemStyles from a Palette.
 func ListItemStyles(p Palette) list.DefaultItemStyles {
 	s := list.NewDefaultItemStyles(false)
@@ -715,6 +842,9 @@ func ListItemStyles(p Palette) list.DefaultItemStyles {
 
 	// Filter match
 	s.FilterMatch = lipgloss.NewStyle().Foreground(p.Primary)
+	if p.Monochrome() {
+		s.FilterMatch = s.FilterMatch.Underline(true)
+	}
 
 	return s
 }
@@ -0,0 +1,363 @@
+// Package toast provides transient notifications that stack in the
+// bottom-right corner of the body, composited over the screen with
+// modal.Composite. Each toast has a title, a body, an icon for its kind, a
+// bar showing its remaining lifetime and an optional action.
+//
+// Toasts are raised with commands that mirror the status package:
+// toast.Info, toast.Success, toast.Warning and toast.Error, or toast.Show
+// for a fully specified Toast. Backend delivers notify notifications as
+// toasts.
+package toast
+
+import (
+	"image/color"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"scaffold/internal/notify"
+	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/status"
+	"scaffold/internal/ui/theme"
+)
+
+// Default lifetimes, matching the status line.
+var (
+	DefaultInfoDuration    = 4 * time.Second
+	DefaultSuccessDuration = 4 * time.Second
+	DefaultWarningDuration = 6 * time.Second
+	DefaultErrorDuration   = 8 * time.Second
+)
+
+// Layout limits.
+const (
+	Width        = 40 // outer width of a toast, border included
+	MaxVisible   = 4  // older toasts wait until a slot frees up
+	tickInterval = 100 * time.Millisecond
+)
+
+// Action is an optional follow-up offered by a toast. Msg is sent when the
+// user triggers it with the Act key or a click, and the toast is dismissed.
+type Action struct {
+	Label string
+	Msg   tea.Msg
+}
+
+// Toast is one notification. A zero Duration means the default for Kind.
+type Toast struct {
+	Title    string
+	Body     string
+	Kind     status.Kind
+	Duration time.Duration
+	Action   *Action
+}
+
+// ShowMsg adds a toast to the stack.
+type ShowMsg struct {
+	Toast Toast
+}
+
+// TickMsg advances toast lifetimes. The stack schedules it while toasts
+// are shown.
+type TickMsg struct{}
+
+// Show returns a command that shows t.
+func Show(t Toast) tea.Cmd {
+	return func() tea.Msg { return ShowMsg{Toast: t} }
+}
+
+// Info shows an informational toast.
+// If duration is 0, uses DefaultInfoDuration.
+func Info(title, body string, duration time.Duration) tea.Cmd {
+	return Show(Toast{Title: title, Body: body, Kind: status.KindInfo, Duration: duration})
+}
+
+// Success shows a success toast.
+// If duration is 0, uses DefaultSuccessDuration.
+func Success(title, body string, duration time.Duration) tea.Cmd {
+	return Show(Toast{Title: title, Body: body, Kind: status.KindSuccess, Duration: duration})
+}
+
+// Warning shows a warning toast.
+// If duration is 0, uses DefaultWarningDuration.
+func Warning(title, body string, duration time.Duration) tea.Cmd {
+	return Show(Toast{Title: title, Body: body, Kind: status.KindWarning, Duration: duration})
+}
+
+// Error shows an error toast.
+// If duration is 0, uses DefaultErrorDuration.
+func Error(title, body string, duration time.Duration) tea.Cmd {
+	return Show(Toast{Title: title, Body: body, Kind: status.KindError, Duration: duration})
+}
+
+// Backend is the notify.Backend that shows notifications as toasts.
+type Backend struct{}
+
+// Deliver implements notify.Backend.
+func (Backend) Deliver(n notify.Notification) tea.Cmd {
+	kind := status.KindInfo
+	switch n.Kind {
+	case notify.KindError:
+		kind = status.KindError
+	case notify.KindSuccess:
+		kind = status.KindSuccess
+	}
+	return Show(Toast{Title: n.Title, Body: n.Body, Kind: kind})
+}
+
+// External implements notify.Backend: toasts stay inside the app.
+func (Backend) External() bool { return false }
+
+// KeyMap holds the stack's bindings.
+type KeyMap struct {
+	Act key.Binding // runs the newest toast's action
+}
+
+func init() {
+	keys.Register(keys.Def{ID: "toast.act", Keys: []string{"ctrl+y"}, Desc: "toast.keys.act"})
+}
+
+// DefaultKeyMap returns the stack bindings with user overrides applied and
+// help text in the current language.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{Act: keys.Bind("toast.act")}
+}
+
+// entry is a toast on the stack with its remaining lifetime.
+type entry struct {
+	Toast
+	total, left time.Duration
+}
+
+// Model is the toast stack. The zero value is empty and unstyled.
+type Model struct {
+	entries []entry // oldest first
+	ticking bool
+	keys    KeyMap
+	palette theme.Palette
+}
+
+// New creates an empty stack.
+func New() Model {
+	return Model{keys: DefaultKeyMap()}
+}
+
+// Keys returns the stack's bindings.
+func (m Model) Keys() KeyMap { return m.keys }
+
+// WithLanguage returns the stack with key help in the current language.
+func (m Model) WithLanguage() Model {
+	m.keys = DefaultKeyMap()
+	return m
+}
+
+// ApplyTheme styles toasts from the palette.
+func (m *Model) ApplyTheme(state theme.State) {
+	m.palette = state.Palette
+}
+
+// Len returns the number of toasts, including those waiting to be shown.
+func (m Model) Len() int { return len(m.entries) }
+
+// HasAction reports whether a visible toast offers an action.
+func (m Model) HasAction() bool {
+	for _, e := range m.visible() {
+		if e.Action != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Update handles ShowMsg and TickMsg, and the Act key while a toast offers
+// an action.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ShowMsg:
+		d := msg.Toast.Duration
+		if d <= 0 {
+			d = defaultDuration(msg.Toast.Kind)
+		}
+		m.entries = append(m.entries, entry{Toast: msg.Toast, total: d, left: d})
+		return m.startTicking()
+
+	case TickMsg:
+		m.ticking = false
+		// Only visible toasts age; queued ones wait for a slot.
+		n := min(len(m.entries), MaxVisible)
+		kept := m.entries[:0]
+		for i, e := range m.entries {
+			if i < n {
+				e.left -= tickInterval
+				if e.left <= 0 {
+					continue
+				}
+			}
+			kept = append(kept, e)
+		}
+		m.entries = kept
+		return m.startTicking()
+
+	case tea.KeyPressMsg:
+		if key.Matches(msg, m.keys.Act) {
+			vis := m.visible()
+			for i := len(vis) - 1; i >= 0; i-- {
+				if vis[i].Action != nil {
+					return m.act(i)
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// Click runs the action of the toast at (x, y), relative to the stack's
+// top-left corner, and dismisses it. ok is false when no toast is there.
+func (m Model) Click(x, y int) (_ Model, _ tea.Cmd, ok bool) {
+	if x < 0 || x >= Width {
+		return m, nil, false
+	}
+	row := 0
+	for i, e := range m.visible() {
+		h := lipgloss.Height(m.renderToast(e))
+		if y >= row && y < row+h {
+			if e.Action == nil {
+				m.entries = append(m.entries[:i:i], m.entries[i+1:]...)
+				return m, nil, true
+			}
+			m, cmd := m.act(i)
+			return m, cmd, true
+		}
+		row += h
+	}
+	return m, nil, false
+}
+
+// View renders the visible toasts, oldest on top, or "" when there are
+// none.
+func (m Model) View() string {
+	vis := m.visible()
+	if len(vis) == 0 {
+		return ""
+	}
+	rendered := make([]string, len(vis))
+	for i, e := range vis {
+		rendered[i] = m.renderToast(e)
+	}
+	return lipgloss.JoinVertical(lipgloss.Right, rendered...)
+}
+
+// visible returns the toasts currently on screen.
+func (m Model) visible() []entry {
+	return m.entries[:min(len(m.entries), MaxVisible)]
+}
+
+// act dismisses visible toast i and sends its action message.
+func (m Model) act(i int) (Model, tea.Cmd) {
+	action := m.entries[i].Action
+	m.entries = append(m.entries[:i:i], m.entries[i+1:]...)
+	if action == nil || action.Msg == nil {
+		return m, nil
+	}
+	msg := action.Msg
+	return m, func() tea.Msg { return msg }
+}
+
+// startTicking schedules the next TickMsg while toasts remain.
+func (m Model) startTicking() (Model, tea.Cmd) {
+	if m.ticking || len(m.entries) == 0 {
+		return m, nil
+	}
+	m.ticking = true
+	return m, tea.Tick(tickInterval, func(time.Time) tea.Msg { return TickMsg{} })
+}
+
+// renderToast draws one toast: icon badge and title, wrapped body, action
+// hint and the lifetime bar, inside a border in the kind's color.
+func (m Model) renderToast(e entry) string {
+	p := m.palette
+	accent, onAccent := kindColors(p, e.Kind)
+	inner := Width - 4 // border and horizontal padding
+
+	badge := p.Fill(lipgloss.NewStyle().Bold(true), accent, onAccent).
+		Render(" " + icon(e.Kind) + " ")
+	title := lipgloss.NewStyle().Bold(true).Foreground(p.Foreground).
+		Width(inner - lipgloss.Width(badge) - 1).
+		Render(e.Title)
+	lines := []string{lipgloss.JoinHorizontal(lipgloss.Top, badge, " ", title)}
+	if e.Body != "" {
+		lines = append(lines, lipgloss.NewStyle().Foreground(p.ForegroundMuted).Width(inner).Render(e.Body))
+	}
+	if e.Action != nil {
+		hint := lipgloss.NewStyle().Foreground(accent).Bold(true).Render(m.keys.Act.Help().Key) +
+			" " + lipgloss.NewStyle().Foreground(p.Foreground).Render(e.Action.Label)
+		lines = append(lines, hint)
+	}
+	lines = append(lines, progressBar(p, accent, inner, float64(e.left)/float64(e.total)))
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(accent).
+		Padding(0, 1).
+		Width(Width).
+		Render(strings.Join(lines, "\n"))
+}
+
+// progressBar renders the remaining fraction of a toast's lifetime. Without
+// colors the elapsed part is drawn thin.
+func progressBar(p theme.Palette, accent color.Color, width int, frac float64) string {
+	filled := int(float64(width)*min(max(frac, 0), 1) + 0.5)
+	elapsed := "━"
+	if p.Monochrome() {
+		elapsed = "─"
+	}
+	return lipgloss.NewStyle().Foreground(accent).Render(strings.Repeat("━", filled)) +
+		lipgloss.NewStyle().Foreground(p.BorderMuted).Render(strings.Repeat(elapsed, width-filled))
+}
+
+// kindColors returns the accent color for kind and the text color on it.
+func kindColors(p theme.Palette, kind status.Kind) (accent, on color.Color) {
+	switch kind {
+	case status.KindSuccess:
+		return p.Success, p.OnSuccess
+	case status.KindWarning:
+		return p.Warning, p.OnWarning
+	case status.KindError:
+		return p.Error, p.OnError
+	case status.KindInfo:
+		return p.Info, p.OnInfo
+	default:
+		return p.Primary, p.OnPrimary
+	}
+}
+
+// icon returns the badge glyph for kind.
+func icon(kind status.Kind) string {
+	switch kind {
+	case status.KindSuccess:
+		return "✓"
+	case status.KindWarning:
+		return "!"
+	case status.KindError:
+		return "✗"
+	default:
+		return "i"
+	}
+}
+
+// defaultDuration returns the lifetime of a toast of kind without one.
+func defaultDuration(kind status.Kind) time.Duration {
+	switch kind {
+	case status.KindSuccess:
+		return DefaultSuccessDuration
+	case status.KindWarning:
+		return DefaultWarningDuration
+	case status.KindError:
+		return DefaultErrorDuration
+	default:
+		return DefaultInfoDuration
+	}
+}
@@ -0,0 +1,119 @@
+package toast
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"scaffold/internal/notify"
+	"scaffold/internal/ui/status"
+)
+
+// show adds t to m.
+func show(m Model, t Toast) Model {
+	m, _ = m.Update(Show(t)())
+	return m
+}
+
+// tick advances m by d.
+func tick(m Model, d time.Duration) Model {
+	for ; d > 0; d -= tickInterval {
+		m, _ = m.Update(TickMsg{})
+	}
+	return m
+}
+
+func TestShow_DefaultsDurationByKind(t *testing.T) {
+	msg := Error("Sync failed", "boom", 0)().(ShowMsg)
+	assert.Equal(t, Toast{Title: "Sync failed", Body: "boom", Kind: status.KindError}, msg.Toast)
+
+	m := show(New(), msg.Toast)
+	assert.Equal(t, DefaultErrorDuration, m.entries[0].total)
+
+	m = show(New(), Toast{Title: "t", Kind: status.KindInfo, Duration: time.Second})
+	assert.Equal(t, time.Second, m.entries[0].total)
+}
+
+func TestUpdate_TicksUntilExpired(t *testing.T) {
+	m, cmd := New().Update(ShowMsg{Toast: Toast{Title: "t", Duration: time.Second}})
+	require.NotNil(t, cmd, "showing a toast starts the tick")
+
+	_, cmd = m.Update(ShowMsg{Toast: Toast{Title: "u", Duration: time.Second}})
+	assert.Nil(t, cmd, "only one tick runs at a time")
+
+	m = tick(m, time.Second-tickInterval)
+	assert.Equal(t, 1, m.Len())
+	m, cmd = m.Update(TickMsg{})
+	assert.Equal(t, 0, m.Len())
+	assert.Nil(t, cmd, "the tick stops with the last toast")
+	assert.Empty(t, m.View())
+}
+
+func TestUpdate_QueuesBeyondMaxVisible(t *testing.T) {
+	m := New()
+	for i := range MaxVisible + 1 {
+		m = show(m, Toast{Title: string(rune('a' + i)), Duration: time.Duration(i+1) * time.Second})
+	}
+	assert.Len(t, m.visible(), MaxVisible)
+
+	// The queued toast doesn't age until the first one expires.
+	m = tick(m, time.Second)
+	require.Equal(t, MaxVisible, m.Len())
+	last := m.visible()[MaxVisible-1]
+	assert.Equal(t, "e", last.Title)
+	assert.Equal(t, last.total, last.left)
+}
+
+func TestUpdate_ActRunsNewestAction(t *testing.T) {
+	m := show(New(), Toast{Title: "older", Action: &Action{Label: "Open", Msg: "older"}})
+	m = show(m, Toast{Title: "newer", Action: &Action{Label: "Retry", Msg: "newer"}})
+	m = show(m, Toast{Title: "plain"})
+	require.True(t, m.HasAction())
+
+	m, cmd := m.Update(tea.KeyPressMsg{Code: 'y', Mod: tea.ModCtrl})
+	require.NotNil(t, cmd)
+	assert.Equal(t, "newer", cmd())
+	assert.Equal(t, 2, m.Len(), "acting dismisses the toast")
+}
+
+func TestClick_HitsToastUnderPointer(t *testing.T) {
+	m := show(New(), Toast{Title: "first", Body: "body"})
+	m = show(m, Toast{Title: "second", Action: &Action{Label: "Undo", Msg: "undo"}})
+	firstH := lipgloss.Height(m.renderToast(m.entries[0]))
+
+	_, _, ok := m.Click(Width, 0)
+	assert.False(t, ok)
+
+	m2, cmd, ok := m.Click(1, firstH)
+	require.True(t, ok)
+	require.NotNil(t, cmd)
+	assert.Equal(t, "undo", cmd())
+	assert.Equal(t, 1, m2.Len())
+
+	m2, cmd, ok = m.Click(1, 0)
+	require.True(t, ok)
+	assert.Nil(t, cmd, "clicking a toast without an action dismisses it")
+	assert.Equal(t, "second", m2.entries[0].Title)
+}
+
+func TestView_RendersTitleBodyAndAction(t *testing.T) {
+	m := show(New(), Toast{Title: "Saved", Body: "config.json", Kind: status.KindSuccess,
+		Action: &Action{Label: "Open", Msg: "open"}})
+	view := m.View()
+	assert.Contains(t, view, "Saved")
+	assert.Contains(t, view, "config.json")
+	assert.Contains(t, view, "Open")
+	assert.Equal(t, Width, lipgloss.Width(view))
+}
+
+func TestBackend_ShowsNotificationAsToast(t *testing.T) {
+	msg := Backend{}.Deliver(notify.Notification{Title: "Task failed", Body: "sync: boom", Kind: notify.KindError})()
+	show, ok := msg.(ShowMsg)
+	require.True(t, ok)
+	assert.Equal(t, Toast{Title: "Task failed", Body: "sync: boom", Kind: status.KindError}, show.Toast)
+	assert.False(t, Backend{}.External(), "quiet hours keep toasts")
+}
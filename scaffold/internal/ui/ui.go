@@ -13,8 +13,12 @@ import (
 // ctx and cancel are the application-wide context for graceful shutdown.
 // configPath is the path to persist settings; empty means no file save.
 // firstRun indicates that no config file existed before this launch.
-func New(ctx context.Context, cancel context.CancelFunc, cfg config.Config, configPath string, firstRun bool) rootModel {
-	return newRootModel(ctx, cancel, cfg, configPath, firstRun)
+// secretsErr is the error loading cfg's secrets, if any; it is reported on
+// start, and the secrets it names are not overwritten when saving.
+func New(ctx context.Context, cancel context.CancelFunc, cfg config.Config, configPath string, firstRun bool, secretsErr error) rootModel {
+	m := newRootModel(ctx, cancel, cfg, configPath, firstRun)
+	m.secretsErr = secretsErr
+	return m
 }
 
 // Run starts the TUI program. ctx is used to cancel background goroutines on quit.
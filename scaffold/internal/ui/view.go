@@ -2,15 +2,30 @@
 package ui
 
 import (
+	"strings"
+
 	"charm.land/bubbles/v2/key"
 	"charm.land/lipgloss/v2"
 
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/keyhelp"
 	"scaffold/internal/ui/keys"
+	"scaffold/internal/ui/mouse"
 	"scaffold/internal/ui/screens"
+	"scaffold/internal/ui/theme"
 )
 
-// helpView renders the persistent help box showing global and screen-specific keybindings.
+// helpView renders the persistent help box showing global and screen-specific
+// keybindings, or "" when UIConfig.ShowHelpBar is off.
 func (m rootModel) helpView() string {
+	if !m.effectiveCfg().UI.ShowHelpBar {
+		return ""
+	}
+	return m.helpBar()
+}
+
+// helpBar renders the help bar, whether or not it is shown.
+func (m rootModel) helpBar() string {
 	combined := m.combinedKeys()
 	return m.styles.Help.Render(m.help.View(combined))
 }
@@ -18,19 +33,25 @@ func (m rootModel) helpView() string {
 // combinedKeys returns a key map that combines global keys with screen-specific keys.
 func (m rootModel) combinedKeys() combinedKeyMap {
 	return combinedKeyMap{
-		global: m.keys,
-		screen: m.current,
+		global:  m.keys,
+		screen:  m.current,
+		pending: m.chords.Pending(),
 	}
 }
 
-// combinedKeyMap combines global and screen-specific key bindings.
+// combinedKeyMap combines global and screen-specific key bindings. While a
+// chord is pending its help lists only the keys that can finish the chord.
 type combinedKeyMap struct {
-	global keys.GlobalKeyMap
-	screen screens.Screen
+	global  keys.GlobalKeyMap
+	screen  screens.Screen
+	pending []string
 }
 
 // ShortHelp returns combined short help bindings.
 func (c combinedKeyMap) ShortHelp() []key.Binding {
+	if c.pending != nil {
+		return c.continuations()
+	}
 	bindings := c.global.ShortHelp()
 	if kb, ok := c.screen.(screens.KeyBinder); ok {
 		bindings = append(bindings, kb.ShortHelp()...)
@@ -40,6 +61,9 @@ func (c combinedKeyMap) ShortHelp() []key.Binding {
 
 // FullHelp returns combined full help bindings.
 func (c combinedKeyMap) FullHelp() [][]key.Binding {
+	if c.pending != nil {
+		return [][]key.Binding{c.continuations()}
+	}
 	groups := c.global.FullHelp()
 	if kb, ok := c.screen.(screens.KeyBinder); ok {
 		groups = append(groups, kb.FullHelp()...)
@@ -47,12 +71,33 @@ func (c combinedKeyMap) FullHelp() [][]key.Binding {
 	return groups
 }
 
-// Layout constants document the fixed-height chrome components.
-// Header and help heights are dynamic (banner height varies; help wraps at
-// narrow terminals), so they are measured at runtime and cached in rootModel.bodyH.
+// Bindings returns every global binding and the current screen's, the
+// candidates for starting or finishing a chord.
+func (c combinedKeyMap) Bindings() []key.Binding {
+	bindings := c.global.Bindings()
+	if kb, ok := c.screen.(screens.KeyBinder); ok {
+		for _, g := range kb.FullHelp() {
+			bindings = append(bindings, g...)
+		}
+	}
+	return bindings
+}
+
+// continuations lists the keys that finish the pending chord, labelled
+// with the full chord, followed by esc to cancel it.
+func (c combinedKeyMap) continuations() []key.Binding {
+	next := keys.Continuations(c.pending, c.Bindings())
+	for i, b := range next {
+		next[i].SetHelp(b.Keys()[0], b.Help().Desc)
+	}
+	return append(next, key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", i18n.T("keys.cancelChord"))))
+}
+
+// Layout constants bound the body height. Header, help and footer heights
+// are dynamic (banner height varies; help wraps at narrow terminals; compact
+// mode drops padding and borders), so they are measured at runtime and
+// cached in rootModel.bodyH.
 const (
-	// footerLines is the number of terminal lines the footer chrome occupies.
-	footerLines = 1
 	// minBodyLines is the minimum body height guaranteed by View().
 	minBodyLines = 1
 	// maxBodyPercent is the maximum percentage of terminal height the body can occupy.
@@ -61,16 +106,28 @@ const (
 
 // bodyHeight estimates the available height for the body content area.
 // It subtracts the header, help, and footer chrome from the terminal height,
-// then caps the result at maxBodyPercent of the terminal height.
+// then caps the result at maxBodyPercent of the terminal height. A hidden
+// help bar takes no rows and raises the cap by the rows it frees. In compact
+// mode the cap never drops below minBodyLines plus the rows the compact
+// chrome frees, so short terminals keep them while tall ones lay out as
+// before.
 func (m rootModel) bodyHeight() int {
 	if m.height == 0 {
 		return 0
 	}
-	helpH := lipgloss.Height(m.helpView())
-	body := m.height - m.header.Height() - helpH - footerLines
+	helpH := lipgloss.Height(m.helpBar())
+	body := m.height - m.header.Height() - m.statusbar.Height()
 
 	// Cap at maxBodyPercent of terminal height
 	maxBody := m.height * maxBodyPercent / 100
+	if m.effectiveCfg().UI.ShowHelpBar {
+		body -= helpH
+	} else {
+		maxBody += helpH
+	}
+	if m.effectiveCfg().UI.CompactMode {
+		maxBody = max(maxBody, minBodyLines+compactSavings(m.themeMgr.State().Palette))
+	}
 	if body > maxBody {
 		body = maxBody
 	}
@@ -80,3 +137,94 @@ func (m rootModel) bodyHeight() int {
 	}
 	return body
 }
+
+// compactSavings returns the rows the compact header and footer free: the
+// vertical frames of the normal ones.
+func compactSavings(p theme.Palette) int {
+	frame := func(normal, compact lipgloss.Style) int {
+		return normal.GetVerticalFrameSize() - compact.GetVerticalFrameSize()
+	}
+	return frame(theme.HeaderStyle(false), theme.HeaderStyle(true)) +
+		frame(theme.FooterStyle(p, false), theme.FooterStyle(p, true))
+}
+
+// bodyScroll is the mouse-wheel scroll position of an overflowing body. It
+// belongs to one screen, so navigating away implicitly resets it.
+type bodyScroll struct {
+	owner  screens.Screen
+	offset int
+}
+
+// bodyOffset returns the scroll offset of the current screen's body.
+func (m rootModel) bodyOffset() int {
+	if m.scroll.owner != m.current {
+		return 0
+	}
+	return min(m.scroll.offset, m.bodyOverflow())
+}
+
+// bodyOverflow returns how many lines of the current body don't fit in bodyH.
+func (m rootModel) bodyOverflow() int {
+	return max(lipgloss.Height(m.current.Body())-m.bodyH, 0)
+}
+
+// bodyView returns the current body scrolled to bodyOffset.
+func (m rootModel) bodyView() string {
+	body := m.current.Body()
+	offset := m.bodyOffset()
+	if offset == 0 {
+		return body
+	}
+	lines := strings.Split(body, "\n")
+	return strings.Join(lines[offset:], "\n")
+}
+
+// bodyRect returns the terminal region the body content occupies, excluding
+// the body style's padding.
+func (m rootModel) bodyRect() mouse.Rect {
+	return mouse.Rect{
+		X: m.styles.Body.GetPaddingLeft(),
+		Y: lipgloss.Height(m.header.View().Content) + m.styles.Body.GetPaddingTop(),
+		W: m.styles.MaxWidth - m.styles.Body.GetHorizontalFrameSize(),
+		H: m.bodyH,
+	}
+}
+
+// toastRect returns the terminal region of the rendered toast stack, in
+// the bottom-right corner of the body.
+func (m rootModel) toastRect(stack string) mouse.Rect {
+	body := m.bodyRect()
+	w, h := lipgloss.Width(stack), lipgloss.Height(stack)
+	return mouse.Rect{
+		X: max(body.X+body.W-w, 0),
+		Y: max(body.Y+body.H-h, 0),
+		W: w,
+		H: h,
+	}
+}
+
+// fullHelpSections groups the global bindings and the current screen's for
+// the full-help overlay.
+func (m rootModel) fullHelpSections() []keyhelp.Section {
+	sections := []keyhelp.Section{{Title: i18n.T("help.global"), Groups: m.keys.FullHelp()}}
+	if kb, ok := m.current.(screens.KeyBinder); ok {
+		sections = append(sections, keyhelp.Section{Title: i18n.T("help.screen"), Groups: kb.FullHelp()})
+	}
+	return sections
+}
+
+// openFullHelp (re)builds the full-help overlay for the current screen,
+// theme, language and terminal height.
+func (m rootModel) openFullHelp() rootModel {
+	m.fullHelp = keyhelp.New(m.fullHelpSections(), m.themeMgr.State().Palette, m.height)
+	return m
+}
+
+// refreshFullHelp rebuilds the full-help overlay if it is open, after a
+// resize, theme or language change.
+func (m rootModel) refreshFullHelp() rootModel {
+	if !m.fullHelp.Visible() {
+		return m
+	}
+	return m.openFullHelp()
+}
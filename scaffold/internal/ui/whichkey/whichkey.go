@@ -0,0 +1,71 @@
+// Package whichkey provides the popup shown while a chord is pending: the
+// keys typed so far and every key that can follow, with what it does.
+// rootModel draws it over the bottom-left corner of the body.
+package whichkey
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/lipgloss/v2"
+
+	"scaffold/internal/i18n"
+	"scaffold/internal/ui/theme"
+)
+
+// styles holds the popup's themed styles.
+type styles struct {
+	box   lipgloss.Style
+	title lipgloss.Style
+	key   lipgloss.Style
+	desc  lipgloss.Style
+	muted lipgloss.Style
+}
+
+// Model is the which-key popup. The zero value is invisible.
+type Model struct {
+	pending []string
+	next    []key.Binding
+	styles  styles
+}
+
+// New creates a popup for the pending keys, listing next, the bindings
+// that finish a chord from there as returned by keys.Continuations.
+func New(pending []string, next []key.Binding, p theme.Palette) Model {
+	return Model{
+		pending: pending,
+		next:    next,
+		styles: styles{
+			box: lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(p.Border).
+				Padding(0, 1),
+			title: lipgloss.NewStyle().Bold(true).Foreground(p.Primary),
+			key:   lipgloss.NewStyle().Foreground(p.Secondary),
+			desc:  lipgloss.NewStyle().Foreground(p.Foreground),
+			muted: lipgloss.NewStyle().Foreground(p.ForegroundMuted),
+		},
+	}
+}
+
+// Visible reports whether a chord is pending.
+func (m Model) Visible() bool { return len(m.pending) > 0 }
+
+// View renders the popup, or "" when it is invisible.
+func (m Model) View() string {
+	if !m.Visible() {
+		return ""
+	}
+	keyW := 0
+	for _, b := range m.next {
+		keyW = max(keyW, lipgloss.Width(b.Help().Key))
+	}
+	keySty := m.styles.key.Width(keyW + 2)
+
+	rows := []string{m.styles.title.Render(strings.Join(m.pending, " ")) + m.styles.muted.Render(" …")}
+	for _, b := range m.next {
+		rows = append(rows, keySty.Render(b.Help().Key)+m.styles.desc.Render(b.Help().Desc))
+	}
+	rows = append(rows, m.styles.muted.Render(i18n.T("whichkey.hint")))
+	return m.styles.box.Render(strings.Join(rows, "\n"))
+}
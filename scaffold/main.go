@@ -30,7 +30,7 @@ func main() {
 	logger.Setup(cmd.IsDebugMode())
 	defer logger.Close()
 
-	cfg, configPath := loadConfig()
+	cfg, configPath, secretsErr := loadConfig()
 
 	// Re-initialize if config debug setting differs from CLI flag
 	if cfg.Debug {
@@ -57,18 +57,20 @@ func main() {
 	logger.Debug("first run: %v", firstRun)
 	logger.Debug("starting UI")
 
-	if err := ui.Run(ctx, ui.New(ctx, cancel, *cfg, configPath, firstRun)); err != nil {
+	if err := ui.Run(ctx, ui.New(ctx, cancel, *cfg, configPath, firstRun, secretsErr)); err != nil {
 		logger.Debug("Program exited: %v", err)
 		os.Exit(1)
 	}
 }
 
 // loadConfig builds the effective config following priority order:
-// defaults → config file → CLI flags (only when explicitly set).
-// Returns the config and the path to use (default path even if file doesn't exist yet).
-func loadConfig() (*config.Config, string) {
+// defaults → config file → profile overlay → secret store → CLI flags (only when explicitly set).
+// Returns the config and the path to use (default path even if file doesn't exist yet),
+// and any error reading the secret store, which the UI reports on start.
+func loadConfig() (*config.Config, string, error) {
 	cfg := config.DefaultConfig()
 	configPath := cmd.GetConfigFile() // Get default or explicit path
+	var secretsErr error
 
 	if configPath != "" {
 		fileCfg, err := config.Load(configPath)
@@ -80,6 +82,34 @@ func loadConfig() (*config.Config, string) {
 		}
 		// ErrConfigNotFound or parse error → silently fall back to defaults
 		// but keep configPath so first-run detection and saving work
+
+		// An explicit --profile wins over the base config's active profile.
+		profile := cfg.Profile
+		if cmd.WasProfileSet() {
+			profile = cmd.GetProfile()
+			cfg.Profile = profile
+		}
+		if profile != "" {
+			profCfg, err := config.LoadProfile(configPath, profile)
+			switch {
+			case err == nil:
+				cfg = profCfg
+				logger.Debug("applied profile: %s", profile)
+			case cmd.WasProfileSet():
+				fmt.Fprintf(os.Stderr, "Cannot use profile %q: %v\n", profile, err)
+				os.Exit(1)
+			default:
+				// Keep the selection: the UI warns about it and does not save
+				// over the profile until one is picked.
+				logger.Debug("active profile %q ignored: %v", profile, err)
+			}
+		}
+
+		// Secrets live outside config.json; hydrate cfg_secret fields last.
+		secretsErr = config.LoadSecrets(cfg, config.NewSecretStore(configPath))
+		if secretsErr != nil {
+			logger.Debug("secret load failed: %v", secretsErr)
+		}
 	}
 
 	// CLI flags override file/defaults only when explicitly passed.
@@ -87,5 +117,5 @@ func loadConfig() (*config.Config, string) {
 		cfg.Debug = true
 	}
 
-	return cfg, configPath
+	return cfg, configPath, secretsErr
 }